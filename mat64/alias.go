@@ -0,0 +1,78 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"unsafe"
+)
+
+// ErrOverlap is the panic value used when the receiver of an element-wise
+// operation shares storage with an operand other than element for element.
+const ErrOverlap = Error("mat64: receiver partially overlaps an operand")
+
+// rawOverlap returns whether the elements of the matrices a and b share any
+// storage, and whether they occupy identical storage, that is each element of
+// a is stored at the same location as the corresponding element of b.
+func rawOverlap(a, b RawMatrix) (overlap, identical bool) {
+	if a.Rows == 0 || a.Cols == 0 || b.Rows == 0 || b.Cols == 0 {
+		return false, false
+	}
+	const size = int(unsafe.Sizeof(float64(0)))
+	pa := uintptr(unsafe.Pointer(&a.Data[0]))
+	pb := uintptr(unsafe.Pointer(&b.Data[0]))
+	endA := pa + uintptr(((a.Rows-1)*a.Stride+a.Cols)*size)
+	endB := pb + uintptr(((b.Rows-1)*b.Stride+b.Cols)*size)
+	if pa >= endB || pb >= endA {
+		return false, false
+	}
+	if pa == pb && a.Stride == b.Stride && a.Rows == b.Rows && a.Cols == b.Cols {
+		return true, true
+	}
+	if a.Stride != b.Stride {
+		// Conservatively report overlap of interleaved storage.
+		return true, false
+	}
+
+	// With equal strides and the origin of b at element offset d from
+	// that of a, the element (i, j) of a coincides with (i', j') of b iff
+	// d = (i-i')*stride + (j-j'). Since the column differences are less
+	// than the stride in magnitude, only two row differences are possible.
+	if pb < pa {
+		pa, pb = pb, pa
+		a, b = b, a
+	}
+	d := int(pb-pa) / size
+	s := a.Stride
+	for _, i := range []int{d / s, d/s + 1} {
+		j := d - i*s
+		if i > -b.Rows && i < a.Rows && j > -b.Cols && j < a.Cols {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// checkOverlap panics with ErrOverlap if the receiver and a share storage other
+// than element for element. Operations that process elements in order may write
+// to an element of the receiver that is yet to be read from a in that case.
+func (m *Dense) checkOverlap(a Matrix) {
+	ra, ok := a.(RawMatrixer)
+	if !ok || m.isZero() {
+		return
+	}
+	if overlap, identical := rawOverlap(m.mat, ra.RawMatrix()); overlap && !identical {
+		panic(ErrOverlap)
+	}
+}
+
+// overlaps returns whether the receiver shares any storage with a.
+func (m *Dense) overlaps(a Matrix) bool {
+	ra, ok := a.(RawMatrixer)
+	if !ok || m.isZero() {
+		return false
+	}
+	overlap, _ := rawOverlap(m.mat, ra.RawMatrix())
+	return overlap
+}
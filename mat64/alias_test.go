@@ -0,0 +1,96 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestRawOverlap(c *check.C) {
+	base := NewDense(4, 6, nil)
+	view := func(i, j, r, cols int) RawMatrix {
+		var v Dense
+		v.View(base, i, j, r, cols)
+		return v.mat
+	}
+	for i, test := range []struct {
+		a, b               RawMatrix
+		overlap, identical bool
+	}{
+		{view(0, 0, 4, 6), view(0, 0, 4, 6), true, true},
+		{view(0, 0, 2, 3), view(0, 0, 2, 3), true, true},
+		{view(0, 0, 2, 3), view(0, 0, 2, 2), true, false},
+		{view(0, 0, 4, 3), view(0, 3, 4, 3), false, false},
+		{view(0, 3, 4, 3), view(0, 0, 4, 3), false, false},
+		{view(0, 0, 4, 3), view(0, 2, 4, 3), true, false},
+		{view(0, 0, 2, 6), view(2, 0, 2, 6), false, false},
+		{view(0, 0, 3, 6), view(2, 0, 2, 6), true, false},
+		{view(1, 1, 2, 2), view(2, 2, 2, 2), true, false},
+		{view(1, 1, 1, 2), view(2, 2, 2, 2), false, false},
+		{view(0, 4, 2, 2), view(1, 0, 2, 2), false, false},
+		{view(0, 0, 4, 6), NewDense(4, 6, nil).mat, false, false},
+	} {
+		overlap, identical := rawOverlap(test.a, test.b)
+		c.Check(overlap, check.Equals, test.overlap, check.Commentf("Test %d", i))
+		c.Check(identical, check.Equals, test.identical, check.Commentf("Test %d", i))
+	}
+}
+
+func (s *S) TestReceiverAliasing(c *check.C) {
+	a := NewDense(3, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 10,
+	})
+	b := NewDense(3, 3, []float64{
+		1, 0, 1,
+		2, 1, 0,
+		0, 3, 1,
+	})
+	var want Dense
+	want.Mul(a, b)
+
+	// In-place element-wise operations.
+	m := DenseCopyOf(a)
+	m.Add(m, b)
+	m.Sub(m, b)
+	c.Check(m.Equals(a), check.Equals, true)
+	m.Scale(2, m)
+	m.Scale(0.5, m)
+	c.Check(m.Equals(a), check.Equals, true)
+
+	// The product may be placed in either operand and views of the
+	// receiver remain valid.
+	m = DenseCopyOf(a)
+	var row Dense
+	row.View(m, 1, 0, 1, 3)
+	m.Mul(m, b)
+	c.Check(m.Equals(&want), check.Equals, true)
+	c.Check(row.At(0, 2), check.Equals, want.At(1, 2))
+
+	m = DenseCopyOf(b)
+	m.Mul(a, m)
+	c.Check(m.Equals(&want), check.Equals, true)
+
+	// Aliasing through a distinct Dense sharing storage is detected.
+	m = DenseCopyOf(a)
+	alias := *m
+	m.Mul(&alias, b)
+	c.Check(m.Equals(&want), check.Equals, true)
+
+	// Partial overlaps are rejected for element-wise operations.
+	big := NewDense(3, 4, nil)
+	var left, right Dense
+	left.View(big, 0, 0, 3, 3)
+	right.View(big, 0, 1, 3, 3)
+	c.Check(func() { left.Add(&right, b) }, check.PanicMatches, string(ErrOverlap))
+	c.Check(func() { left.Scale(2, &right) }, check.PanicMatches, string(ErrOverlap))
+	c.Check(func() { left.AbsElem(&right) }, check.PanicMatches, string(ErrOverlap))
+
+	// The product handles partial overlap through the temporary.
+	right.Copy(a)
+	left.Mul(&right, b)
+	c.Check(left.Equals(&want), check.Equals, true)
+}
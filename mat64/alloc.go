@@ -0,0 +1,48 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// An Allocator provides the storage of matrices, allowing it to be placed in
+// memory managed outside the Go heap, such as an arena, locked pages, huge pages
+// or memory bound to a NUMA node. Alloc and Free must be safe for concurrent use.
+type Allocator interface {
+	// Alloc returns a slice of length n with zeroed elements.
+	Alloc(n int) []float64
+
+	// Free is called with a slice that is no longer used by the package,
+	// as passed to PutFloats or PutDense. Free may be passed a slice that
+	// was not returned by Alloc, such as one allocated before the
+	// Allocator was set, and should then ignore it.
+	Free(f []float64)
+}
+
+var allocator Allocator
+
+// SetAllocator sets the Allocator used for the storage of new matrices and
+// returns the previous setting. A nil a restores the default, which allocates
+// from the Go heap. When an Allocator is set, NewDense and the operations that
+// allocate storage for an empty receiver obtain it from Alloc, and GetFloats and
+// PutFloats pass their requests to Alloc and Free in place of the package's
+// buffer pool, so that the Allocator may pool storage itself. Storage that is
+// not returned through PutFloats or PutDense is not freed by the package; an
+// arena allocator may reclaim it in bulk.
+//
+// Temporary storage used within operations and slices returned to the caller,
+// such as rows and eigenvalues, are allocated from the Go heap.
+//
+// SetAllocator must not be called concurrently with operations on matrices.
+func SetAllocator(a Allocator) Allocator {
+	prev := allocator
+	allocator = a
+	return prev
+}
+
+// newFloats returns a zeroed slice of length n for the storage of a matrix.
+func newFloats(n int) []float64 {
+	if allocator == nil {
+		return make([]float64, n)
+	}
+	return allocator.Alloc(n)
+}
@@ -0,0 +1,83 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"sync"
+	"unsafe"
+
+	check "launchpad.net/gocheck"
+)
+
+// arena is an Allocator that carves slices from a single buffer.
+type arena struct {
+	mu    sync.Mutex
+	buf   []float64
+	used  int
+	freed int
+}
+
+func (a *arena) Alloc(n int) []float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.used+n > len(a.buf) {
+		panic("arena exhausted")
+	}
+	f := a.buf[a.used : a.used+n : a.used+n]
+	a.used += n
+	return f
+}
+
+func (a *arena) Free(f []float64) {
+	a.mu.Lock()
+	a.freed += cap(f)
+	a.mu.Unlock()
+}
+
+func (a *arena) owns(f []float64) bool {
+	if len(f) == 0 {
+		return false
+	}
+	p := uintptr(unsafe.Pointer(&f[0]))
+	return p >= uintptr(unsafe.Pointer(&a.buf[0])) && p <= uintptr(unsafe.Pointer(&a.buf[len(a.buf)-1]))
+}
+
+func (s *S) TestAllocator(c *check.C) {
+	// Created before the allocator is set.
+	heap := NewDense(2, 2, []float64{1, 2, 3, 4})
+
+	ar := &arena{buf: make([]float64, 1000)}
+	c.Check(SetAllocator(ar), check.IsNil)
+	defer SetAllocator(nil)
+
+	m := NewDense(3, 3, nil)
+	c.Check(ar.owns(m.mat.Data), check.Equals, true)
+	c.Check(ar.used, check.Equals, 9)
+
+	// Receivers allocate from the arena.
+	var p Dense
+	p.Mul(heap, heap)
+	c.Check(ar.owns(p.mat.Data), check.Equals, true)
+	var cl Dense
+	cl.Clone(heap)
+	c.Check(ar.owns(cl.mat.Data), check.Equals, true)
+	c.Check(cl.Equals(heap), check.Equals, true)
+
+	// The pool is replaced by the allocator.
+	d := GetDense(4, 5, false)
+	c.Check(ar.owns(d.mat.Data), check.Equals, true)
+	PutDense(d)
+	c.Check(ar.freed, check.Equals, 20)
+
+	// User provided storage is not replaced.
+	data := make([]float64, 4)
+	c.Check(ar.owns(NewDense(2, 2, data).mat.Data), check.Equals, false)
+
+	c.Check(SetAllocator(nil), check.Equals, ar)
+	used := ar.used
+	NewDense(3, 3, nil)
+	c.Check(ar.used, check.Equals, used)
+	SetAllocator(ar)
+}
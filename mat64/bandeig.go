@@ -0,0 +1,228 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math"
+
+// GenEigenFactors holds the solution of a symmetric-definite generalized
+// eigenproblem k.x = λ.m.x. Values holds the eigenvalues in ascending order
+// and the columns of V the corresponding eigenvectors, normalized so that
+// V'.m.V = I.
+type GenEigenFactors struct {
+	Values []float64
+	V      *Dense
+}
+
+// BandedGenEigen solves the generalized eigenproblem k.x = λ.m.x where k is
+// symmetric and m is symmetric positive definite, both of order n with
+// half-bandwidth kd, as arise from finite difference and finite element
+// discretizations of Sturm–Liouville and beam vibration problems. Only the
+// diagonal and the kd subdiagonals of k and m are referenced. If m is nil it is
+// taken to be the identity.
+//
+// When m is diagonal, as for a lumped mass matrix, the problem is scaled to a
+// standard banded problem which is reduced to tridiagonal form by Givens
+// rotations, preserving the band, in O(n².kd) operations. Otherwise m is
+// factorized by a banded Cholesky decomposition m = L.L' and the dense matrix
+// L^-1.k.L^-T is reduced by Householder transformations. In both cases the
+// tridiagonal problem is solved by the implicit QL algorithm.
+//
+// BandedGenEigen will panic with ErrSquare or ErrShape if k and m are not square
+// matrices of the same order, and with ErrNotPositiveDefinite if m is not
+// positive definite.
+func BandedGenEigen(k, m Matrix, kd int, epsilon float64) GenEigenFactors {
+	n, c := k.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	if m != nil {
+		if mr, mc := m.Dims(); mr != n || mc != n {
+			panic(ErrShape)
+		}
+	}
+	if kd < 0 {
+		panic(ErrShape)
+	}
+	kd = min(kd, max(n-1, 0))
+	if n == 0 {
+		return GenEigenFactors{V: &Dense{}}
+	}
+
+	// Copy the band of k into a symmetric dense matrix.
+	a := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := max(0, i-kd); j <= i; j++ {
+			v := k.At(i, j)
+			a.Set(i, j, v)
+			a.Set(j, i, v)
+		}
+	}
+
+	d := make([]float64, n)
+	e := make([]float64, n)
+	var v *Dense
+	switch {
+	case m == nil:
+		v = bandTridiag(a, kd, d, e)
+	case isBandDiagonal(m, kd):
+		s := make([]float64, n)
+		for i := range s {
+			mi := m.At(i, i)
+			if !(mi > 0) {
+				panic(ErrNotPositiveDefinite)
+			}
+			s[i] = 1 / math.Sqrt(mi)
+		}
+		for i := 0; i < n; i++ {
+			row := a.rowView(i)
+			for j := max(0, i-kd); j <= min(n-1, i+kd); j++ {
+				row[j] *= s[i] * s[j]
+			}
+		}
+		v = bandTridiag(a, kd, d, e)
+		tql2(d, e, v, epsilon, nil, nil)
+		for i := 0; i < n; i++ {
+			scalUnitary(s[i], v.rowView(i))
+		}
+		return GenEigenFactors{Values: d, V: v}
+	default:
+		l := bandCholesky(m, kd)
+		bandLowerSolve(l, kd, a)
+		var at Dense
+		at.TCopy(a)
+		bandLowerSolve(l, kd, &at)
+		a = &at
+		Symmetrize(a)
+		v = tred2(a, d, e)
+		tql2(d, e, v, epsilon, nil, nil)
+		bandLowerTransSolve(l, kd, v)
+		return GenEigenFactors{Values: d, V: v}
+	}
+	tql2(d, e, v, epsilon, nil, nil)
+	return GenEigenFactors{Values: d, V: v}
+}
+
+// isBandDiagonal returns whether the kd subdiagonals of m are zero.
+func isBandDiagonal(m Matrix, kd int) bool {
+	n, _ := m.Dims()
+	for i := 1; i < n; i++ {
+		for j := max(0, i-kd); j < i; j++ {
+			if m.At(i, j) != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// bandTridiag reduces the symmetric matrix a with half-bandwidth kd to
+// tridiagonal form T = Q'.a.Q by Givens rotations, chasing the bulge created
+// by each rotation down the band so that the band is preserved. The diagonal of
+// T is returned in d and the subdiagonal in e[1:], with e[0] set to zero, as
+// required by tql2, and Q is returned. The matrix a is overwritten.
+//
+// This is the algorithm of Schwarz, "Tridiagonalization of a symmetric band
+// matrix", Numer. Math. 12:231-241, 1968.
+func bandTridiag(a *Dense, kd int, d, e []float64) *Dense {
+	n, _ := a.Dims()
+	q := identityDense(n)
+	for j := 0; j < n-2; j++ {
+		for k := min(j+kd, n-1); k >= j+2; k-- {
+			// Annihilate a[k][j] with a rotation in the plane
+			// (k-1, k), then chase the bulge it creates at
+			// a[k-1+kd+1][k-1] down the band.
+			c, p := j, k
+			for p < n {
+				x, y := a.At(p-1, c), a.At(p, c)
+				if y == 0 {
+					break
+				}
+				r := math.Hypot(x, y)
+				cs, sn := x/r, y/r
+				lo := max(0, p-1-2*kd)
+				hi := min(n, p+2*kd+1)
+				rotateBand(a, p-1, p, cs, sn, lo, hi)
+				q.RotateCols(p-1, p, cs, sn)
+				a.Set(p, c, 0)
+				a.Set(c, p, 0)
+				c, p = p-1, p+kd
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		d[i] = a.At(i, i)
+		if i > 0 {
+			e[i] = a.At(i, i-1)
+		}
+	}
+	e[0] = 0
+	return q
+}
+
+// rotateBand applies the rotation [c s; -s c] in the plane (p, q) to the rows and
+// columns of the symmetric matrix a, touching only the elements of the rows and
+// columns within [lo, hi), outside of which rows and columns p and q are zero.
+func rotateBand(a *Dense, p, q int, c, s float64, lo, hi int) {
+	rp, rq := a.rowView(p)[lo:hi], a.rowView(q)[lo:hi]
+	for j, x := range rp {
+		y := rq[j]
+		rp[j] = c*x + s*y
+		rq[j] = c*y - s*x
+	}
+	for i := lo; i < hi; i++ {
+		row := a.rowView(i)
+		x, y := row[p], row[q]
+		row[p] = c*x + s*y
+		row[q] = c*y - s*x
+	}
+}
+
+// bandCholesky returns the lower triangular Cholesky factor of the symmetric
+// positive definite matrix m with half-bandwidth kd, which has the same band.
+func bandCholesky(m Matrix, kd int) *Dense {
+	n, _ := m.Dims()
+	l := NewDense(n, n, nil)
+	for j := 0; j < n; j++ {
+		lo := max(0, j-kd)
+		row := l.rowView(j)
+		for k := lo; k < j; k++ {
+			klo := max(lo, k-kd)
+			s := m.At(j, k) - dotUnitary(l.rowView(k)[klo:k], row[klo:])
+			row[k] = s / l.At(k, k)
+		}
+		dd := m.At(j, j) - dotUnitary(row[lo:j], row[lo:])
+		if !(dd > 0) {
+			panic(ErrNotPositiveDefinite)
+		}
+		row[j] = math.Sqrt(dd)
+	}
+	return l
+}
+
+// bandLowerSolve overwrites b with L^-1.b, where l is lower triangular with
+// half-bandwidth kd.
+func bandLowerSolve(l *Dense, kd int, b *Dense) {
+	n, _ := l.Dims()
+	for i := 0; i < n; i++ {
+		bi := b.rowView(i)
+		for k := max(0, i-kd); k < i; k++ {
+			axpyUnitary(-l.At(i, k), b.rowView(k), bi)
+		}
+		scalUnitary(1/l.At(i, i), bi)
+	}
+}
+
+// bandLowerTransSolve overwrites b with L^-T.b, where l is lower triangular
+// with half-bandwidth kd.
+func bandLowerTransSolve(l *Dense, kd int, b *Dense) {
+	n, _ := l.Dims()
+	for i := n - 1; i >= 0; i-- {
+		bi := b.rowView(i)
+		for k := i + 1; k <= min(n-1, i+kd); k++ {
+			axpyUnitary(-l.At(k, i), b.rowView(k), bi)
+		}
+		scalUnitary(1/l.At(i, i), bi)
+	}
+}
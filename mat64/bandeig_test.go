@@ -0,0 +1,103 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	check "launchpad.net/gocheck"
+)
+
+func randBandSym(rnd *rand.Rand, n, kd int, diag float64) *Dense {
+	a := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := max(0, i-kd); j <= i; j++ {
+			v := rnd.NormFloat64()
+			if i == j {
+				v += diag
+			}
+			a.Set(i, j, v)
+			a.Set(j, i, v)
+		}
+	}
+	return a
+}
+
+func checkGenEigen(c *check.C, k, m *Dense, f GenEigenFactors, tol float64, comment check.CommentInterface) {
+	n, _ := k.Dims()
+	if m == nil {
+		m = identityDense(n)
+	}
+	c.Check(sort.Float64sAreSorted(f.Values), check.Equals, true, comment)
+
+	lambda := NewDense(n, n, nil)
+	for i, v := range f.Values {
+		lambda.Set(i, i, v)
+	}
+	var kv, mv, mvl, vtmv, vt Dense
+	kv.Mul(k, f.V)
+	mv.Mul(m, f.V)
+	mvl.Mul(&mv, lambda)
+	c.Check(kv.EqualsApprox(&mvl, tol), check.Equals, true, comment)
+	vt.TCopy(f.V)
+	vtmv.Mul(&vt, &mv)
+	c.Check(vtmv.EqualsApprox(identityDense(n), tol), check.Equals, true, comment)
+}
+
+func (s *S) TestBandedGenEigen(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ n, kd int }{
+		{1, 0}, {2, 1}, {10, 1}, {25, 3}, {30, 7}, {12, 11}, {8, 20},
+	} {
+		k := randBandSym(rnd, test.n, test.kd, 0)
+		kd := test.kd
+
+		f := BandedGenEigen(k, nil, kd, epsilon)
+		checkGenEigen(c, k, nil, f, 1e-10, check.Commentf("standard %+v", test))
+		want := Eigen(DenseCopyOf(k), epsilon)
+		for i, v := range f.Values {
+			c.Check(math.Abs(v-want.d[i]) < 1e-10, check.Equals, true)
+		}
+
+		lumped := NewDense(test.n, test.n, nil)
+		for i := 0; i < test.n; i++ {
+			lumped.Set(i, i, 1+rnd.Float64())
+		}
+		f = BandedGenEigen(k, lumped, kd, epsilon)
+		checkGenEigen(c, k, lumped, f, 1e-10, check.Commentf("lumped %+v", test))
+
+		m := randBandSym(rnd, test.n, min(kd, 2), 8)
+		f = BandedGenEigen(k, m, kd, epsilon)
+		checkGenEigen(c, k, m, f, 1e-10, check.Commentf("banded %+v", test))
+	}
+
+	// Linear finite elements for -u'' = λu on [0, π] with u(0) = u(π) = 0,
+	// whose eigenvalues are 1, 4, 9, ...
+	const n = 199
+	h := math.Pi / (n + 1)
+	k := NewDense(n, n, nil)
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		k.Set(i, i, 2/h)
+		m.Set(i, i, 4*h/6)
+		if i > 0 {
+			k.Set(i, i-1, -1/h)
+			k.Set(i-1, i, -1/h)
+			m.Set(i, i-1, h/6)
+			m.Set(i-1, i, h/6)
+		}
+	}
+	f := BandedGenEigen(k, m, 1, epsilon)
+	for i, want := range []float64{1, 4, 9} {
+		c.Check(math.Abs(f.Values[i]-want) < 1e-3*want, check.Equals, true, check.Commentf("mode %d: %v", i, f.Values[i]))
+	}
+
+	c.Check(func() { BandedGenEigen(NewDense(2, 3, nil), nil, 1, epsilon) }, check.PanicMatches, string(ErrSquare))
+	c.Check(func() { BandedGenEigen(k, NewDense(2, 2, nil), 1, epsilon) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { BandedGenEigen(identityDense(2), NewDense(2, 2, []float64{1, 2, 2, 1}), 1, epsilon) }, check.PanicMatches, string(ErrNotPositiveDefinite))
+	c.Check(func() { BandedGenEigen(identityDense(2), NewDense(2, 2, []float64{1, 0, 0, -1}), 1, epsilon) }, check.PanicMatches, string(ErrNotPositiveDefinite))
+}
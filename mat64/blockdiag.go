@@ -0,0 +1,207 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/cmplx"
+)
+
+// EigenClusters groups the eigenvalues held in values into clusters of nearly
+// equal values. Two eigenvalues belong to the same cluster if they are linked by
+// a chain of eigenvalues each within tol of the next. The clusters are returned
+// as ascending lists of indices into values, ordered by their first index.
+func EigenClusters(values []complex128, tol float64) [][]int {
+	parent := make([]int, len(values))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range values {
+		for j := i + 1; j < len(values); j++ {
+			if cmplx.Abs(values[i]-values[j]) <= tol {
+				ri, rj := find(i), find(j)
+				if ri < rj {
+					parent[rj] = ri
+				} else {
+					parent[ri] = rj
+				}
+			}
+		}
+	}
+
+	var clusters [][]int
+	index := make(map[int]int)
+	for i := range values {
+		r := find(i)
+		c, ok := index[r]
+		if !ok {
+			c = len(clusters)
+			index[r] = c
+			clusters = append(clusters, nil)
+		}
+		clusters[c] = append(clusters[c], i)
+	}
+	return clusters
+}
+
+// BlockDiagFactors holds a block diagonalization of a square matrix a, such that
+// a = X.B.X^-1 where B is block diagonal with quasi-upper triangular diagonal
+// blocks, each holding a cluster of nearly equal eigenvalues of a.
+type BlockDiagFactors struct {
+	B *Dense
+	X *Dense
+
+	// Blocks holds the orders of the diagonal blocks of B.
+	Blocks []int
+}
+
+// BlockDiagonalize returns a block diagonalization of the square matrix a using
+// the algorithm of Bavely and Stewart, "An algorithm for computing reducing
+// subspaces by block diagonalization", SIAM J. Numer. Anal. 16(2):359-367, 1979.
+//
+// The real Schur form of a is reordered so that eigenvalues clustered by
+// EigenClusters with tolerance tol are adjacent, and the off-diagonal blocks are
+// then eliminated by solving Sylvester equations. When eliminating a block would
+// require a transformation with elements larger than bound in magnitude, the
+// block is merged with the following cluster, so that defective and nearly
+// defective eigenvalues end up sharing a Jordan-like block. The condition number
+// of X is controlled by bound.
+func BlockDiagonalize(a *Dense, tol, bound float64) BlockDiagFactors {
+	f := Schur(DenseCopyOf(a), epsilon)
+	t, x := f.T, f.Z
+	n, _ := t.Dims()
+
+	// Cluster the Schur blocks by their eigenvalues and group them.
+	sizes := schurBlocks(t)
+	values := make([]complex128, len(sizes))
+	var i int
+	for b, p := range sizes {
+		re, im := schurBlockEigenvalue(t, i, p)
+		values[b] = complex(re, im)
+		i += p
+	}
+	clusters := EigenClusters(values, tol)
+	keys := make([]int, len(sizes))
+	order := make([]int, len(clusters))
+	for c, members := range clusters {
+		for _, b := range members {
+			keys[b] = c
+			order[c] += sizes[b]
+		}
+	}
+	sortSchurBlocks(t, x, sizes, keys)
+
+	// Eliminate the off-diagonal blocks, merging clusters when the
+	// transformation would be too large.
+	var blocks []int
+	var t11, t12, t22, x1, x2 Dense
+	for start, c := 0, 0; start < n; {
+		end := start + order[c]
+		c++
+		for end < n {
+			t11.View(t, start, start, end-start, end-start)
+			t12.View(t, start, end, end-start, n-end)
+			t22.View(t, end, end, n-end, n-end)
+			y, ok := sylvesterQuasi(&t11, &t22, &t12)
+			if ok && y.Norm(inf) <= bound {
+				// With the transformation [I -Y; 0 I] the off-diagonal
+				// block T11.(-Y) - (-Y).T22 + T12 vanishes.
+				for r := 0; r < end-start; r++ {
+					zero(t12.rowView(r))
+				}
+				x1.View(x, 0, start, n, end-start)
+				x2.View(x, 0, end, n, n-end)
+				var xy Dense
+				xy.Mul(&x1, y)
+				x2.Sub(&x2, &xy)
+				break
+			}
+			end += order[c]
+			c++
+		}
+		blocks = append(blocks, end-start)
+		start = end
+	}
+
+	return BlockDiagFactors{B: t, X: x, Blocks: blocks}
+}
+
+// Block returns a copy of the i-th diagonal block of B.
+func (f BlockDiagFactors) Block(i int) *Dense {
+	if i < 0 || i >= len(f.Blocks) {
+		panic(ErrIndexOutOfRange)
+	}
+	var start int
+	for _, p := range f.Blocks[:i] {
+		start += p
+	}
+	b := &Dense{}
+	b.Submatrix(f.B, start, start, f.Blocks[i], f.Blocks[i])
+	return b
+}
+
+// sylvesterQuasi solves the Sylvester equation a.x - x.b = c for x, where a and
+// b are quasi-upper triangular matrices in real Schur form, using the back
+// substitution of Bartels and Stewart. The returned bool is false if a and b
+// share an eigenvalue.
+func sylvesterQuasi(a, b *Dense, c Matrix) (*Dense, bool) {
+	p, _ := a.Dims()
+	q, _ := b.Dims()
+	x := NewDense(p, q, nil)
+
+	rowBlocks := schurBlocks(a)
+	var aii, bjj Dense
+	for j := 0; j < q; {
+		cq := schurBlockSize(b, j)
+
+		// r holds the right hand side for the columns of x in the block.
+		r := NewDense(p, cq, nil)
+		for row := 0; row < p; row++ {
+			for col := 0; col < cq; col++ {
+				v := c.At(row, j+col)
+				for k := 0; k < j; k++ {
+					v += x.At(row, k) * b.At(k, j+col)
+				}
+				r.Set(row, col, v)
+			}
+		}
+
+		bjj.View(b, j, j, cq, cq)
+		end := p
+		for k := len(rowBlocks) - 1; k >= 0; k-- {
+			rp := rowBlocks[k]
+			i := end - rp
+			rhs := NewDense(rp, cq, nil)
+			for row := 0; row < rp; row++ {
+				for col := 0; col < cq; col++ {
+					v := r.At(i+row, col)
+					for l := end; l < p; l++ {
+						v -= a.At(i+row, l) * x.At(l, j+col)
+					}
+					rhs.Set(row, col, v)
+				}
+			}
+			aii.View(a, i, i, rp, rp)
+			y, ok := sylvesterSmall(&aii, &bjj, rhs)
+			if !ok {
+				return nil, false
+			}
+			for row := 0; row < rp; row++ {
+				for col := 0; col < cq; col++ {
+					x.Set(i+row, j+col, y.At(row, col))
+				}
+			}
+			end = i
+		}
+		j += cq
+	}
+	return x, true
+}
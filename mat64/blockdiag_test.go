@@ -0,0 +1,106 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"sort"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestEigenClusters(c *check.C) {
+	values := []complex128{1, 5, 1 + 1e-9, 2, 5 + 2i, 2.05, 2.1}
+	c.Check(EigenClusters(values, 1e-6), check.DeepEquals, [][]int{{0, 2}, {1}, {3}, {4}, {5}, {6}})
+	c.Check(EigenClusters(values, 0.06), check.DeepEquals, [][]int{{0, 2}, {1}, {3, 5, 6}, {4}})
+	c.Check(EigenClusters(nil, 1), check.IsNil)
+}
+
+func (s *S) TestBlockDiagonalize(c *check.C) {
+	for i, test := range []struct {
+		a      *Dense
+		tol    float64
+		blocks []int
+	}{
+		{
+			// Distinct real eigenvalues.
+			a: NewDense(3, 3, []float64{
+				1, 2, 3,
+				0, 4, 5,
+				0, 0, 6,
+			}),
+			tol:    1e-8,
+			blocks: []int{1, 1, 1},
+		},
+		{
+			// A defective eigenvalue is kept in a single block by the
+			// clustering tolerance.
+			a: NewDense(3, 3, []float64{
+				2, 1, 0,
+				0, 2, 3,
+				0, 0, 5,
+			}),
+			tol:    1e-6,
+			blocks: []int{2, 1},
+		},
+		{
+			// A nearly defective pair is merged by the bound.
+			a: NewDense(3, 3, []float64{
+				2, 1, 0,
+				0, 2 + 1e-10, 3,
+				0, 0, 5,
+			}),
+			tol:    0,
+			blocks: []int{2, 1},
+		},
+		{
+			// A complex pair.
+			a: NewDense(4, 4, []float64{
+				1, -2, 1, 0,
+				2, 1, 0, 1,
+				0, 0, 3, 1,
+				0, 0, 0, -1,
+			}),
+			tol:    1e-8,
+			blocks: []int{2, 1, 1},
+		},
+	} {
+		f := BlockDiagonalize(test.a, test.tol, 1e4)
+		n, _ := test.a.Dims()
+
+		var sum int
+		for _, p := range f.Blocks {
+			sum += p
+		}
+		c.Check(sum, check.Equals, n, check.Commentf("Test %d", i))
+		bs := append([]int(nil), f.Blocks...)
+		sort.Ints(bs)
+		want := append([]int(nil), test.blocks...)
+		sort.Ints(want)
+		c.Check(bs, check.DeepEquals, want, check.Commentf("Test %d", i))
+
+		// B is block diagonal.
+		var start int
+		for _, p := range f.Blocks {
+			for r := start; r < start+p; r++ {
+				for col := 0; col < n; col++ {
+					if col < start || col >= start+p {
+						c.Check(f.B.At(r, col), check.Equals, 0.0, check.Commentf("Test %d", i))
+					}
+				}
+			}
+			start += p
+		}
+
+		b0 := f.Block(0)
+		r0, c0 := b0.Dims()
+		c.Check(r0 == f.Blocks[0] && c0 == f.Blocks[0], check.Equals, true, check.Commentf("Test %d", i))
+
+		// a.X = X.B
+		var ax, xb Dense
+		ax.Mul(test.a, f.X)
+		xb.Mul(f.X, f.B)
+		c.Check(ax.EqualsApprox(&xb, 1e-8), check.Equals, true, check.Commentf("Test %d", i))
+	}
+}
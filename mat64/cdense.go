@@ -0,0 +1,72 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// CDense is a dense matrix of complex128 values. It provides a minimal
+// representation for results, such as eigenvectors of nonsymmetric matrices,
+// that are naturally complex.
+type CDense struct {
+	rows, cols int
+	stride     int
+	data       []complex128
+}
+
+// NewCDense returns a new r-by-c complex matrix using the row-major data in mat
+// as its backing storage. If mat is nil, new storage is allocated. NewCDense
+// will panic with ErrShape if mat is not nil and its length is not r*c.
+func NewCDense(r, c int, mat []complex128) *CDense {
+	if mat != nil && r*c != len(mat) {
+		panic(ErrShape)
+	}
+	if mat == nil {
+		mat = make([]complex128, r*c)
+	}
+	return &CDense{rows: r, cols: c, stride: c, data: mat}
+}
+
+// Dims returns the dimensions of the matrix.
+func (m *CDense) Dims() (r, c int) { return m.rows, m.cols }
+
+// At returns the value of the matrix element at (r, c). It will panic if r or c
+// are out of bounds for the matrix.
+func (m *CDense) At(r, c int) complex128 {
+	if r >= m.rows || r < 0 || c >= m.cols || c < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	return m.data[r*m.stride+c]
+}
+
+// Set alters the matrix element at (r, c) to v. It will panic if r or c are out
+// of bounds for the matrix.
+func (m *CDense) Set(r, c int, v complex128) {
+	if r >= m.rows || r < 0 || c >= m.cols || c < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	m.data[r*m.stride+c] = v
+}
+
+// Real returns a newly allocated matrix holding the real parts of the elements
+// of the receiver.
+func (m *CDense) Real() *Dense {
+	re := NewDense(m.rows, m.cols, nil)
+	for i := 0; i < m.rows; i++ {
+		for j, v := range m.data[i*m.stride : i*m.stride+m.cols] {
+			re.mat.Data[i*re.mat.Stride+j] = real(v)
+		}
+	}
+	return re
+}
+
+// Imag returns a newly allocated matrix holding the imaginary parts of the
+// elements of the receiver.
+func (m *CDense) Imag() *Dense {
+	im := NewDense(m.rows, m.cols, nil)
+	for i := 0; i < m.rows; i++ {
+		for j, v := range m.data[i*m.stride : i*m.stride+m.cols] {
+			im.mat.Data[i*im.mat.Stride+j] = imag(v)
+		}
+	}
+	return im
+}
@@ -17,7 +17,18 @@ type CholeskyFactor struct {
 // CholeskyL returns the left Cholesky decomposition of the matrix a and whether
 // the matrix is symmetric or positive definite, the returned matrix l is a lower
 // triangular matrix such that a = l.l'.
+//
+// If a LAPACK backend is registered, the decomposition of a square matrix is
+// computed by its Dpotrf.
 func Cholesky(a *Dense) CholeskyFactor {
+	if p := startProfile(); p != nil {
+		_, n := a.Dims()
+		defer p.stop("Cholesky", n*n*n/3)
+	}
+	if m, n := a.Dims(); m == n && lapackEngine != nil {
+		return choleskyLapack(a)
+	}
+
 	// Initialize.
 	m, n := a.Dims()
 	spd := m == n
@@ -28,10 +39,7 @@ func Cholesky(a *Dense) CholeskyFactor {
 		lRowj := l.RowView(j)
 		var d float64
 		for k := 0; k < j; k++ {
-			var s float64
-			for i, v := range l.RowView(k)[:k] {
-				s += v * lRowj[i]
-			}
+			s := dotUnitary(l.RowView(k)[:k], lRowj)
 			s = (a.At(j, k) - s) / l.At(k, k)
 			lRowj[k] = s
 			d += s * s
@@ -53,7 +61,7 @@ func Cholesky(a *Dense) CholeskyFactor {
 // matrix b is overwritten by the operation.
 func (f CholeskyFactor) Solve(b *Dense) (x *Dense) {
 	if !f.SPD {
-		panic("mat64: matrix not symmetric positive definite")
+		panic(ErrNotPositiveDefinite)
 	}
 	l := f.L
 
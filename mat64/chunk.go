@@ -0,0 +1,194 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"io"
+	"math"
+)
+
+// A RowBlockReader reads a matrix stored outside memory as a sequence of blocks
+// of consecutive rows, so that the matrix can be processed with bounded memory.
+type RowBlockReader interface {
+	// Next returns the next block of rows. The final block may hold
+	// fewer rows than the others. Next returns io.EOF when no rows
+	// remain. The storage of the returned matrix may be reused by
+	// subsequent calls to Next.
+	Next() (*Dense, error)
+
+	// Cols returns the number of columns of the matrix.
+	Cols() int
+}
+
+// CSVBlockReader is a RowBlockReader for delimited text.
+type CSVBlockReader struct {
+	r      *csv.Reader
+	opts   CSVOptions
+	rows   int
+	cols   []int
+	header []string
+	first  []string
+	line   int
+	block  Dense
+}
+
+// NewCSVBlockReader returns a CSVBlockReader that reads blocks of up to rows
+// rows from the delimited text in r as described by opts, which may be nil. The
+// first record, and the header if opts.Header is true, is read immediately to
+// determine the number of columns.
+func NewCSVBlockReader(r io.Reader, rows int, opts *CSVOptions) (*CSVBlockReader, error) {
+	if rows <= 0 {
+		return nil, ErrZeroLength
+	}
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	b := &CSVBlockReader{
+		r:    newCSVReader(r, opts),
+		opts: *opts,
+		rows: rows,
+		line: 1,
+	}
+	rec, err := b.r.Read()
+	if err == io.EOF {
+		return nil, ErrZeroLength
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.cols, err = csvColumns(opts, len(rec))
+	if err != nil {
+		return nil, err
+	}
+	if len(b.cols) == 0 {
+		return nil, ErrZeroLength
+	}
+	if opts.Header {
+		b.header = csvHeader(rec, b.cols)
+		b.line++
+	} else {
+		b.first = rec
+	}
+	return b, nil
+}
+
+// Header returns the names of the selected columns when the text has a header.
+func (b *CSVBlockReader) Header() []string { return b.header }
+
+// Cols returns the number of selected columns.
+func (b *CSVBlockReader) Cols() int { return len(b.cols) }
+
+// Next returns the next block of rows.
+func (b *CSVBlockReader) Next() (*Dense, error) {
+	b.block.mat = RawMatrix{
+		Rows:   b.rows,
+		Cols:   len(b.cols),
+		Stride: len(b.cols),
+		Data:   use(b.block.mat.Data, b.rows*len(b.cols)),
+	}
+	var i int
+	for ; i < b.rows; i++ {
+		rec := b.first
+		b.first = nil
+		if rec == nil {
+			var err error
+			rec, err = b.r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		err := parseCSVRow(b.block.rowView(i), rec, b.cols, b.opts.NA, b.line)
+		if err != nil {
+			return nil, err
+		}
+		b.line++
+	}
+	if i == 0 {
+		return nil, io.EOF
+	}
+	b.block.mat.Rows = i
+	b.block.mat.Data = b.block.mat.Data[:i*len(b.cols)]
+	return &b.block, nil
+}
+
+// NativeBlockReader is a RowBlockReader for matrices in the native format
+// described for WriteTo.
+type NativeBlockReader struct {
+	r     io.Reader
+	rows  int
+	total int
+	cols  int
+	left  int
+	buf   []byte
+	block Dense
+}
+
+// NewNativeBlockReader returns a NativeBlockReader that reads blocks of up to
+// rows rows from the native format matrix in r. The header is read immediately.
+// Only row-major encodings can be read in blocks; ErrNativeFormat is returned for
+// column-major encodings.
+func NewNativeBlockReader(r io.Reader, rows int) (*NativeBlockReader, error) {
+	if rows <= 0 {
+		return nil, ErrZeroLength
+	}
+	hb := make([]byte, nativeHeaderSize)
+	if _, err := io.ReadFull(r, hb); err != nil {
+		return nil, err
+	}
+	h, err := unmarshalNativeHeader(hb)
+	if err != nil {
+		return nil, err
+	}
+	if h.order != nativeRowMajor {
+		return nil, ErrNativeFormat
+	}
+	return &NativeBlockReader{r: r, rows: rows, total: h.rows, cols: h.cols, left: h.rows}, nil
+}
+
+// Rows returns the total number of rows of the matrix.
+func (b *NativeBlockReader) Rows() int { return b.total }
+
+// Cols returns the number of columns of the matrix.
+func (b *NativeBlockReader) Cols() int { return b.cols }
+
+// Next returns the next block of rows.
+func (b *NativeBlockReader) Next() (*Dense, error) {
+	if b.left == 0 {
+		return nil, io.EOF
+	}
+	r := min(b.rows, b.left)
+	n := r * b.cols
+	if cap(b.buf) < 8*n {
+		b.buf = make([]byte, 8*n)
+	}
+	buf := b.buf[:8*n]
+	if _, err := io.ReadFull(b.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	b.block.mat = RawMatrix{
+		Rows:   r,
+		Cols:   b.cols,
+		Stride: b.cols,
+		Data:   use(b.block.mat.Data, n),
+	}
+	for i := range b.block.mat.Data {
+		b.block.mat.Data[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8*i:]))
+	}
+	b.left -= r
+	return &b.block, nil
+}
+
+var (
+	_ RowBlockReader = (*CSVBlockReader)(nil)
+	_ RowBlockReader = (*NativeBlockReader)(nil)
+)
@@ -0,0 +1,100 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	check "launchpad.net/gocheck"
+)
+
+// gramBlocks accumulates x'.x from the row blocks read from r.
+func gramBlocks(r RowBlockReader) (*Dense, []int, error) {
+	n := r.Cols()
+	g := NewDense(n, n, nil)
+	var sizes []int
+	for {
+		b, err := r.Next()
+		if err == io.EOF {
+			return g, sizes, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		rows, _ := b.Dims()
+		sizes = append(sizes, rows)
+		var bt, p Dense
+		bt.TCopy(b)
+		p.Mul(&bt, b)
+		g.Add(g, &p)
+	}
+}
+
+func (s *S) TestRowBlockReaders(c *check.C) {
+	x := NewDense(5, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		-1, 0, 2,
+		3, -2, 1,
+		0.5, 1, -1,
+	})
+	var xt, want Dense
+	xt.TCopy(x)
+	want.Mul(&xt, x)
+
+	var buf bytes.Buffer
+	_, err := x.WriteTo(&buf)
+	c.Assert(err, check.IsNil)
+	nr, err := NewNativeBlockReader(&buf, 2)
+	c.Assert(err, check.IsNil)
+	c.Check(nr.Rows(), check.Equals, 5)
+	c.Check(nr.Cols(), check.Equals, 3)
+	g, sizes, err := gramBlocks(nr)
+	c.Assert(err, check.IsNil)
+	c.Check(sizes, check.DeepEquals, []int{2, 2, 1})
+	c.Check(g.EqualsApprox(&want, 1e-12), check.Equals, true)
+
+	buf.Reset()
+	c.Assert(WriteCSVDense(&buf, x, &CSVOptions{Header: true}), check.IsNil)
+	cr, err := NewCSVBlockReader(&buf, 3, &CSVOptions{Header: true})
+	c.Assert(err, check.IsNil)
+	c.Check(cr.Header(), check.DeepEquals, []string{"0", "1", "2"})
+	g, sizes, err = gramBlocks(cr)
+	c.Assert(err, check.IsNil)
+	c.Check(sizes, check.DeepEquals, []int{3, 2})
+	c.Check(g.EqualsApprox(&want, 1e-12), check.Equals, true)
+
+	// Column selection without a header.
+	cr, err = NewCSVBlockReader(strings.NewReader("1,2,3\n4,5,6\n7,8,9\n"), 2, &CSVOptions{Columns: []int{2, 0}})
+	c.Assert(err, check.IsNil)
+	b, err := cr.Next()
+	c.Assert(err, check.IsNil)
+	c.Check(b.Equals(NewDense(2, 2, []float64{3, 1, 6, 4})), check.Equals, true)
+	b, err = cr.Next()
+	c.Assert(err, check.IsNil)
+	c.Check(b.Equals(NewDense(1, 2, []float64{9, 7})), check.Equals, true)
+	_, err = cr.Next()
+	c.Check(err, check.Equals, io.EOF)
+
+	cr, err = NewCSVBlockReader(strings.NewReader("1,2\n3,x\n"), 5, nil)
+	c.Assert(err, check.IsNil)
+	_, err = cr.Next()
+	c.Check(err, check.ErrorMatches, "mat64: csv record 2 column 1: .*")
+
+	// Truncated native data.
+	buf.Reset()
+	x.WriteTo(&buf)
+	nr, err = NewNativeBlockReader(bytes.NewReader(buf.Bytes()[:buf.Len()-8]), 4)
+	c.Assert(err, check.IsNil)
+	_, err = nr.Next()
+	c.Assert(err, check.IsNil)
+	_, err = nr.Next()
+	c.Check(err, check.Equals, io.ErrUnexpectedEOF)
+
+	_, err = NewCSVBlockReader(strings.NewReader(""), 1, nil)
+	c.Check(err, check.Equals, ErrZeroLength)
+}
@@ -0,0 +1,160 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cgo && lapacke
+// +build cgo,lapacke
+
+package clapack
+
+/*
+#cgo linux LDFLAGS: -llapacke -llapack
+#cgo darwin LDFLAGS: -lopenblas
+#include <lapacke.h>
+*/
+import "C"
+
+import "github.com/gonum/matrix/mat64"
+
+var (
+	_ mat64.Lapack      = Lapack{}
+	_ mat64.LapackEigen = Lapack{}
+	_ mat64.LapackSVD   = Lapack{}
+)
+
+// Lapack implements mat64.Lapack using LAPACKE.
+type Lapack struct{}
+
+// Dgetrf computes the LU factorization of a with partial pivoting.
+func (Lapack) Dgetrf(m, n int, a []float64, lda int, ipiv []int) bool {
+	k := m
+	if n < k {
+		k = n
+	}
+	if k == 0 {
+		return true
+	}
+	if len(ipiv) < k || len(a) < (m-1)*lda+n {
+		panic("clapack: insufficient slice length")
+	}
+	p := make([]C.lapack_int, k)
+	info := C.LAPACKE_dgetrf(C.LAPACK_ROW_MAJOR,
+		C.lapack_int(m), C.lapack_int(n),
+		(*C.double)(&a[0]), C.lapack_int(lda),
+		&p[0])
+	if info < 0 {
+		panic("clapack: illegal argument to dgetrf")
+	}
+	for i, v := range p {
+		ipiv[i] = int(v) - 1
+	}
+	return info == 0
+}
+
+// Dpotrf computes the lower Cholesky factorization of a.
+func (Lapack) Dpotrf(n int, a []float64, lda int) bool {
+	if n == 0 {
+		return true
+	}
+	if len(a) < (n-1)*lda+n {
+		panic("clapack: insufficient slice length")
+	}
+	info := C.LAPACKE_dpotrf(C.LAPACK_ROW_MAJOR, 'L',
+		C.lapack_int(n),
+		(*C.double)(&a[0]), C.lapack_int(lda))
+	if info < 0 {
+		panic("clapack: illegal argument to dpotrf")
+	}
+	return info == 0
+}
+
+// Dsyev computes the eigenvalues and optionally the eigenvectors of the
+// symmetric matrix a.
+func (Lapack) Dsyev(wantv bool, n int, a []float64, lda int, w []float64) bool {
+	if n == 0 {
+		return true
+	}
+	if len(w) < n || len(a) < (n-1)*lda+n {
+		panic("clapack: insufficient slice length")
+	}
+	jobz := C.char('N')
+	if wantv {
+		jobz = 'V'
+	}
+	info := C.LAPACKE_dsyev(C.LAPACK_ROW_MAJOR, jobz, 'L',
+		C.lapack_int(n),
+		(*C.double)(&a[0]), C.lapack_int(lda),
+		(*C.double)(&w[0]))
+	if info < 0 {
+		panic("clapack: illegal argument to dsyev")
+	}
+	return info == 0
+}
+
+// Dgeev computes the eigenvalues and optionally the right eigenvectors of the
+// general matrix a.
+func (Lapack) Dgeev(wantv bool, n int, a []float64, lda int, wr, wi []float64, vr []float64, ldvr int) bool {
+	if n == 0 {
+		return true
+	}
+	if len(wr) < n || len(wi) < n || len(a) < (n-1)*lda+n {
+		panic("clapack: insufficient slice length")
+	}
+	jobvr := C.char('N')
+	var pvr *C.double
+	if wantv {
+		if len(vr) < (n-1)*ldvr+n {
+			panic("clapack: insufficient slice length")
+		}
+		jobvr = 'V'
+		pvr = (*C.double)(&vr[0])
+	} else {
+		ldvr = 1
+	}
+	info := C.LAPACKE_dgeev(C.LAPACK_ROW_MAJOR, 'N', jobvr,
+		C.lapack_int(n),
+		(*C.double)(&a[0]), C.lapack_int(lda),
+		(*C.double)(&wr[0]), (*C.double)(&wi[0]),
+		nil, 1,
+		pvr, C.lapack_int(ldvr))
+	if info < 0 {
+		panic("clapack: illegal argument to dgeev")
+	}
+	return info == 0
+}
+
+// Dgesvd computes the thin singular value decomposition of a.
+func (Lapack) Dgesvd(wantu, wantv bool, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int) bool {
+	k := m
+	if n < k {
+		k = n
+	}
+	if k == 0 {
+		return true
+	}
+	if len(s) < k || len(a) < (m-1)*lda+n {
+		panic("clapack: insufficient slice length")
+	}
+	jobu, jobvt := C.char('N'), C.char('N')
+	var pu, pvt *C.double
+	if wantu {
+		jobu = 'S'
+		pu = (*C.double)(&u[0])
+	}
+	if wantv {
+		jobvt = 'S'
+		pvt = (*C.double)(&vt[0])
+	}
+	superb := make([]float64, k)
+	info := C.LAPACKE_dgesvd(C.LAPACK_ROW_MAJOR, jobu, jobvt,
+		C.lapack_int(m), C.lapack_int(n),
+		(*C.double)(&a[0]), C.lapack_int(lda),
+		(*C.double)(&s[0]),
+		pu, C.lapack_int(ldu),
+		pvt, C.lapack_int(ldvt),
+		(*C.double)(&superb[0]))
+	if info < 0 {
+		panic("clapack: illegal argument to dgesvd")
+	}
+	return info == 0
+}
@@ -0,0 +1,20 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clapack provides a mat64.Lapack backend that calls a system LAPACK
+// through the LAPACKE C interface, for example the one bundled with OpenBLAS.
+// The backend also implements mat64.LapackEigen and mat64.LapackSVD, so that
+// Eigen and SVD use dsyev, dgeev and dgesvd.
+//
+// The backend is built only when cgo is enabled and the lapacke build tag is
+// given, so that the mat64 package does not require a C toolchain by default:
+//
+//  go build -tags lapacke
+//
+// It is registered with
+//
+//  mat64.RegisterLapack(clapack.Lapack{})
+//
+// The library linked can be changed with the CGO_LDFLAGS environment variable.
+package clapack
@@ -0,0 +1,170 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+var (
+	colDense *ColDense
+
+	_ Matrix   = colDense
+	_ Mutable  = colDense
+	_ Vectorer = colDense
+	_ Muler    = colDense
+)
+
+// ColDense is a dense matrix whose elements are held in column-major order, the
+// layout used by Fortran, LAPACK and most GPU libraries. Element (i, j) is stored
+// at index i+j*ld of the backing data, where the leading dimension ld is at least
+// the number of rows.
+//
+// A column-major matrix occupies the same storage as the row-major form of its
+// transpose, so a ColDense can be passed to row-major code through T, and to
+// Dense.Mul and Dense.MulAdd, which use its storage directly, without copying.
+type ColDense struct {
+	// t is the transpose of the matrix, sharing its storage.
+	t Dense
+}
+
+// NewColDense returns a new r-by-c matrix. If data is not nil it must have length
+// r*c and holds the elements in column-major order. The matrix takes ownership of
+// data without copying it, as for NewDense. If data is nil a new zeroed backing
+// slice is allocated.
+func NewColDense(r, c int, data []float64) *ColDense {
+	if data != nil && r*c != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float64, r*c)
+	}
+	return &ColDense{Dense{RawMatrix{
+		Rows:   c,
+		Cols:   r,
+		Stride: r,
+		Data:   data,
+	}}}
+}
+
+// NewColDenseStride returns a new r-by-c matrix whose columns start ld elements
+// apart in data, which the matrix uses without copying. This allows a matrix to
+// view a column-major array with a leading dimension larger than its number of
+// rows, as passed to LAPACK routines. NewColDenseStride will panic with
+// ErrIllegalStride if ld is less than r, and with ErrShape if data is too short
+// to hold the matrix.
+func NewColDenseStride(r, c, ld int, data []float64) *ColDense {
+	m := &ColDense{}
+	m.t.SetRawMatrix(RawMatrix{Rows: c, Cols: r, Stride: ld, Data: data})
+	return m
+}
+
+// ColDenseCopyOf returns a newly allocated column-major copy of the elements of
+// a.
+func ColDenseCopyOf(a Matrix) *ColDense {
+	r, c := a.Dims()
+	m := NewColDense(r, c, nil)
+	for j := 0; j < c; j++ {
+		col := m.t.rowView(j)
+		for i := range col {
+			col[i] = a.At(i, j)
+		}
+	}
+	return m
+}
+
+// ColMajor returns the backing data of the matrix and its leading dimension, for
+// passing to code that expects column-major storage.
+func (m *ColDense) ColMajor() (data []float64, ld int) {
+	return m.t.mat.Data, m.t.mat.Stride
+}
+
+func (m *ColDense) Dims() (r, c int) { return m.t.mat.Cols, m.t.mat.Rows }
+
+// At returns the element at row r and column c.
+func (m *ColDense) At(r, c int) float64 {
+	if r >= m.t.mat.Cols || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= m.t.mat.Rows || c < 0 {
+		panic(ErrColAccess)
+	}
+	return m.t.at(c, r)
+}
+
+// Set sets the element at row r and column c to v.
+func (m *ColDense) Set(r, c int, v float64) {
+	if r >= m.t.mat.Cols || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= m.t.mat.Rows || c < 0 {
+		panic(ErrColAccess)
+	}
+	m.t.mat.Data[c*m.t.mat.Stride+r] = v
+}
+
+// Row copies the elements of row r into row, allocating a new slice if row is
+// nil, and returns it.
+func (m *ColDense) Row(row []float64, r int) []float64 {
+	if r >= m.t.mat.Cols || r < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	if row == nil {
+		row = make([]float64, m.t.mat.Rows)
+	}
+	row = row[:min(len(row), m.t.mat.Rows)]
+	for j := range row {
+		row[j] = m.t.at(j, r)
+	}
+	return row
+}
+
+// Col copies the elements of column c into col, allocating a new slice if col is
+// nil, and returns it.
+func (m *ColDense) Col(col []float64, c int) []float64 {
+	if c >= m.t.mat.Rows || c < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	if col == nil {
+		col = make([]float64, m.t.mat.Cols)
+	}
+	copy(col, m.t.rowView(c))
+	return col
+}
+
+// ColView returns a slice sharing the storage of column c.
+func (m *ColDense) ColView(c int) []float64 {
+	if c >= m.t.mat.Rows || c < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	return m.t.rowView(c)
+}
+
+// T returns the transpose of the matrix as a row-major matrix sharing its
+// storage.
+func (m *ColDense) T() *Dense {
+	t := m.t
+	return &t
+}
+
+// Dense returns a newly allocated row-major copy of the matrix.
+func (m *ColDense) Dense() *Dense {
+	var d Dense
+	d.TCopy(&m.t)
+	return &d
+}
+
+// Mul places the matrix product of a and b in the receiver, which is allocated
+// if it is empty. The transpose of the product, which shares the receiver's
+// storage, is formed by Dense.MulAdd, so the operands are not copied when they
+// are Dense or ColDense.
+func (m *ColDense) Mul(a, b Matrix) {
+	m.t.MulAdd(1, b, true, a, true, 0, nil)
+}
+
+// rowMajorOp returns a matrix and transpose flag describing the same operand as
+// a and trans, replacing a column-major matrix by its row-major transpose.
+func rowMajorOp(a Matrix, trans bool) (Matrix, bool) {
+	if cd, ok := a.(*ColDense); ok {
+		return &cd.t, !trans
+	}
+	return a, trans
+}
@@ -0,0 +1,92 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/rand"
+
+	"github.com/gonum/blas"
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestColDense(c *check.C) {
+	// A 2-by-3 matrix in column-major order.
+	data := []float64{1, 4, 2, 5, 3, 6}
+	m := NewColDense(2, 3, data)
+	want := NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	r, cols := m.Dims()
+	c.Check(r == 2 && cols == 3, check.Equals, true)
+	c.Check(m.Dense().Equals(want), check.Equals, true)
+	c.Check(m.Row(nil, 1), check.DeepEquals, []float64{4, 5, 6})
+	c.Check(m.Col(nil, 2), check.DeepEquals, []float64{3, 6})
+
+	// The storage is shared with the caller and the transpose view.
+	m.Set(1, 0, 10)
+	c.Check(data[1], check.Equals, 10.0)
+	m.ColView(2)[0] = 7
+	c.Check(m.At(0, 2), check.Equals, 7.0)
+	m.T().Set(1, 1, 11)
+	c.Check(m.At(1, 1), check.Equals, 11.0)
+	got, ld := m.ColMajor()
+	c.Check(ld, check.Equals, 2)
+	c.Check(&got[0] == &data[0], check.Equals, true)
+
+	// A leading dimension larger than the number of rows skips padding.
+	padded := []float64{1, 4, -1, 2, 5, -1, 3, 6}
+	p := NewColDenseStride(2, 3, 3, padded)
+	c.Check(p.Dense().Equals(want), check.Equals, true)
+	c.Check(ColDenseCopyOf(want).Dense().Equals(want), check.Equals, true)
+
+	c.Check(func() { NewColDense(2, 3, make([]float64, 5)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { NewColDenseStride(3, 2, 2, make([]float64, 6)) }, check.PanicMatches, string(ErrIllegalStride))
+	c.Check(func() { NewColDenseStride(2, 3, 3, make([]float64, 7)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { m.At(2, 0) }, check.PanicMatches, string(ErrRowAccess))
+	c.Check(func() { m.Set(0, 3, 0) }, check.PanicMatches, string(ErrColAccess))
+	c.Check(func() { m.Col(nil, 3) }, check.PanicMatches, string(ErrIndexOutOfRange))
+}
+
+func (s *S) TestColDenseMul(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	engine := Registered()
+	defer Register(engine)
+	for _, e := range []blas.Float64{engine, nil} {
+		Register(e)
+		for _, test := range []struct{ m, n, k int }{
+			{1, 1, 1}, {3, 4, 5}, {9, 2, 70},
+		} {
+			a := NewNormal(test.m, test.k, 0, 1, rnd)
+			b := NewNormal(test.k, test.n, 0, 1, rnd)
+			var want Dense
+			want.Mul(a, b)
+
+			ca, cb := ColDenseCopyOf(a), ColDenseCopyOf(b)
+			for _, ops := range []struct{ a, b Matrix }{
+				{ca, cb}, {ca, b}, {a, cb},
+			} {
+				var d Dense
+				d.Mul(ops.a, ops.b)
+				c.Check(d.EqualsApprox(&want, 1e-12), check.Equals, true)
+
+				var cm ColDense
+				cm.Mul(ops.a, ops.b)
+				c.Check(cm.Dense().EqualsApprox(&want, 1e-12), check.Equals, true)
+			}
+
+			// A column-major operand is used transposed.
+			var ata, at, gram Dense
+			at.TCopy(a)
+			gram.Mul(&at, a)
+			ata.MulAdd(1, ca, true, ca, false, 0, nil)
+			c.Check(ata.EqualsApprox(&gram, 1e-12), check.Equals, true)
+
+			x := NewNormal(test.n, 2, 0, 1, rnd)
+			got := Product(ca, cb, ColDenseCopyOf(x))
+			c.Check(got.EqualsApprox(Product(a, b, x), 1e-12), check.Equals, true)
+		}
+	}
+
+	var m ColDense
+	c.Check(func() { m.Mul(NewColDense(2, 3, nil), NewColDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+}
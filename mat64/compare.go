@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math"
+
+// EqualApprox returns whether the matrices a and b have the same shape and all
+// of their elements are equal or differ in absolute value by no more than tol.
+// Infinities of the same sign compare equal and NaN elements compare unequal.
+// This differs from Dense.EqualsApprox, which compares the absolute difference
+// alone and so treats a NaN element as equal to any value.
+func EqualApprox(a, b Matrix, tol float64) bool {
+	return equalFunc(a, b, func(x, y float64) bool {
+		return x == y || math.Abs(x-y) <= tol
+	})
+}
+
+// EqualApproxRel returns whether the matrices a and b have the same shape and all
+// of their elements differ by no more than tol relative to the larger magnitude
+// of each pair of elements, that is |x-y| <= tol*max(|x|, |y|). Equal elements,
+// including infinities of the same sign, always compare equal, while NaN elements
+// and an infinity paired with any other value compare unequal.
+func EqualApproxRel(a, b Matrix, tol float64) bool {
+	return equalFunc(a, b, func(x, y float64) bool {
+		if x == y {
+			return true
+		}
+		if math.IsInf(x, 0) || math.IsInf(y, 0) {
+			return false
+		}
+		return math.Abs(x-y) <= tol*math.Max(math.Abs(x), math.Abs(y))
+	})
+}
+
+func equalFunc(a, b Matrix, eq func(x, y float64) bool) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+
+	if a, ok := a.(RawMatrixer); ok {
+		if b, ok := b.(RawMatrixer); ok {
+			amat, bmat := a.RawMatrix(), b.RawMatrix()
+			for ja, jb := 0, 0; ja < ar*amat.Stride; ja, jb = ja+amat.Stride, jb+bmat.Stride {
+				for i, v := range amat.Data[ja : ja+ac] {
+					if !eq(v, bmat.Data[i+jb]) {
+						return false
+					}
+				}
+			}
+			return true
+		}
+	}
+
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			if !eq(a.At(r, c), b.At(r, c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Diff returns the location and absolute magnitude of the largest element-wise
+// difference between a and b. If any pair of elements differ by NaN, the first
+// such location is returned with a NaN magnitude. Diff will panic with ErrShape
+// if a and b do not have the same shape.
+func Diff(a, b Matrix) (r, c int, d float64) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			v := math.Abs(a.At(i, j) - b.At(i, j))
+			if math.IsNaN(v) {
+				return i, j, v
+			}
+			if v > d {
+				r, c, d = i, j, v
+			}
+		}
+	}
+	return r, c, d
+}
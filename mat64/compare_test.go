@@ -0,0 +1,93 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestEqualApprox(c *check.C) {
+	for i, test := range []struct {
+		a, b     Matrix
+		tol      float64
+		abs, rel bool
+	}{
+		{
+			a:   NewDense(2, 2, []float64{1, 2, 3, 4}),
+			b:   NewDense(2, 2, []float64{1, 2, 3, 4}),
+			tol: 0,
+			abs: true, rel: true,
+		},
+		{
+			a:   NewDense(2, 2, []float64{1, 2, 3, 4}),
+			b:   NewDense(2, 2, []float64{1, 2, 3, 4.1}),
+			tol: 0.05,
+			abs: false, rel: true,
+		},
+		{
+			a:   NewDense(2, 2, []float64{1e-3, 2, 3, 4}),
+			b:   NewDense(2, 2, []float64{2e-3, 2, 3, 4}),
+			tol: 0.01,
+			abs: true, rel: false,
+		},
+		{
+			a:   NewDense(2, 2, []float64{1, 2, 3, 4}),
+			b:   (*basicMatrix)(NewDense(2, 2, []float64{1, 2, 3, 4})),
+			tol: 0,
+			abs: true, rel: true,
+		},
+		{
+			a:   NewDense(2, 2, []float64{1, 2, 3, 4}),
+			b:   NewDense(1, 4, []float64{1, 2, 3, 4}),
+			tol: 1,
+			abs: false, rel: false,
+		},
+		{
+			a:   NewDense(1, 2, []float64{1, math.NaN()}),
+			b:   NewDense(1, 2, []float64{1, math.NaN()}),
+			tol: 1,
+			abs: false, rel: false,
+		},
+		{
+			a:   NewDense(1, 2, []float64{math.Inf(1), math.Inf(-1)}),
+			b:   NewDense(1, 2, []float64{math.Inf(1), math.Inf(-1)}),
+			tol: 0,
+			abs: true, rel: true,
+		},
+		{
+			a:   NewDense(1, 2, []float64{1, math.Inf(1)}),
+			b:   NewDense(1, 2, []float64{1, math.Inf(-1)}),
+			tol: 1,
+			abs: false, rel: false,
+		},
+	} {
+		c.Check(EqualApprox(test.a, test.b, test.tol), check.Equals, test.abs, check.Commentf("Test %d", i))
+		c.Check(EqualApproxRel(test.a, test.b, test.tol), check.Equals, test.rel, check.Commentf("Test %d", i))
+	}
+}
+
+func (s *S) TestDiff(c *check.C) {
+	a := NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	b := NewDense(2, 3, []float64{1, 2.5, 3, 4, 5, 4})
+	r, col, d := Diff(a, b)
+	c.Check(r, check.Equals, 1)
+	c.Check(col, check.Equals, 2)
+	c.Check(d, check.Equals, 2.0)
+
+	r, col, d = Diff(a, a)
+	c.Check(r, check.Equals, 0)
+	c.Check(col, check.Equals, 0)
+	c.Check(d, check.Equals, 0.0)
+
+	b.Set(0, 1, math.NaN())
+	r, col, d = Diff(a, b)
+	c.Check(r, check.Equals, 0)
+	c.Check(col, check.Equals, 1)
+	c.Check(math.IsNaN(d), check.Equals, true)
+
+	c.Check(func() { Diff(a, eye()) }, check.PanicMatches, string(ErrShape))
+}
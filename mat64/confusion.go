@@ -0,0 +1,103 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// ConfusionMatrix returns the classes-by-classes confusion matrix of the predicted
+// class labels in pred against the true labels in truth. Element (i, j) holds the
+// number of observations of class i that were predicted to be class j. Class
+// labels must lie in [0, classes). ConfusionMatrix will panic with ErrShape if
+// pred and truth differ in length and with ErrIndexOutOfRange for an invalid
+// label.
+func ConfusionMatrix(pred, truth []int, classes int) *Dense {
+	if len(pred) != len(truth) {
+		panic(ErrShape)
+	}
+	if classes <= 0 {
+		panic(ErrZeroLength)
+	}
+	m := NewDense(classes, classes, nil)
+	for k, p := range pred {
+		t := truth[k]
+		if p < 0 || p >= classes || t < 0 || t >= classes {
+			panic(ErrIndexOutOfRange)
+		}
+		m.mat.Data[t*m.mat.Stride+p]++
+	}
+	return m
+}
+
+// Accuracy returns the fraction of observations counted by the confusion matrix
+// cm that were correctly classified. Accuracy will panic with ErrSquare if cm is
+// not square.
+func Accuracy(cm Matrix) float64 {
+	n := confusionOrder(cm)
+	var correct, total float64
+	for i := 0; i < n; i++ {
+		correct += cm.At(i, i)
+		for j := 0; j < n; j++ {
+			total += cm.At(i, j)
+		}
+	}
+	return correct / total
+}
+
+// Precision returns the fraction of the observations predicted to be the given
+// class that belong to the class, from the confusion matrix cm. The result is
+// NaN if the class was never predicted.
+func Precision(cm Matrix, class int) float64 {
+	n := confusionOrder(cm)
+	if class < 0 || class >= n {
+		panic(ErrIndexOutOfRange)
+	}
+	var predicted float64
+	for i := 0; i < n; i++ {
+		predicted += cm.At(i, class)
+	}
+	return cm.At(class, class) / predicted
+}
+
+// Recall returns the fraction of the observations of the given class that were
+// predicted to be the class, from the confusion matrix cm. The result is NaN if
+// the class does not occur.
+func Recall(cm Matrix, class int) float64 {
+	n := confusionOrder(cm)
+	if class < 0 || class >= n {
+		panic(ErrIndexOutOfRange)
+	}
+	var actual float64
+	for j := 0; j < n; j++ {
+		actual += cm.At(class, j)
+	}
+	return cm.At(class, class) / actual
+}
+
+// F1 returns the harmonic mean of the precision and recall of the given class
+// from the confusion matrix cm.
+func F1(cm Matrix, class int) float64 {
+	p, r := Precision(cm, class), Recall(cm, class)
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+// MacroF1 returns the unweighted mean over all classes of the F1 score from the
+// confusion matrix cm.
+func MacroF1(cm Matrix) float64 {
+	n := confusionOrder(cm)
+	var sum float64
+	for c := 0; c < n; c++ {
+		sum += F1(cm, c)
+	}
+	return sum / float64(n)
+}
+
+func confusionOrder(cm Matrix) int {
+	r, c := cm.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	return r
+}
@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestConfusionMatrix(c *check.C) {
+	truth := []int{0, 0, 0, 1, 1, 2, 2, 2}
+	pred := []int{0, 1, 0, 1, 1, 2, 0, 1}
+	cm := ConfusionMatrix(pred, truth, 4)
+	c.Check(cm.Equals(NewDense(4, 4, []float64{
+		2, 1, 0, 0,
+		0, 2, 0, 0,
+		1, 1, 1, 0,
+		0, 0, 0, 0,
+	})), check.Equals, true)
+
+	c.Check(Accuracy(cm), check.Equals, 5.0/8)
+	c.Check(Precision(cm, 0), check.Equals, 2.0/3)
+	c.Check(Recall(cm, 0), check.Equals, 2.0/3)
+	c.Check(Precision(cm, 1), check.Equals, 0.5)
+	c.Check(Recall(cm, 1), check.Equals, 1.0)
+	c.Check(math.Abs(F1(cm, 1)-2.0/3) < 1e-15, check.Equals, true)
+	c.Check(Precision(cm, 2), check.Equals, 1.0)
+	c.Check(math.IsNaN(Precision(cm, 3)), check.Equals, true)
+	c.Check(math.IsNaN(Recall(cm, 3)), check.Equals, true)
+
+	cm3 := ConfusionMatrix(pred, truth, 3)
+	want := (2.0/3 + 2.0/3 + 0.5) / 3
+	c.Check(math.Abs(MacroF1(cm3)-want) < 1e-15, check.Equals, true)
+
+	c.Check(func() { ConfusionMatrix(pred, truth[1:], 3) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { ConfusionMatrix(pred, truth, 2) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { Recall(cm, 4) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { Accuracy(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrSquare))
+}
@@ -0,0 +1,66 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "context"
+
+// canceled is the panic value used to unwind an iterative decomposition whose
+// done channel has been closed. It is recovered by withContext.
+type canceled struct{}
+
+// checkDone panics with canceled if done is closed. A nil done is never closed.
+func checkDone(done <-chan struct{}) {
+	select {
+	case <-done:
+		panic(canceled{})
+	default:
+	}
+}
+
+// withContext calls fn with the done channel of ctx and returns ctx.Err() if
+// fn was abandoned because ctx was done, or if ctx was done before the call.
+// Other panics are propagated.
+func withContext(ctx context.Context, fn func(done <-chan struct{})) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(canceled); ok {
+				err = ctx.Err()
+				return
+			}
+			panic(r)
+		}
+	}()
+	fn(ctx.Done())
+	return nil
+}
+
+// EigenContext returns the eigen decomposition of the square matrix a as for
+// Eigen. The context is checked after each sweep of the iteration, and if it is
+// cancelled or its deadline passes the decomposition is abandoned and ctx.Err()
+// is returned. The matrix a is overwritten in either case.
+//
+// A decomposition computed by a registered LAPACK backend cannot be abandoned.
+func EigenContext(ctx context.Context, a *Dense, epsilon float64) (f EigenFactors, err error) {
+	err = withContext(ctx, func(done <-chan struct{}) {
+		f = eigen(a, epsilon, done)
+	})
+	return f, err
+}
+
+// SVDContext returns the singular value decomposition of a as for SVD. The
+// context is checked after each sweep of the iteration, and if it is cancelled
+// or its deadline passes the decomposition is abandoned and ctx.Err() is
+// returned. The matrix a is overwritten in either case.
+//
+// A decomposition computed by a registered LAPACK backend cannot be abandoned.
+func SVDContext(ctx context.Context, a *Dense, epsilon, small float64, wantu, wantv bool) (f SVDFactors, err error) {
+	err = withContext(ctx, func(done <-chan struct{}) {
+		f = svd(a, epsilon, small, wantu, wantv, done)
+	})
+	return f, err
+}
@@ -0,0 +1,92 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"context"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+// expiringContext is a context that is done, but reports no error to the
+// first call to Err, so that operations start and are then abandoned at their
+// first check.
+type expiringContext struct {
+	context.Context
+	done  chan struct{}
+	calls int
+}
+
+func newExpiringContext() *expiringContext {
+	c := &expiringContext{Context: context.Background(), done: make(chan struct{})}
+	close(c.done)
+	return c
+}
+
+func (c *expiringContext) Done() <-chan struct{} { return c.done }
+
+func (c *expiringContext) Err() error {
+	c.calls++
+	if c.calls == 1 {
+		return nil
+	}
+	return context.Canceled
+}
+
+func (s *S) TestContextDecompositions(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(6, 6, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = rnd.NormFloat64()
+	}
+	var at, sym Dense
+	at.TCopy(a)
+	sym.Add(a, &at)
+
+	for _, m := range []*Dense{a, &sym} {
+		want := Eigen(DenseCopyOf(m), epsilon)
+		got, err := EigenContext(context.Background(), DenseCopyOf(m), epsilon)
+		c.Check(err, check.IsNil)
+		c.Check(got, check.DeepEquals, want)
+
+		_, err = EigenContext(newExpiringContext(), DenseCopyOf(m), epsilon)
+		c.Check(err, check.Equals, context.Canceled)
+	}
+
+	want := SVD(DenseCopyOf(a), epsilon, small, true, true)
+	got, err := SVDContext(context.Background(), DenseCopyOf(a), epsilon, small, true, true)
+	c.Check(err, check.IsNil)
+	c.Check(got.Sigma, check.DeepEquals, want.Sigma)
+	_, err = SVDContext(newExpiringContext(), DenseCopyOf(a), epsilon, small, true, true)
+	c.Check(err, check.Equals, context.Canceled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = EigenContext(ctx, DenseCopyOf(a), epsilon)
+	c.Check(err, check.Equals, context.Canceled)
+
+	// Other panics are not recovered.
+	c.Check(func() { EigenContext(context.Background(), NewDense(2, 3, nil), epsilon) }, check.PanicMatches, string(ErrSquare))
+}
+
+func (s *S) TestFastICAContext(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	data := NewDense(200, 3, nil)
+	for i := range data.mat.Data {
+		data.mat.Data[i] = rnd.Float64()
+	}
+	for _, sym := range []bool{false, true} {
+		opts := &ICAOptions{Symmetric: sym, Src: rand.New(rand.NewSource(2))}
+		want := FastICA(data, 2, opts)
+		opts.Src = rand.New(rand.NewSource(2))
+		got, err := FastICAContext(context.Background(), data, 2, opts)
+		c.Check(err, check.IsNil)
+		c.Check(got.Unmixing.Equals(want.Unmixing), check.Equals, true)
+
+		_, err = FastICAContext(newExpiringContext(), data, 2, opts)
+		c.Check(err, check.Equals, context.Canceled)
+	}
+}
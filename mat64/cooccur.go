@@ -0,0 +1,229 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "sort"
+
+var _ Matrix = (*SymSparse)(nil)
+
+// SymSparse is a square symmetric matrix held in compressed sparse row form.
+// Both triangles are stored, so that rows may be traversed directly. Elements
+// that are not stored are zero.
+type SymSparse struct {
+	n      int
+	rowPtr []int
+	colIdx []int
+	vals   []float64
+}
+
+func (m *SymSparse) Dims() (r, c int) { return m.n, m.n }
+
+// At returns the element at row r and column c.
+func (m *SymSparse) At(r, c int) float64 {
+	if r >= m.n || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= m.n || c < 0 {
+		panic(ErrColAccess)
+	}
+	cols := m.colIdx[m.rowPtr[r]:m.rowPtr[r+1]]
+	k := sort.SearchInts(cols, c)
+	if k < len(cols) && cols[k] == c {
+		return m.vals[m.rowPtr[r]+k]
+	}
+	return 0
+}
+
+// NNZ returns the number of stored elements, counting each off-diagonal
+// element in both triangles.
+func (m *SymSparse) NNZ() int { return len(m.vals) }
+
+// Row calls fn for each stored element of row r in increasing column order.
+func (m *SymSparse) Row(r int, fn func(c int, v float64)) {
+	for k := m.rowPtr[r]; k < m.rowPtr[r+1]; k++ {
+		fn(m.colIdx[k], m.vals[k])
+	}
+}
+
+// MulVec sets dst to the product of the matrix with x and returns dst. If dst
+// is nil a new slice is allocated. MulVec will panic with ErrShape if x or a
+// non-nil dst does not have length equal to the order of the matrix.
+func (m *SymSparse) MulVec(dst, x []float64) []float64 {
+	if len(x) != m.n {
+		panic(ErrShape)
+	}
+	if dst == nil {
+		dst = make([]float64, m.n)
+	} else if len(dst) != m.n {
+		panic(ErrShape)
+	}
+	for r := 0; r < m.n; r++ {
+		var s float64
+		for k := m.rowPtr[r]; k < m.rowPtr[r+1]; k++ {
+			s += m.vals[k] * x[m.colIdx[k]]
+		}
+		dst[r] = s
+	}
+	return dst
+}
+
+// Apply sets dst to the product of the matrix with x, as required by the
+// LinearOperator interface.
+func (m *SymSparse) Apply(dst, x []float64) {
+	checkApply(m, dst, x)
+	m.MulVec(dst, x)
+}
+
+// Dense returns a newly allocated dense copy of the matrix.
+func (m *SymSparse) Dense() *Dense {
+	d := NewDense(m.n, m.n, nil)
+	for r := 0; r < m.n; r++ {
+		row := d.rowView(r)
+		for k := m.rowPtr[r]; k < m.rowPtr[r+1]; k++ {
+			row[m.colIdx[k]] = m.vals[k]
+		}
+	}
+	return d
+}
+
+// CooccurrenceOptions specifies how a CooccurrenceBuilder weights the pairs of
+// tokens it counts.
+type CooccurrenceOptions struct {
+	// Window is the number of preceding tokens with which each
+	// token is paired. A Window of zero is treated as one.
+	Window int
+
+	// Weight returns the weight of a pair of tokens separated by
+	// distance positions, with 1 <= distance <= Window. If Weight
+	// is nil the harmonic weight 1/distance is used.
+	Weight func(distance int) float64
+}
+
+// CooccurrenceBuilder accumulates a symmetric co-occurrence matrix from a
+// stream of tokens in [0, n), such as the word indices of a corpus. Each token
+// is paired with each of the tokens up to Window positions before it, adding
+// the weight of the pair to both the (i, j) and (j, i) elements, so that a
+// token paired with itself adds twice the weight to its diagonal element.
+//
+// Only the pairs that occur are stored, so the memory used is proportional to
+// the number of distinct pairs in the stream rather than to n².
+type CooccurrenceBuilder struct {
+	n      int
+	window int
+	weight func(int) float64
+
+	// recent holds up to the last window tokens of the current
+	// document, oldest first.
+	recent []int
+
+	counts map[[2]int]float64
+}
+
+// NewCooccurrenceBuilder returns a builder for a co-occurrence matrix of order
+// n. If opts is nil, a window of one and the harmonic weight are used.
+func NewCooccurrenceBuilder(n int, opts *CooccurrenceOptions) *CooccurrenceBuilder {
+	if n <= 0 {
+		panic(ErrZeroLength)
+	}
+	if opts == nil {
+		opts = &CooccurrenceOptions{}
+	}
+	b := &CooccurrenceBuilder{
+		n:      n,
+		window: max(opts.Window, 1),
+		weight: opts.Weight,
+		counts: make(map[[2]int]float64),
+	}
+	if b.weight == nil {
+		b.weight = func(d int) float64 { return 1 / float64(d) }
+	}
+	return b
+}
+
+// Add appends tokens to the stream. Pairs are formed across calls to Add until
+// Break is called. Add will panic with ErrIndexOutOfRange if a token is not in
+// [0, n).
+func (b *CooccurrenceBuilder) Add(tokens ...int) {
+	for _, t := range tokens {
+		if t < 0 || t >= b.n {
+			panic(ErrIndexOutOfRange)
+		}
+		// Visit the preceding tokens from the nearest.
+		for d := 1; d <= len(b.recent); d++ {
+			u := b.recent[len(b.recent)-d]
+			w := b.weight(d)
+			if t == u {
+				b.counts[[2]int{t, t}] += 2 * w
+			} else {
+				b.counts[[2]int{min(t, u), max(t, u)}] += w
+			}
+		}
+		if len(b.recent) == b.window {
+			copy(b.recent, b.recent[1:])
+			b.recent = b.recent[:b.window-1]
+		}
+		b.recent = append(b.recent, t)
+	}
+}
+
+// Break marks the end of a document, so that tokens added after the call are
+// not paired with those added before it.
+func (b *CooccurrenceBuilder) Break() {
+	b.recent = b.recent[:0]
+}
+
+// Pairs returns the number of distinct unordered pairs counted so far.
+func (b *CooccurrenceBuilder) Pairs() int { return len(b.counts) }
+
+// Matrix returns the co-occurrence matrix of the tokens added so far. The
+// builder may continue to be used after Matrix is called.
+func (b *CooccurrenceBuilder) Matrix() *SymSparse {
+	rowNNZ := make([]int, b.n+1)
+	for k := range b.counts {
+		rowNNZ[k[0]+1]++
+		if k[0] != k[1] {
+			rowNNZ[k[1]+1]++
+		}
+	}
+	for i := 1; i <= b.n; i++ {
+		rowNNZ[i] += rowNNZ[i-1]
+	}
+	m := &SymSparse{
+		n:      b.n,
+		rowPtr: rowNNZ,
+		colIdx: make([]int, rowNNZ[b.n]),
+		vals:   make([]float64, rowNNZ[b.n]),
+	}
+	pos := make([]int, b.n)
+	copy(pos, rowNNZ)
+	put := func(r, c int, v float64) {
+		m.colIdx[pos[r]] = c
+		m.vals[pos[r]] = v
+		pos[r]++
+	}
+	for k, v := range b.counts {
+		put(k[0], k[1], v)
+		if k[0] != k[1] {
+			put(k[1], k[0], v)
+		}
+	}
+	for r := 0; r < b.n; r++ {
+		sort.Sort(csrRow{m.colIdx[m.rowPtr[r]:m.rowPtr[r+1]], m.vals[m.rowPtr[r]:m.rowPtr[r+1]]})
+	}
+	return m
+}
+
+// csrRow sorts the elements of a compressed row by column.
+type csrRow struct {
+	cols []int
+	vals []float64
+}
+
+func (r csrRow) Len() int           { return len(r.cols) }
+func (r csrRow) Less(i, j int) bool { return r.cols[i] < r.cols[j] }
+func (r csrRow) Swap(i, j int) {
+	r.cols[i], r.cols[j] = r.cols[j], r.cols[i]
+	r.vals[i], r.vals[j] = r.vals[j], r.vals[i]
+}
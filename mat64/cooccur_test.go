@@ -0,0 +1,94 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestCooccurrenceBuilder(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 12
+	docs := make([][]int, 3)
+	for i := range docs {
+		docs[i] = make([]int, 40+i)
+		for j := range docs[i] {
+			docs[i][j] = rnd.Intn(n)
+		}
+	}
+
+	for _, opts := range []*CooccurrenceOptions{
+		nil,
+		{Window: 3},
+		{Window: 5, Weight: func(d int) float64 { return math.Exp(-float64(d)) }},
+	} {
+		window, weight := 1, func(d int) float64 { return 1 / float64(d) }
+		if opts != nil {
+			window = opts.Window
+			if opts.Weight != nil {
+				weight = opts.Weight
+			}
+		}
+
+		want := NewDense(n, n, nil)
+		b := NewCooccurrenceBuilder(n, opts)
+		for _, doc := range docs {
+			for i, t := range doc {
+				for d := 1; d <= window && i-d >= 0; d++ {
+					u := doc[i-d]
+					want.Set(t, u, want.At(t, u)+weight(d))
+					want.Set(u, t, want.At(u, t)+weight(d))
+				}
+			}
+			// Split each document across calls to Add.
+			b.Add(doc[:len(doc)/2]...)
+			b.Add(doc[len(doc)/2:]...)
+			b.Break()
+		}
+
+		m := b.Matrix()
+		r, cols := m.Dims()
+		c.Check(r, check.Equals, n)
+		c.Check(cols, check.Equals, n)
+		c.Check(m.Dense().EqualsApprox(want, 1e-12), check.Equals, true)
+
+		var nnz int
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				c.Check(math.Abs(m.At(i, j)-want.At(i, j)) < 1e-12, check.Equals, true)
+				if want.At(i, j) != 0 {
+					nnz++
+				}
+			}
+			prev := -1
+			m.Row(i, func(col int, v float64) {
+				c.Check(col > prev, check.Equals, true)
+				prev = col
+			})
+		}
+		c.Check(m.NNZ(), check.Equals, nnz)
+
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = rnd.NormFloat64()
+		}
+		var wantY Dense
+		wantY.Mul(want, NewDense(n, 1, x))
+		y := m.MulVec(nil, x)
+		for i, v := range y {
+			c.Check(math.Abs(v-wantY.At(i, 0)) < 1e-12, check.Equals, true)
+		}
+	}
+
+	b := NewCooccurrenceBuilder(3, nil)
+	b.Add(1, 1)
+	c.Check(b.Matrix().At(1, 1), check.Equals, 2.)
+	c.Check(b.Pairs(), check.Equals, 1)
+	c.Check(func() { b.Add(3) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { b.Matrix().MulVec(nil, []float64{1}) }, check.PanicMatches, string(ErrShape))
+}
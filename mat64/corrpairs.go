@@ -0,0 +1,142 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// CorrPair holds the Pearson correlation R of the columns I and J of a matrix,
+// with I < J.
+type CorrPair struct {
+	I, J int
+	R    float64
+}
+
+// corrBlockSize is the number of columns in each block of the pairwise
+// correlation screen.
+const corrBlockSize = 64
+
+// CorrelatedPairs returns the pairs of distinct columns of the n-by-p matrix a
+// whose Pearson correlation has magnitude at least t, ordered by I and then J.
+// Columns with zero variance are not correlated with any column.
+//
+// The p-by-p correlation matrix is never formed. The columns are standardized
+// and the pairs are screened in blocks of columns shared among up to MaxProcs
+// goroutines. For standardized columns x and y, |r| >= t exactly when one of
+// |x-y|² and |x+y|² is at most 2(1-t), so the accumulation for a pair is
+// abandoned as soon as both partial sums exceed that bound; for large t most
+// pairs are rejected after examining a small part of the columns.
+func CorrelatedPairs(a Matrix, t float64) []CorrPair {
+	n, p := a.Dims()
+	if n < 2 || t > 1 {
+		return nil
+	}
+
+	// Store the standardized columns of a as contiguous rows.
+	z := NewDense(p, n, nil)
+	ok := make([]bool, p)
+	for j := 0; j < p; j++ {
+		col := z.rowView(j)
+		var mean float64
+		for i := range col {
+			col[i] = a.At(i, j)
+			mean += col[i]
+		}
+		mean /= float64(n)
+		var ss float64
+		for i := range col {
+			col[i] -= mean
+			ss += col[i] * col[i]
+		}
+		if ss == 0 || math.IsNaN(ss) {
+			continue
+		}
+		scalUnitary(1/math.Sqrt(ss), col)
+		ok[j] = true
+	}
+
+	bound := 2 * (1 - math.Max(t, 0))
+	nb := (p + corrBlockSize - 1) / corrBlockSize
+	type job struct{ bi, bj int }
+	jobs := make(chan job)
+	results := make([][]CorrPair, max(min(maxProcs, nb*(nb+1)/2), 1))
+	var wg sync.WaitGroup
+	for w := range results {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for jb := range jobs {
+				iHi := min((jb.bi+1)*corrBlockSize, p)
+				jHi := min((jb.bj+1)*corrBlockSize, p)
+				for i := jb.bi * corrBlockSize; i < iHi; i++ {
+					if !ok[i] {
+						continue
+					}
+					jLo := jb.bj * corrBlockSize
+					if jb.bi == jb.bj {
+						jLo = i + 1
+					}
+					for j := jLo; j < jHi; j++ {
+						if !ok[j] {
+							continue
+						}
+						if r, hit := screenPair(z.rowView(i), z.rowView(j), bound); hit && math.Abs(r) >= t {
+							results[w] = append(results[w], CorrPair{I: i, J: j, R: r})
+						}
+					}
+				}
+			}
+		}(w)
+	}
+	for bi := 0; bi < nb; bi++ {
+		for bj := bi; bj < nb; bj++ {
+			jobs <- job{bi, bj}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	var pairs []CorrPair
+	for _, r := range results {
+		pairs = append(pairs, r...)
+	}
+	sort.Sort(byCorrPair(pairs))
+	return pairs
+}
+
+// screenPair returns the correlation of the standardized vectors x and y, and
+// whether it was computed. The computation is abandoned, returning false, once
+// the partial sums of both |x-y|² and |x+y|² exceed bound.
+func screenPair(x, y []float64, bound float64) (r float64, ok bool) {
+	const chunk = 16
+	var dm, dp float64
+	for lo := 0; lo < len(x); lo += chunk {
+		hi := min(lo+chunk, len(x))
+		for i, v := range x[lo:hi] {
+			w := y[lo+i]
+			dm += (v - w) * (v - w)
+			dp += (v + w) * (v + w)
+		}
+		if dm > bound && dp > bound {
+			return 0, false
+		}
+	}
+	// |x-y|² = 2 - 2r for unit vectors.
+	return (dp - dm) / 4, true
+}
+
+type byCorrPair []CorrPair
+
+func (p byCorrPair) Len() int      { return len(p) }
+func (p byCorrPair) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p byCorrPair) Less(i, j int) bool {
+	if p[i].I != p[j].I {
+		return p[i].I < p[j].I
+	}
+	return p[i].J < p[j].J
+}
@@ -0,0 +1,70 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestCorrelatedPairs(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	n, p := 50, 150
+	a := NewDense(n, p, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < p; j++ {
+			a.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	// Plant correlated columns across block boundaries and a constant column.
+	for i := 0; i < n; i++ {
+		a.Set(i, 100, -3*a.At(i, 2)+1)
+		a.Set(i, 70, a.At(i, 5)+0.1*rnd.NormFloat64())
+		a.Set(i, 40, 7)
+	}
+
+	cols := make([][]float64, p)
+	for j := range cols {
+		cols[j] = make([]float64, n)
+		for i := range cols[j] {
+			cols[j][i] = a.At(i, j)
+		}
+	}
+
+	for _, t := range []float64{0.9, 0.5, 0.3} {
+		var want []CorrPair
+		for i := 0; i < p; i++ {
+			for j := i + 1; j < p; j++ {
+				if i == 40 || j == 40 {
+					continue
+				}
+				if r := correlation(cols[i], cols[j]); math.Abs(r) >= t {
+					want = append(want, CorrPair{I: i, J: j, R: r})
+				}
+			}
+		}
+
+		for _, procs := range []int{1, 4} {
+			old := SetMaxProcs(procs)
+			got := CorrelatedPairs(a, t)
+			SetMaxProcs(old)
+
+			c.Assert(len(got), check.Equals, len(want), check.Commentf("t=%v procs=%d", t, procs))
+			for k := range got {
+				c.Check(got[k].I, check.Equals, want[k].I)
+				c.Check(got[k].J, check.Equals, want[k].J)
+				c.Check(math.Abs(got[k].R-want[k].R) < 1e-12, check.Equals, true)
+			}
+		}
+	}
+
+	got := CorrelatedPairs(a, 0.999)
+	c.Check(got, check.DeepEquals, []CorrPair{{I: 2, J: 100, R: got[0].R}})
+	c.Check(math.Abs(got[0].R+1) < 1e-12, check.Equals, true)
+
+	c.Check(CorrelatedPairs(NewDense(1, 3, nil), 0), check.IsNil)
+}
@@ -0,0 +1,175 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math"
+
+const ErrOverflow = Error("mat64: integer overflow")
+
+// OverflowMode specifies how integer accumulation into a Count matrix handles
+// results that cannot be represented by an int64.
+type OverflowMode int
+
+const (
+	// Wrap wraps overflowing results modulo 2^64, as for the
+	// built in int64 arithmetic.
+	Wrap OverflowMode = iota
+
+	// Checked panics with ErrOverflow when a result overflows. The
+	// element being updated is left unchanged.
+	Checked
+
+	// Saturate clamps overflowing results to math.MaxInt64 or
+	// math.MinInt64 and records that saturation has occurred.
+	Saturate
+)
+
+var _ Matrix = (*Count)(nil)
+
+// Count is a dense matrix of int64 counts for accumulation heavy work such as
+// co-occurrence counting. Elements are accumulated with the matrix's
+// OverflowMode so that overflow is either detected or bounded rather than
+// silently corrupting the counts. Count satisfies Matrix, so the counts may be
+// used directly as the input to the floating point operations of the package.
+type Count struct {
+	rows, cols int
+	data       []int64
+
+	mode      OverflowMode
+	saturated bool
+}
+
+// NewCount returns a new r-by-c Count matrix with the given overflow mode. If
+// data is not nil it is used as the backing slice in row-major order and must
+// have length r*c, otherwise the matrix is zeroed.
+func NewCount(r, c int, mode OverflowMode, data []int64) *Count {
+	if data != nil && r*c != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]int64, r*c)
+	}
+	return &Count{rows: r, cols: c, data: data, mode: mode}
+}
+
+func (m *Count) Dims() (r, c int) { return m.rows, m.cols }
+
+// Mode returns the overflow mode of the matrix.
+func (m *Count) Mode() OverflowMode { return m.mode }
+
+// Saturated returns whether any accumulation into a matrix with mode Saturate
+// has been clamped since the matrix was created or ResetSaturated was called.
+func (m *Count) Saturated() bool { return m.saturated }
+
+// ResetSaturated clears the saturation flag of the matrix.
+func (m *Count) ResetSaturated() { m.saturated = false }
+
+// At returns the element at row r and column c converted to float64.
+func (m *Count) At(r, c int) float64 { return float64(m.Int(r, c)) }
+
+// Int returns the element at row r and column c.
+func (m *Count) Int(r, c int) int64 {
+	return m.data[m.index(r, c)]
+}
+
+// SetInt sets the element at row r and column c to v.
+func (m *Count) SetInt(r, c int, v int64) {
+	m.data[m.index(r, c)] = v
+}
+
+func (m *Count) index(r, c int) int {
+	if r >= m.rows || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= m.cols || c < 0 {
+		panic(ErrColAccess)
+	}
+	return r*m.cols + c
+}
+
+// Inc adds delta to the element at row r and column c, handling overflow
+// according to the mode of the matrix.
+func (m *Count) Inc(r, c int, delta int64) {
+	i := m.index(r, c)
+	m.data[i] = m.add(m.data[i], delta)
+}
+
+// Add adds the elements of a to the receiver, handling overflow according to
+// the mode of the receiver. If the mode is Checked and an element overflows,
+// the elements preceding it in row-major order have been updated when Add
+// panics.
+func (m *Count) Add(a *Count) {
+	if a.rows != m.rows || a.cols != m.cols {
+		panic(ErrShape)
+	}
+	for i, v := range a.data {
+		m.data[i] = m.add(m.data[i], v)
+	}
+}
+
+// Scale multiplies the elements of the receiver by f, handling overflow
+// according to the mode of the receiver as described for Add.
+func (m *Count) Scale(f int64) {
+	for i, v := range m.data {
+		m.data[i] = m.mul(v, f)
+	}
+}
+
+// Sum returns the sum of the elements of the receiver, handling overflow
+// according to the mode of the receiver.
+func (m *Count) Sum() int64 {
+	var s int64
+	for _, v := range m.data {
+		s = m.add(s, v)
+	}
+	return s
+}
+
+// Dense returns a newly allocated copy of the counts as a Dense matrix.
+func (m *Count) Dense() *Dense {
+	d := NewDense(m.rows, m.cols, nil)
+	for i, v := range m.data {
+		d.mat.Data[i] = float64(v)
+	}
+	return d
+}
+
+// add returns a+b according to the mode of m.
+func (m *Count) add(a, b int64) int64 {
+	s := a + b
+	// Overflow occurred if a and b have the same sign and
+	// s has the opposite sign.
+	if (a >= 0) == (b >= 0) && (s >= 0) != (a >= 0) {
+		switch m.mode {
+		case Checked:
+			panic(ErrOverflow)
+		case Saturate:
+			m.saturated = true
+			if a < 0 {
+				return math.MinInt64
+			}
+			return math.MaxInt64
+		}
+	}
+	return s
+}
+
+// mul returns a*b according to the mode of m.
+func (m *Count) mul(a, b int64) int64 {
+	p := a * b
+	if a != 0 && (p/a != b || (a == -1 && b == math.MinInt64)) {
+		switch m.mode {
+		case Checked:
+			panic(ErrOverflow)
+		case Saturate:
+			m.saturated = true
+			if (a < 0) != (b < 0) {
+				return math.MinInt64
+			}
+			return math.MaxInt64
+		}
+	}
+	return p
+}
@@ -0,0 +1,83 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestCount(c *check.C) {
+	m := NewCount(2, 3, Wrap, nil)
+	r, cols := m.Dims()
+	c.Check(r, check.Equals, 2)
+	c.Check(cols, check.Equals, 3)
+
+	m.Inc(0, 1, 3)
+	m.Inc(0, 1, 4)
+	m.Inc(1, 2, -2)
+	c.Check(m.Int(0, 1), check.Equals, int64(7))
+	c.Check(m.At(1, 2), check.Equals, -2.)
+	c.Check(m.Sum(), check.Equals, int64(5))
+	c.Check(m.Dense().Equals(NewDense(2, 3, []float64{0, 7, 0, 0, 0, -2})), check.Equals, true)
+
+	m.Add(NewCount(2, 3, Wrap, []int64{1, 1, 1, 1, 1, 1}))
+	c.Check(m.data, check.DeepEquals, []int64{1, 8, 1, 1, 1, -1})
+	m.Scale(3)
+	c.Check(m.data, check.DeepEquals, []int64{3, 24, 3, 3, 3, -3})
+
+	c.Check(func() { m.Add(NewCount(3, 2, Wrap, nil)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { m.Inc(2, 0, 1) }, check.PanicMatches, "index error: row access out of bounds")
+	c.Check(func() { NewCount(2, 2, Wrap, make([]int64, 3)) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestCountOverflow(c *check.C) {
+	for _, test := range []struct {
+		a, b     int64
+		add      bool
+		wrap     int64
+		sat      int64
+		overflow bool
+	}{
+		{a: math.MaxInt64, b: 1, add: true, wrap: math.MinInt64, sat: math.MaxInt64, overflow: true},
+		{a: math.MinInt64, b: -1, add: true, wrap: math.MaxInt64, sat: math.MinInt64, overflow: true},
+		{a: math.MaxInt64, b: -1, add: true, wrap: math.MaxInt64 - 1, sat: math.MaxInt64 - 1},
+		{a: math.MinInt64, b: math.MaxInt64, add: true, wrap: -1, sat: -1},
+		{a: math.MaxInt64/2 + 1, b: 2, wrap: math.MinInt64, sat: math.MaxInt64, overflow: true},
+		{a: math.MaxInt64/2 + 1, b: -2, wrap: math.MinInt64, sat: math.MinInt64, overflow: false},
+		{a: math.MinInt64, b: -1, wrap: math.MinInt64, sat: math.MaxInt64, overflow: true},
+		{a: -3, b: 5, wrap: -15, sat: -15},
+	} {
+		op := func(m *Count) {
+			if test.add {
+				m.Inc(0, 0, test.b)
+			} else {
+				m.Scale(test.b)
+			}
+		}
+
+		w := NewCount(1, 1, Wrap, []int64{test.a})
+		op(w)
+		c.Check(w.Int(0, 0), check.Equals, test.wrap, check.Commentf("%+v", test))
+		c.Check(w.Saturated(), check.Equals, false)
+
+		sm := NewCount(1, 1, Saturate, []int64{test.a})
+		op(sm)
+		c.Check(sm.Int(0, 0), check.Equals, test.sat, check.Commentf("%+v", test))
+		c.Check(sm.Saturated(), check.Equals, test.overflow, check.Commentf("%+v", test))
+		sm.ResetSaturated()
+		c.Check(sm.Saturated(), check.Equals, false)
+
+		ch := NewCount(1, 1, Checked, []int64{test.a})
+		if test.overflow {
+			c.Check(func() { op(ch) }, check.PanicMatches, string(ErrOverflow), check.Commentf("%+v", test))
+			c.Check(ch.Int(0, 0), check.Equals, test.a)
+		} else {
+			op(ch)
+			c.Check(ch.Int(0, 0), check.Equals, test.wrap, check.Commentf("%+v", test))
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "sync/atomic"
+
+var (
+	cowDense *COWDense
+
+	_ Matrix       = cowDense
+	_ Mutable      = cowDense
+	_ Vectorer     = cowDense
+	_ VectorSetter = cowDense
+)
+
+// COWDense is a dense matrix with copy-on-write storage. CloneCOW returns a copy
+// that shares the storage of the original until either is first modified, when
+// the modified matrix takes a private copy. This makes it cheap to hand
+// snapshots of a large matrix to consumers that rarely or never modify them.
+//
+// Copy-on-write is provided by a separate type because the storage of a Dense
+// is written directly by its views and by every operation that uses it as a
+// receiver, so sharing could not be tracked. The storage of a COWDense is only
+// reachable through its methods; Read exposes it for use as an operand.
+//
+// Distinct matrices sharing storage may be used by different goroutines, but a
+// single COWDense must not be read and written concurrently.
+type COWDense struct {
+	mat Dense
+
+	// refs counts the matrices sharing mat's storage. It is
+	// nil for an empty matrix.
+	refs *int32
+}
+
+// NewCOWDense returns a COWDense holding m. The COWDense takes ownership of m,
+// which, together with any view sharing its storage, must not be used after the
+// call.
+func NewCOWDense(m *Dense) *COWDense {
+	return &COWDense{mat: *m, refs: newRefs()}
+}
+
+// newRefs returns a new reference count for a sole owner.
+func newRefs() *int32 {
+	refs := int32(1)
+	return &refs
+}
+
+// CloneCOW returns a copy of the matrix that shares its storage until either
+// matrix is modified. CloneCOW takes constant time, and may be called
+// concurrently with reads of the receiver.
+func (m *COWDense) CloneCOW() *COWDense {
+	if m.refs == nil {
+		return &COWDense{}
+	}
+	atomic.AddInt32(m.refs, 1)
+	return &COWDense{mat: m.mat, refs: m.refs}
+}
+
+// Release gives up the receiver's share of its storage, so that the remaining
+// matrices sharing it need not copy it when modified, and resets the receiver to
+// an empty matrix. Release need not be called, but a clone that is discarded
+// without being modified otherwise continues to count as sharing the storage.
+func (m *COWDense) Release() {
+	if m.refs != nil {
+		atomic.AddInt32(m.refs, -1)
+	}
+	*m = COWDense{}
+}
+
+// Shared returns whether the receiver's storage is shared with another matrix.
+func (m *COWDense) Shared() bool {
+	return m.refs != nil && atomic.LoadInt32(m.refs) > 1
+}
+
+// unshare gives the receiver a private copy of its storage if it is shared.
+func (m *COWDense) unshare() {
+	if m.refs == nil {
+		m.refs = newRefs()
+	}
+	if !m.Shared() {
+		return
+	}
+	// The copy is complete before the share is given up, so a matrix
+	// that sees itself as the sole owner never writes storage that is
+	// still being read here.
+	d := DenseCopyOf(&m.mat)
+	atomic.AddInt32(m.refs, -1)
+	m.mat, m.refs = *d, newRefs()
+}
+
+func (m *COWDense) Dims() (r, c int) { return m.mat.Dims() }
+
+// At returns the element at row r and column c.
+func (m *COWDense) At(r, c int) float64 { return m.mat.At(r, c) }
+
+// Row copies the elements of row r into row as for Dense.Row.
+func (m *COWDense) Row(row []float64, r int) []float64 { return m.mat.Row(row, r) }
+
+// Col copies the elements of column c into col as for Dense.Col.
+func (m *COWDense) Col(col []float64, c int) []float64 { return m.mat.Col(col, c) }
+
+// Set sets the element at row r and column c to v, first copying the storage if
+// it is shared.
+func (m *COWDense) Set(r, c int, v float64) {
+	m.unshare()
+	m.mat.Set(r, c, v)
+}
+
+// SetRow sets the elements of row r as for Dense.SetRow, first copying the
+// storage if it is shared.
+func (m *COWDense) SetRow(r int, row []float64) int {
+	m.unshare()
+	return m.mat.SetRow(r, row)
+}
+
+// SetCol sets the elements of column c as for Dense.SetCol, first copying the
+// storage if it is shared.
+func (m *COWDense) SetCol(c int, col []float64) int {
+	m.unshare()
+	return m.mat.SetCol(c, col)
+}
+
+// Read calls fn with a matrix sharing the receiver's storage, for use as an
+// operand of Dense methods without copying. fn must not modify the matrix or
+// retain it after returning.
+func (m *COWDense) Read(fn func(d *Dense)) {
+	d := m.mat
+	fn(&d)
+}
+
+// Write calls fn with a matrix holding the receiver's storage, first copying the
+// storage if it is shared. fn may modify the matrix in place or use it as the
+// receiver of a Dense method, including one that reallocates it, but must not
+// retain it after returning.
+func (m *COWDense) Write(fn func(d *Dense)) {
+	m.unshare()
+	fn(&m.mat)
+}
+
+// Dense returns a newly allocated copy of the matrix.
+func (m *COWDense) Dense() *Dense { return DenseCopyOf(&m.mat) }
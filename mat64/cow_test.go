@@ -0,0 +1,82 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"sync"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestCOWDense(c *check.C) {
+	orig := NewDense(2, 2, []float64{1, 2, 3, 4})
+	a := NewCOWDense(DenseCopyOf(orig))
+	c.Check(a.Shared(), check.Equals, false)
+
+	b := a.CloneCOW()
+	c.Check(a.Shared() && b.Shared(), check.Equals, true)
+	c.Check(&a.mat.mat.Data[0] == &b.mat.mat.Data[0], check.Equals, true)
+
+	// Writing to the clone copies its storage and leaves a unchanged.
+	b.Set(0, 0, 10)
+	c.Check(a.At(0, 0), check.Equals, 1.0)
+	c.Check(b.At(0, 0), check.Equals, 10.0)
+	c.Check(a.Shared() || b.Shared(), check.Equals, false)
+
+	// The sole owner writes in place.
+	data := &a.mat.mat.Data[0]
+	a.SetRow(1, []float64{5, 6})
+	c.Check(&a.mat.mat.Data[0] == data, check.Equals, true)
+	c.Check(a.Dense().Equals(NewDense(2, 2, []float64{1, 2, 5, 6})), check.Equals, true)
+
+	// Writing to the original copies it while clones are live.
+	d := a.CloneCOW()
+	e := a.CloneCOW()
+	a.SetCol(1, []float64{-1, -2})
+	c.Check(d.Dense().Equals(NewDense(2, 2, []float64{1, 2, 5, 6})), check.Equals, true)
+	c.Check(d.Shared() && e.Shared(), check.Equals, true)
+	e.Release()
+	c.Check(d.Shared(), check.Equals, false)
+	r, cols := e.Dims()
+	c.Check(r == 0 && cols == 0, check.Equals, true)
+
+	// Read shares storage; Write may reshape.
+	var sum Dense
+	d.Read(func(m *Dense) { sum.Add(m, m) })
+	c.Check(sum.At(1, 1), check.Equals, 12.0)
+	f := d.CloneCOW()
+	f.Write(func(m *Dense) { m.Mul(m, NewDense(2, 1, []float64{1, 1})) })
+	c.Check(f.Dense().Equals(NewDense(2, 1, []float64{3, 11})), check.Equals, true)
+	r, cols = d.Dims()
+	c.Check(r == 2 && cols == 2, check.Equals, true)
+
+	// A zero value acquires storage through Write.
+	var z COWDense
+	z.Write(func(m *Dense) { m.Clone(orig) })
+	y := z.CloneCOW()
+	c.Check(y.Dense().Equals(orig), check.Equals, true)
+	c.Check(z.Shared(), check.Equals, true)
+
+	// Clones may be written by different goroutines.
+	big := NewCOWDense(NewDense(50, 50, nil))
+	var wg sync.WaitGroup
+	clones := make([]*COWDense, 8)
+	for i := range clones {
+		clones[i] = big.CloneCOW()
+	}
+	for i, cl := range clones {
+		wg.Add(1)
+		go func(v float64, cl *COWDense) {
+			defer wg.Done()
+			cl.Set(49, 49, v)
+		}(float64(i+1), cl)
+	}
+	wg.Wait()
+	for i, cl := range clones {
+		c.Check(cl.At(49, 49), check.Equals, float64(i+1))
+	}
+	c.Check(big.At(49, 49), check.Equals, 0.0)
+	c.Check(big.Shared(), check.Equals, false)
+}
@@ -0,0 +1,231 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math"
+
+const (
+	ErrFolds = Error("mat64: invalid number of folds")
+	ErrSplit = Error("mat64: invalid split fraction")
+)
+
+// A Fold holds the row indices of the training and test sets of a single
+// cross-validation fold. The indices in each set are in ascending order.
+type Fold struct {
+	Train []int
+	Test  []int
+}
+
+// KFold partitions the row indices [0, n) into k test sets of as equal size as
+// possible and returns the corresponding k folds. If shuffle is true the rows are
+// assigned to folds randomly using src, otherwise each test set is a contiguous
+// run of rows. KFold will panic with ErrFolds if k < 2 or k > n.
+func KFold(n, k int, shuffle bool, src Source) []Fold {
+	if k < 2 || k > n {
+		panic(ErrFolds)
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	if shuffle {
+		shuffleInts(idx, src)
+	}
+
+	assign := make([]int, n)
+	var start int
+	for f := 0; f < k; f++ {
+		size := n / k
+		if f < n%k {
+			size++
+		}
+		for _, i := range idx[start : start+size] {
+			assign[i] = f
+		}
+		start += size
+	}
+	return makeFolds(assign, k)
+}
+
+// StratifiedKFold returns k cross-validation folds over the rows labelled by
+// labels such that the proportion of each label in every test set is as close as
+// possible to its proportion in the complete set. If shuffle is true the rows of
+// each label are assigned to folds randomly using src. StratifiedKFold will
+// panic with ErrFolds if k < 2 or k > len(labels).
+func StratifiedKFold(labels []int, k int, shuffle bool, src Source) []Fold {
+	n := len(labels)
+	if k < 2 || k > n {
+		panic(ErrFolds)
+	}
+
+	var classes [][]int
+	index := make(map[int]int)
+	for i, l := range labels {
+		c, ok := index[l]
+		if !ok {
+			c = len(classes)
+			index[l] = c
+			classes = append(classes, nil)
+		}
+		classes[c] = append(classes[c], i)
+	}
+
+	// Deal the rows of each class to the folds in turn, continuing
+	// from the fold reached by the previous class so that the test
+	// sets remain balanced in size.
+	assign := make([]int, n)
+	var pos int
+	for _, members := range classes {
+		if shuffle {
+			shuffleInts(members, src)
+		}
+		for _, i := range members {
+			assign[i] = pos % k
+			pos++
+		}
+	}
+	return makeFolds(assign, k)
+}
+
+// TrainTestSplit splits the row indices [0, n) into a test set of
+// round(testFrac*n) rows and a training set of the remaining rows, returned as a
+// Fold whose Views give the two partitions of a data matrix and of any aligned
+// target matrix. If shuffle is true the test rows are chosen randomly using src,
+// otherwise they are the last rows. TrainTestSplit will panic with ErrSplit if
+// testFrac is not in [0, 1].
+func TrainTestSplit(n int, testFrac float64, shuffle bool, src Source) Fold {
+	if !(testFrac >= 0 && testFrac <= 1) {
+		panic(ErrSplit)
+	}
+	k := int(math.Floor(testFrac*float64(n) + 0.5))
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	if shuffle {
+		shuffleInts(idx, src)
+	}
+	return NewFold(n, idx[n-k:])
+}
+
+// NewFold returns the Fold over the row indices [0, n) with the given test rows
+// and the remaining rows for training. NewFold will panic with
+// ErrIndexOutOfRange if a test index is not in [0, n) or is repeated.
+func NewFold(n int, test []int) Fold {
+	assign := make([]int, n)
+	for _, i := range test {
+		if i < 0 || i >= n || assign[i] != 0 {
+			panic(ErrIndexOutOfRange)
+		}
+		assign[i] = 1
+	}
+	var f Fold
+	for i, v := range assign {
+		if v == 1 {
+			f.Test = append(f.Test, i)
+		} else {
+			f.Train = append(f.Train, i)
+		}
+	}
+	return f
+}
+
+// makeFolds returns the k folds described by the fold assignment of each row.
+func makeFolds(assign []int, k int) []Fold {
+	folds := make([]Fold, k)
+	for i, f := range assign {
+		for j := range folds {
+			if j == f {
+				folds[j].Test = append(folds[j].Test, i)
+			} else {
+				folds[j].Train = append(folds[j].Train, i)
+			}
+		}
+	}
+	return folds
+}
+
+// Views returns views of the training and test rows of m for the fold.
+func (f Fold) Views(m *Dense) (train, test *RowSubset) {
+	return NewRowSubset(m, f.Train), NewRowSubset(m, f.Test)
+}
+
+// shuffleInts randomly permutes s in place using src.
+func shuffleInts(s []int, src Source) {
+	src = source(src)
+	for i := len(s) - 1; i > 0; i-- {
+		j := src.Intn(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// A RowSubset is a Matrix view of a subset of the rows of a Dense matrix. The
+// rows are not copied, so changes to the underlying matrix are reflected in the
+// view.
+type RowSubset struct {
+	mat  *Dense
+	rows []int
+}
+
+// NewRowSubset returns a view of the rows of m with the given indices, in the
+// order given. NewRowSubset will panic with ErrIndexOutOfRange if any index is
+// not a row of m.
+func NewRowSubset(m *Dense, rows []int) *RowSubset {
+	r, _ := m.Dims()
+	for _, i := range rows {
+		if i < 0 || i >= r {
+			panic(ErrIndexOutOfRange)
+		}
+	}
+	return &RowSubset{mat: m, rows: rows}
+}
+
+func (s *RowSubset) Dims() (r, c int) {
+	_, c = s.mat.Dims()
+	return len(s.rows), c
+}
+
+func (s *RowSubset) At(r, c int) float64 {
+	return s.mat.At(s.rows[r], c)
+}
+
+// Indices returns the row indices of the underlying matrix held by the view.
+func (s *RowSubset) Indices() []int { return s.rows }
+
+func (s *RowSubset) Row(row []float64, r int) []float64 {
+	if r >= len(s.rows) || r < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	return s.mat.Row(row, s.rows[r])
+}
+
+func (s *RowSubset) Col(col []float64, c int) []float64 {
+	_, cols := s.mat.Dims()
+	if c >= cols || c < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	if col == nil {
+		col = make([]float64, len(s.rows))
+	}
+	col = col[:min(len(col), len(s.rows))]
+	for i := range col {
+		col[i] = s.mat.At(s.rows[i], c)
+	}
+	return col
+}
+
+// RowView returns the r-th row of the view as a slice sharing the data of the
+// underlying matrix.
+func (s *RowSubset) RowView(r int) []float64 {
+	if r >= len(s.rows) || r < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	return s.mat.rowView(s.rows[r])
+}
+
+var (
+	_ Matrix   = (*RowSubset)(nil)
+	_ Vectorer = (*RowSubset)(nil)
+)
@@ -0,0 +1,134 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/rand"
+	"sort"
+
+	check "launchpad.net/gocheck"
+)
+
+func checkFolds(c *check.C, folds []Fold, n int, comment check.CommentInterface) {
+	seen := make([]int, n)
+	for _, f := range folds {
+		c.Check(len(f.Train)+len(f.Test), check.Equals, n, comment)
+		in := make(map[int]bool)
+		for j, i := range f.Test {
+			if j > 0 {
+				c.Check(f.Test[j-1] < i, check.Equals, true, comment)
+			}
+			in[i] = true
+			seen[i]++
+		}
+		for j, i := range f.Train {
+			if j > 0 {
+				c.Check(f.Train[j-1] < i, check.Equals, true, comment)
+			}
+			c.Check(in[i], check.Equals, false, comment)
+		}
+	}
+	for i, v := range seen {
+		c.Check(v, check.Equals, 1, check.Commentf("row %d tested %d times", i, v))
+	}
+}
+
+func (s *S) TestKFold(c *check.C) {
+	folds := KFold(7, 3, false, nil)
+	c.Check(folds[0].Test, check.DeepEquals, []int{0, 1, 2})
+	c.Check(folds[1].Test, check.DeepEquals, []int{3, 4})
+	c.Check(folds[2].Test, check.DeepEquals, []int{5, 6})
+	c.Check(folds[1].Train, check.DeepEquals, []int{0, 1, 2, 5, 6})
+	checkFolds(c, folds, 7, check.Commentf("unshuffled"))
+
+	folds = KFold(10, 4, true, rand.New(rand.NewSource(1)))
+	checkFolds(c, folds, 10, check.Commentf("shuffled"))
+	for _, f := range folds {
+		c.Check(len(f.Test) == 2 || len(f.Test) == 3, check.Equals, true)
+	}
+
+	c.Check(func() { KFold(3, 1, false, nil) }, check.PanicMatches, string(ErrFolds))
+	c.Check(func() { KFold(3, 4, false, nil) }, check.PanicMatches, string(ErrFolds))
+}
+
+func (s *S) TestStratifiedKFold(c *check.C) {
+	labels := []int{0, 0, 0, 0, 0, 0, 1, 1, 1, 2, 2, 2}
+	for _, shuffle := range []bool{false, true} {
+		folds := StratifiedKFold(labels, 3, shuffle, rand.New(rand.NewSource(1)))
+		checkFolds(c, folds, len(labels), check.Commentf("shuffle %v", shuffle))
+		for _, f := range folds {
+			count := make(map[int]int)
+			for _, i := range f.Test {
+				count[labels[i]]++
+			}
+			c.Check(count, check.DeepEquals, map[int]int{0: 2, 1: 1, 2: 1})
+		}
+	}
+	c.Check(func() { StratifiedKFold(labels, 13, false, nil) }, check.PanicMatches, string(ErrFolds))
+}
+
+func (s *S) TestRowSubset(c *check.C) {
+	m := NewDense(4, 2, []float64{
+		0, 1,
+		2, 3,
+		4, 5,
+		6, 7,
+	})
+	folds := KFold(4, 2, false, nil)
+	train, test := folds[0].Views(m)
+	r, cols := train.Dims()
+	c.Check(r, check.Equals, 2)
+	c.Check(cols, check.Equals, 2)
+	c.Check(train.At(1, 0), check.Equals, 6.0)
+	c.Check(test.Row(nil, 1), check.DeepEquals, []float64{2, 3})
+	c.Check(train.Col(nil, 1), check.DeepEquals, []float64{5, 7})
+	c.Check(DenseCopyOf(test).Equals(NewDense(2, 2, []float64{0, 1, 2, 3})), check.Equals, true)
+
+	// Views share the data of the underlying matrix.
+	train.RowView(0)[1] = -5
+	c.Check(m.At(2, 1), check.Equals, -5.0)
+
+	c.Check(func() { NewRowSubset(m, []int{4}) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { test.Row(nil, 2) }, check.PanicMatches, string(ErrIndexOutOfRange))
+}
+
+func (s *S) TestTrainTestSplit(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	f := TrainTestSplit(10, 0.25, false, nil)
+	c.Check(f.Test, check.DeepEquals, []int{7, 8, 9})
+	c.Check(f.Train, check.DeepEquals, []int{0, 1, 2, 3, 4, 5, 6})
+
+	for _, frac := range []float64{0, 0.3, 1} {
+		f := TrainTestSplit(20, frac, true, src)
+		c.Check(len(f.Test), check.Equals, int(frac*20+0.5))
+		c.Check(len(f.Train)+len(f.Test), check.Equals, 20)
+		seen := make(map[int]bool)
+		for _, set := range [][]int{f.Train, f.Test} {
+			c.Check(sort.IntsAreSorted(set), check.Equals, true)
+			for _, i := range set {
+				c.Check(seen[i], check.Equals, false)
+				seen[i] = true
+			}
+		}
+	}
+
+	// The views of aligned data and targets correspond.
+	x := NewDense(5, 2, []float64{0, 0, 1, 10, 2, 20, 3, 30, 4, 40})
+	y := NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+	f = NewFold(5, []int{3, 1})
+	c.Check(f.Test, check.DeepEquals, []int{1, 3})
+	xTrain, xTest := f.Views(x)
+	yTrain, yTest := f.Views(y)
+	r, _ := xTrain.Dims()
+	for i := 0; i < r; i++ {
+		c.Check(xTrain.At(i, 0), check.Equals, yTrain.At(i, 0))
+	}
+	c.Check(xTest.At(1, 1), check.Equals, 30.0)
+	c.Check(yTest.At(1, 0), check.Equals, 3.0)
+
+	c.Check(func() { TrainTestSplit(5, 1.5, false, nil) }, check.PanicMatches, string(ErrSplit))
+	c.Check(func() { NewFold(5, []int{5}) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { NewFold(5, []int{1, 1}) }, check.PanicMatches, string(ErrIndexOutOfRange))
+}
@@ -0,0 +1,200 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CSVOptions specifies the layout of delimited text used by ReadCSVDense and
+// WriteCSVDense. The zero value describes comma separated values with no header
+// in which missing values are written as empty fields.
+type CSVOptions struct {
+	// Comma is the field delimiter. If zero, ',' is used.
+	Comma rune
+
+	// Comment, if not zero, is the comment character. Lines
+	// beginning with the comment character are ignored when reading.
+	Comment rune
+
+	// Header specifies that the first record holds column names.
+	Header bool
+
+	// Names holds the column names written when Header is true. If
+	// Names is nil the columns are named by their index.
+	Names []string
+
+	// Columns holds the indices of the columns to read or write, in
+	// order. If Columns is nil all columns are used.
+	Columns []int
+
+	// NA is the representation of a missing value. Fields equal to NA
+	// and empty fields are read as NaN, and NaN values are written as NA.
+	NA string
+}
+
+func (o *CSVOptions) comma() rune {
+	if o == nil || o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+// ReadCSVDense reads delimited text from r into a new matrix with one row per
+// record. If opts is nil the default options are used. When opts.Header is true
+// the column names of the selected columns are returned. Every record must have
+// the same number of fields.
+func ReadCSVDense(r io.Reader, opts *CSVOptions) (m *Dense, header []string, err error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	records, err := newCSVReader(r, opts).ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, ErrZeroLength
+	}
+
+	cols, err := csvColumns(opts, len(records[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	line := 1
+	if opts.Header {
+		header = csvHeader(records[0], cols)
+		records = records[1:]
+		line++
+		if len(records) == 0 {
+			return nil, header, ErrZeroLength
+		}
+	}
+	if len(cols) == 0 {
+		return nil, header, ErrZeroLength
+	}
+
+	m = NewDense(len(records), len(cols), nil)
+	for i, rec := range records {
+		err = parseCSVRow(m.rowView(i), rec, cols, opts.NA, line+i)
+		if err != nil {
+			return nil, header, err
+		}
+	}
+	return m, header, nil
+}
+
+func newCSVReader(r io.Reader, opts *CSVOptions) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.Comma = opts.comma()
+	cr.Comment = opts.Comment
+	cr.TrimLeadingSpace = true
+	return cr
+}
+
+// csvColumns returns the indices of the columns selected by opts from records
+// with n fields.
+func csvColumns(opts *CSVOptions, n int) ([]int, error) {
+	cols := opts.Columns
+	if cols == nil {
+		cols = make([]int, n)
+		for j := range cols {
+			cols[j] = j
+		}
+	}
+	for _, j := range cols {
+		if j < 0 || j >= n {
+			return nil, ErrIndexOutOfRange
+		}
+	}
+	return cols, nil
+}
+
+func csvHeader(rec []string, cols []int) []string {
+	header := make([]string, len(cols))
+	for k, j := range cols {
+		header[k] = rec[j]
+	}
+	return header
+}
+
+// parseCSVRow parses the selected columns of the record rec, found at the given
+// line, into row.
+func parseCSVRow(row []float64, rec []string, cols []int, na string, line int) error {
+	for k, j := range cols {
+		field := strings.TrimSpace(rec[j])
+		if field == "" || field == na {
+			row[k] = math.NaN()
+			continue
+		}
+		var err error
+		row[k], err = strconv.ParseFloat(field, 64)
+		if err != nil {
+			return fmt.Errorf("mat64: csv record %d column %d: %v", line, j, err)
+		}
+	}
+	return nil
+}
+
+// WriteCSVDense writes the matrix a to w as delimited text with one record per
+// row. If opts is nil the default options are used.
+func WriteCSVDense(w io.Writer, a Matrix, opts *CSVOptions) error {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	r, c := a.Dims()
+
+	cols := opts.Columns
+	if cols == nil {
+		cols = make([]int, c)
+		for j := range cols {
+			cols[j] = j
+		}
+	}
+	for _, j := range cols {
+		if j < 0 || j >= c {
+			return ErrIndexOutOfRange
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = opts.comma()
+	rec := make([]string, len(cols))
+	if opts.Header {
+		for k, j := range cols {
+			if opts.Names != nil {
+				if j >= len(opts.Names) {
+					return ErrIndexOutOfRange
+				}
+				rec[k] = opts.Names[j]
+			} else {
+				rec[k] = strconv.Itoa(j)
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < r; i++ {
+		for k, j := range cols {
+			v := a.At(i, j)
+			if math.IsNaN(v) {
+				rec[k] = opts.NA
+			} else {
+				rec[k] = strconv.FormatFloat(v, 'g', -1, 64)
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
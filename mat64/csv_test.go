@@ -0,0 +1,79 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bytes"
+	"math"
+	"strings"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestReadCSVDense(c *check.C) {
+	in := `# measurements
+x;y;z
+1;2.5;3
+-4;NA; 6e2
+7;;9
+`
+	m, header, err := ReadCSVDense(strings.NewReader(in), &CSVOptions{
+		Comma:   ';',
+		Comment: '#',
+		Header:  true,
+		Columns: []int{2, 1},
+		NA:      "NA",
+	})
+	c.Assert(err, check.IsNil)
+	c.Check(header, check.DeepEquals, []string{"z", "y"})
+	r, cols := m.Dims()
+	c.Check(r, check.Equals, 3)
+	c.Check(cols, check.Equals, 2)
+	c.Check(m.At(0, 0), check.Equals, 3.0)
+	c.Check(m.At(0, 1), check.Equals, 2.5)
+	c.Check(m.At(1, 0), check.Equals, 600.0)
+	c.Check(math.IsNaN(m.At(1, 1)), check.Equals, true)
+	c.Check(math.IsNaN(m.At(2, 1)), check.Equals, true)
+
+	_, _, err = ReadCSVDense(strings.NewReader("1,2\n3,x\n"), nil)
+	c.Check(err, check.ErrorMatches, "mat64: csv record 2 column 1: .*")
+	_, _, err = ReadCSVDense(strings.NewReader("1,2\n3\n"), nil)
+	c.Check(err, check.NotNil)
+	_, _, err = ReadCSVDense(strings.NewReader("1,2\n"), &CSVOptions{Columns: []int{2}})
+	c.Check(err, check.Equals, ErrIndexOutOfRange)
+	_, _, err = ReadCSVDense(strings.NewReader(""), nil)
+	c.Check(err, check.Equals, ErrZeroLength)
+}
+
+func (s *S) TestWriteCSVDense(c *check.C) {
+	m := NewDense(2, 3, []float64{
+		1, 0.5, math.NaN(),
+		-2, 1e-20, 3,
+	})
+
+	var buf bytes.Buffer
+	c.Assert(WriteCSVDense(&buf, m, nil), check.IsNil)
+	c.Check(buf.String(), check.Equals, "1,0.5,\n-2,1e-20,3\n")
+
+	buf.Reset()
+	opts := &CSVOptions{
+		Comma:   '\t',
+		Header:  true,
+		Names:   []string{"a", "b", "c"},
+		Columns: []int{2, 0},
+		NA:      "NA",
+	}
+	c.Assert(WriteCSVDense(&buf, m, opts), check.IsNil)
+	c.Check(buf.String(), check.Equals, "c\ta\nNA\t1\n3\t-2\n")
+
+	got, header, err := ReadCSVDense(&buf, &CSVOptions{Comma: '\t', Header: true, NA: "NA"})
+	c.Assert(err, check.IsNil)
+	c.Check(header, check.DeepEquals, []string{"c", "a"})
+	c.Check(math.IsNaN(got.At(0, 0)), check.Equals, true)
+	got.Set(0, 0, 0)
+	c.Check(got.Equals(NewDense(2, 2, []float64{0, 1, 3, -2})), check.Equals, true)
+
+	c.Check(WriteCSVDense(&buf, m, &CSVOptions{Columns: []int{3}}), check.Equals, ErrIndexOutOfRange)
+}
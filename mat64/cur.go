@@ -0,0 +1,173 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// IDFactors holds a rank k interpolative decomposition a ≈ C.P of an m-by-n
+// matrix a, where the m-by-k matrix C holds the columns of a indexed by Cols and
+// the k-by-n interpolation matrix P holds the identity in the columns indexed by
+// Cols, so that those columns of a are reproduced exactly.
+type IDFactors struct {
+	Cols []int
+	C    *Dense
+	P    *Dense
+}
+
+// Interpolative returns the rank k interpolative decomposition of a, selecting k
+// columns of a by the Householder QR factorization with column pivoting of
+// Businger and Golub. If a.Π = Q.[R11 R12] with Π the column permutation and R11
+// k-by-k upper triangular, the selected columns are the first k of a.Π and the
+// interpolation matrix is [I R11^-1.R12].Π'. The matrix a is not modified.
+//
+// Because the factors are actual columns of a, the decomposition is useful where
+// the interpretability of the factors matters, such as for feature selection.
+//
+// Interpolative will panic with ErrShape if k is not in [1, min(m, n)], and with
+// ErrRankDeficient if a has numerical rank less than k.
+func Interpolative(a *Dense, k int) IDFactors {
+	m, n := a.Dims()
+	if k < 1 || k > min(m, n) {
+		panic(ErrShape)
+	}
+
+	r, perm := pivotedQR(a, k)
+	// The pivoting makes the diagonal of R non-increasing in magnitude.
+	tol := float64(max(m, n)) * epsilon * math.Abs(r.At(0, 0))
+	for i := 0; i < k; i++ {
+		if math.Abs(r.At(i, i)) <= tol {
+			panic(ErrRankDeficient)
+		}
+	}
+
+	// T = R11^-1.R12 by back substitution.
+	t := NewDense(k, n-k, nil)
+	for i := 0; i < k; i++ {
+		copy(t.rowView(i), r.rowView(i)[k:])
+	}
+	for i := k - 1; i >= 0 && n > k; i-- {
+		row := t.rowView(i)
+		for j := i + 1; j < k; j++ {
+			axpyUnitary(-r.At(i, j), t.rowView(j), row)
+		}
+		scalUnitary(1/r.At(i, i), row)
+	}
+
+	f := IDFactors{
+		Cols: append([]int(nil), perm[:k]...),
+		C:    NewDense(m, k, nil),
+		P:    NewDense(k, n, nil),
+	}
+	for i := 0; i < m; i++ {
+		row, cr := a.rowView(i), f.C.rowView(i)
+		for j, c := range f.Cols {
+			cr[j] = row[c]
+		}
+	}
+	for i := 0; i < k; i++ {
+		f.P.Set(i, perm[i], 1)
+		for j := 0; j < n-k; j++ {
+			f.P.Set(i, perm[k+j], t.At(i, j))
+		}
+	}
+	return f
+}
+
+// pivotedQR returns the leading k rows of the triangular factor of the
+// Householder QR factorization of a with column pivoting, and the column
+// permutation, with column j of the factorized matrix being column perm[j] of
+// a. The matrix a is not modified.
+func pivotedQR(a *Dense, k int) (r *Dense, perm []int) {
+	m, n := a.Dims()
+	work := DenseCopyOf(a)
+	perm = make([]int, n)
+	for j := range perm {
+		perm[j] = j
+	}
+	norms := make([]float64, n)
+	x := make([]float64, m)
+	for i := 0; i < k; i++ {
+		// Choose the remaining column of largest norm.
+		for j := i; j < n; j++ {
+			norms[j] = 0
+			for l := i; l < m; l++ {
+				norms[j] = math.Hypot(norms[j], work.At(l, j))
+			}
+		}
+		p := i
+		for j := i + 1; j < n; j++ {
+			if norms[j] > norms[p] {
+				p = j
+			}
+		}
+		if p != i {
+			for l := 0; l < m; l++ {
+				row := work.rowView(l)
+				row[i], row[p] = row[p], row[i]
+			}
+			perm[i], perm[p] = perm[p], perm[i]
+		}
+
+		for l := i; l < m; l++ {
+			x[l] = work.At(l, i)
+		}
+		v, tau, beta := Householder(x[i:])
+		var rest Dense
+		rest.View(work, 0, i, m, n-i)
+		rest.ReflectRows(v, tau, i)
+		work.Set(i, i, beta)
+		for l := i + 1; l < m; l++ {
+			work.Set(l, i, 0)
+		}
+	}
+
+	r = NewDense(k, n, nil)
+	r.Copy(work)
+	return r, perm
+}
+
+// CURFactors holds a rank k CUR decomposition a ≈ C.U.R of an m-by-n matrix a,
+// where C holds the k columns of a indexed by Cols, R holds the k rows of a
+// indexed by Rows, and U is the k-by-k linking matrix.
+type CURFactors struct {
+	Cols, Rows []int
+	C, U, R    *Dense
+}
+
+// CUR returns the rank k CUR decomposition of a. The columns are selected by
+// the interpolative decomposition of a and the rows by that of a', and the
+// linking matrix U = C^+.a.R^+ minimizes the Frobenius norm of a - C.U.R for the
+// selected rows and columns. The matrix a is not modified.
+//
+// CUR will panic with ErrShape if k is not in [1, min(m, n)], and with
+// ErrRankDeficient if a has numerical rank less than k.
+func CUR(a *Dense, k int) CURFactors {
+	_, n := a.Dims()
+	cols := Interpolative(a, k)
+	var at Dense
+	at.TCopy(a)
+	rows := Interpolative(&at, k)
+
+	f := CURFactors{
+		Cols: cols.Cols,
+		Rows: rows.Cols,
+		C:    cols.C,
+		R:    NewDense(k, n, nil),
+	}
+	f.R.TCopy(rows.C)
+
+	// U = C^+.a.R^+, with C^+.a found as the least squares solution of
+	// C.Y = a and U' as that of R'.U' = Y'.
+	y, _ := LeastSquares(f.C, a)
+	var yt, rt Dense
+	yt.TCopy(y)
+	rt.TCopy(f.R)
+	ut, _ := LeastSquares(&rt, &yt)
+	f.U = NewDense(k, k, nil)
+	f.U.TCopy(ut)
+	return f
+}
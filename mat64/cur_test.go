@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math/rand"
+)
+
+// lowRank returns a random m-by-n matrix of rank k.
+func lowRank(rnd *rand.Rand, m, n, k int) *Dense {
+	l, r := NewDense(m, k, nil), NewDense(k, n, nil)
+	for i := 0; i < m; i++ {
+		for j := 0; j < k; j++ {
+			l.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	for i := 0; i < k; i++ {
+		for j := 0; j < n; j++ {
+			r.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	var a Dense
+	a.Mul(l, r)
+	return &a
+}
+
+func (s *S) TestInterpolative(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, t := range [][3]int{{8, 6, 3}, {5, 9, 2}, {6, 6, 6}} {
+		m, n, k := t[0], t[1], t[2]
+		a := lowRank(rnd, m, n, k)
+		orig := DenseCopyOf(a)
+		f := Interpolative(a, k)
+		comment := check.Commentf("m=%d n=%d k=%d", m, n, k)
+		c.Check(a.Equals(orig), check.Equals, true, comment)
+		c.Check(len(f.Cols), check.Equals, k, comment)
+
+		seen := make(map[int]bool)
+		for i, col := range f.Cols {
+			c.Check(seen[col], check.Equals, false, comment)
+			seen[col] = true
+			for r := 0; r < m; r++ {
+				c.Check(f.C.At(r, i), check.Equals, a.At(r, col))
+			}
+			for r := 0; r < k; r++ {
+				want := 0.
+				if r == i {
+					want = 1
+				}
+				c.Check(f.P.At(r, col), check.Equals, want, comment)
+			}
+		}
+
+		var cp Dense
+		cp.Mul(f.C, f.P)
+		c.Check(cp.EqualsApprox(a, 1e-10), check.Equals, true, comment)
+	}
+
+	// The column of largest norm is chosen first.
+	a := NewDense(2, 3, []float64{
+		1, 0, 3,
+		0, 1, 4,
+	})
+	c.Check(Interpolative(a, 1).Cols, check.DeepEquals, []int{2})
+
+	c.Check(func() { Interpolative(a, 3) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { Interpolative(NewDense(3, 3, []float64{1, 1, 1, 1, 1, 1, 1, 1, 1}), 2) }, check.PanicMatches, string(ErrRankDeficient))
+}
+
+func (s *S) TestCUR(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	a := lowRank(rnd, 9, 7, 3)
+	f := CUR(a, 3)
+	for i, col := range f.Cols {
+		for r := 0; r < 9; r++ {
+			c.Check(f.C.At(r, i), check.Equals, a.At(r, col))
+		}
+	}
+	for i, row := range f.Rows {
+		c.Check(f.R.rowView(i), check.DeepEquals, a.rowView(row))
+	}
+
+	var cu, cur Dense
+	cu.Mul(f.C, f.U)
+	cur.Mul(&cu, f.R)
+	c.Check(cur.EqualsApprox(a, 1e-10), check.Equals, true)
+}
@@ -0,0 +1,62 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// IsPositiveDefinite reports whether a is symmetric to within the relative
+// tolerance tol, as tested by IsSymmetric, and its symmetric part is positive
+// definite. The test attempts a Cholesky factorization, which costs a third of
+// an LU factorization and stops early at the first non-positive pivot, making it
+// a cheap check of a covariance or Hessian matrix before solving with it. A
+// matrix that is positive definite only to within rounding error may fail the
+// test; IsPositiveSemidefinite tests against a tolerance on the eigenvalues. The
+// matrix a is not modified.
+func IsPositiveDefinite(a *Dense, tol float64) bool {
+	if !IsSymmetric(a, tol) {
+		return false
+	}
+	b := DenseCopyOf(a)
+	Symmetrize(b)
+	n, _ := b.Dims()
+	for j := 0; j < n; j++ {
+		// Fail fast on the necessary condition of positive diagonal.
+		if !(b.at(j, j) > 0) {
+			return false
+		}
+	}
+	return Cholesky(b).SPD
+}
+
+// IsPositiveSemidefinite reports whether a is symmetric to within the relative
+// tolerance tol, as tested by IsSymmetric, and the eigenvalues of its symmetric
+// part are all at least -tol times the largest eigenvalue magnitude. The test
+// computes the eigenvalues, so it is more expensive than IsPositiveDefinite but
+// correctly classifies singular and nearly singular matrices. The matrix a is
+// not modified.
+func IsPositiveSemidefinite(a *Dense, tol float64) bool {
+	if !IsSymmetric(a, tol) {
+		return false
+	}
+	b := DenseCopyOf(a)
+	Symmetrize(b)
+	n, _ := b.Dims()
+	if n == 0 {
+		return true
+	}
+	vals := Eigen(b, epsilon).Values()
+	var norm float64
+	for _, v := range vals {
+		norm = math.Max(norm, math.Abs(real(v)))
+	}
+	for _, v := range vals {
+		if real(v) < -tol*norm {
+			return false
+		}
+	}
+	return true
+}
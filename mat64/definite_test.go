@@ -0,0 +1,37 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestIsPositiveDefinite(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		a       *Dense
+		tol     float64
+		pd, psd bool
+	}{
+		{a: NewSPD([]float64{1, 2, 3}, rnd), tol: 1e-14, pd: true, psd: true},
+		{a: NewSPDCond(6, 1e10, rnd), tol: 1e-14, pd: true, psd: true},
+		{a: NewDense(2, 2, []float64{1, 1, 1, 1}), tol: 1e-14, pd: false, psd: true},
+		{a: NewDense(2, 2, []float64{1, 2, 2, 1}), tol: 1e-14, pd: false, psd: false},
+		{a: NewDense(2, 2, []float64{-1, 0, 0, 2}), tol: 1e-14, pd: false, psd: false},
+		{a: NewDense(2, 2, nil), tol: 0, pd: false, psd: true},
+		{a: NewDense(2, 2, []float64{2, 1, 1.1, 2}), tol: 0, pd: false, psd: false},
+		{a: NewDense(2, 2, []float64{2, 1, 1.1, 2}), tol: 0.1, pd: true, psd: true},
+		{a: NewDense(2, 3, nil), tol: 1, pd: false, psd: false},
+		// Positive semi-definite to within rounding.
+		{a: NewDense(2, 2, []float64{1, 1, 1, 1 - 1e-17}), tol: 1e-12, pd: false, psd: true},
+	} {
+		orig := DenseCopyOf(test.a)
+		c.Check(IsPositiveDefinite(test.a, test.tol), check.Equals, test.pd, check.Commentf("%v", test.a))
+		c.Check(IsPositiveSemidefinite(test.a, test.tol), check.Equals, test.psd, check.Commentf("%v", test.a))
+		c.Check(test.a.Equals(orig), check.Equals, true)
+	}
+}
@@ -58,12 +58,18 @@ type Dense struct {
 	mat RawMatrix
 }
 
+// NewDense returns a new r-by-c matrix. If mat is not nil it must have length
+// r*c and holds the elements in row-major order. The matrix takes ownership of
+// mat without copying it, so changes to the elements of either are visible in
+// the other; this allows a matrix to be constructed over a buffer owned by
+// other code, such as cgo allocated or network received memory, at no cost. If
+// mat is nil a new zeroed backing slice is allocated.
 func NewDense(r, c int, mat []float64) *Dense {
 	if mat != nil && r*c != len(mat) {
 		panic(ErrShape)
 	}
 	if mat == nil {
-		mat = make([]float64, r*c)
+		mat = newFloats(r * c)
 	}
 	return &Dense{RawMatrix{
 		Rows:   r,
@@ -73,6 +79,18 @@ func NewDense(r, c int, mat []float64) *Dense {
 	}}
 }
 
+// NewDenseStride returns a new r-by-c matrix whose rows start stride elements
+// apart in data, which the matrix uses without copying as described for
+// NewDense. This allows a matrix to view a buffer holding padded rows or a
+// sub-block of a larger row-major array. NewDenseStride will panic with
+// ErrIllegalStride if stride is less than c, and with ErrShape if data is too
+// short to hold the matrix.
+func NewDenseStride(r, c, stride int, data []float64) *Dense {
+	m := &Dense{}
+	m.SetRawMatrix(RawMatrix{Rows: r, Cols: c, Stride: stride, Data: data})
+	return m
+}
+
 // DenseCopyOf returns a newly allocated copy of the elements of a.
 func DenseCopyOf(a Matrix) *Dense {
 	d := &Dense{}
@@ -82,6 +100,24 @@ func DenseCopyOf(a Matrix) *Dense {
 
 func (m *Dense) LoadRawMatrix(b RawMatrix) { m.mat = b }
 
+// SetRawMatrix sets the receiver to the matrix described by b, sharing its
+// backing data without copying as for LoadRawMatrix. Unlike LoadRawMatrix, b is
+// checked: SetRawMatrix will panic with ErrIllegalStride if b.Stride is less
+// than b.Cols, and with ErrShape if the dimensions are negative or b.Data is too
+// short to hold the matrix.
+func (m *Dense) SetRawMatrix(b RawMatrix) {
+	if b.Rows < 0 || b.Cols < 0 {
+		panic(ErrShape)
+	}
+	if b.Stride < b.Cols || b.Stride < 1 {
+		panic(ErrIllegalStride)
+	}
+	if b.Rows > 0 && len(b.Data) < (b.Rows-1)*b.Stride+b.Cols {
+		panic(ErrShape)
+	}
+	m.mat = b
+}
+
 func (m *Dense) RawMatrix() RawMatrix { return m.mat }
 
 func (m *Dense) isZero() bool {
@@ -90,10 +126,10 @@ func (m *Dense) isZero() bool {
 
 func (m *Dense) At(r, c int) float64 {
 	if r >= m.mat.Rows || r < 0 {
-		panic("index error: row access out of bounds")
+		panic(ErrRowAccess)
 	}
 	if c >= m.mat.Cols || c < 0 {
-		panic("index error: column access out of bounds")
+		panic(ErrColAccess)
 	}
 	return m.at(r, c)
 }
@@ -104,10 +140,10 @@ func (m *Dense) at(r, c int) float64 {
 
 func (m *Dense) Set(r, c int, v float64) {
 	if r >= m.mat.Rows || r < 0 {
-		panic("index error: row access out of bounds")
+		panic(ErrRowAccess)
 	}
 	if c >= m.mat.Cols || c < 0 {
-		panic("index error: column access out of bounds")
+		panic(ErrColAccess)
 	}
 	m.mat.Data[r*m.mat.Stride+c] = v
 }
@@ -178,6 +214,44 @@ func (m *Dense) rowView(r int) []float64 {
 	return m.mat.Data[r*m.mat.Stride : r*m.mat.Stride+m.mat.Cols]
 }
 
+// Diag returns a newly allocated slice holding the elements of the k-th diagonal
+// of the receiver. The main diagonal is k == 0, super-diagonals have k > 0 and
+// sub-diagonals have k < 0. Diag will panic with ErrIndexOutOfRange if the k-th
+// diagonal does not exist.
+func (m *Dense) Diag(k int) []float64 {
+	off, n := m.diag(k)
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = m.mat.Data[off+i*(m.mat.Stride+1)]
+	}
+	return d
+}
+
+// SetDiag sets the elements of the k-th diagonal of the receiver to the values
+// held in v, using the same diagonal numbering as Diag. SetDiag will panic with
+// ErrShape if the length of v does not match the length of the diagonal.
+func (m *Dense) SetDiag(k int, v []float64) {
+	off, n := m.diag(k)
+	if len(v) != n {
+		panic(ErrShape)
+	}
+	for i, e := range v {
+		m.mat.Data[off+i*(m.mat.Stride+1)] = e
+	}
+}
+
+// diag returns the data offset of the first element of the k-th diagonal and
+// the number of elements in the diagonal.
+func (m *Dense) diag(k int) (off, n int) {
+	if k >= m.mat.Cols || -k >= m.mat.Rows {
+		panic(ErrIndexOutOfRange)
+	}
+	if k >= 0 {
+		return k, min(m.mat.Rows, m.mat.Cols-k)
+	}
+	return -k * m.mat.Stride, min(m.mat.Rows+k, m.mat.Cols)
+}
+
 func (m *Dense) View(a Matrix, i, j, r, c int) {
 	*m = *a.(*Dense)
 	m.mat.Data = m.mat.Data[i*m.mat.Stride+j : (i+r-1)*m.mat.Stride+(j+c)]
@@ -206,7 +280,7 @@ func (m *Dense) Clone(a Matrix) {
 	switch a := a.(type) {
 	case RawMatrixer:
 		amat := a.RawMatrix()
-		mat.Data = make([]float64, r*c)
+		mat.Data = newFloats(r * c)
 		for i := 0; i < r; i++ {
 			copy(mat.Data[i*c:(i+1)*c], amat.Data[i*amat.Stride:i*amat.Stride+c])
 		}
@@ -246,7 +320,7 @@ func (m *Dense) Copy(a Matrix) (r, c int) {
 	default:
 		for i := 0; i < r; i++ {
 			for j := 0; j < c; j++ {
-				m.Set(r, c, a.At(r, c))
+				m.Set(i, j, a.At(i, j))
 			}
 		}
 	}
@@ -370,6 +444,51 @@ func (m *Dense) zeroUpper() {
 	}
 }
 
+// Triu places the elements of a on and above the k-th diagonal into the receiver,
+// zeroing the remaining elements. Diagonals are numbered as for Diag, so Triu(a, 1)
+// gives the strictly upper triangular part of a. The matrix a need not be square.
+// If a is the receiver, the lower residue is zeroed in place.
+func (m *Dense) Triu(a Matrix, k int) {
+	m.triCopy(a)
+	for i := 0; i < m.mat.Rows; i++ {
+		if n := min(max(i+k, 0), m.mat.Cols); n > 0 {
+			zero(m.rowView(i)[:n])
+		}
+	}
+}
+
+// Tril places the elements of a on and below the k-th diagonal into the receiver,
+// zeroing the remaining elements. Diagonals are numbered as for Diag, so Tril(a, -1)
+// gives the strictly lower triangular part of a. The matrix a need not be square.
+// If a is the receiver, the upper residue is zeroed in place.
+func (m *Dense) Tril(a Matrix, k int) {
+	m.triCopy(a)
+	for i := 0; i < m.mat.Rows; i++ {
+		if n := max(i+k+1, 0); n < m.mat.Cols {
+			zero(m.rowView(i)[n:])
+		}
+	}
+}
+
+// triCopy copies a into the receiver in preparation for Triu and Tril.
+func (m *Dense) triCopy(a Matrix) {
+	if m == a {
+		return
+	}
+	ar, ac := a.Dims()
+	if m.isZero() {
+		m.mat = RawMatrix{
+			Rows:   ar,
+			Cols:   ac,
+			Stride: ac,
+			Data:   use(m.mat.Data, ar*ac),
+		}
+	} else if ar != m.mat.Rows || ac != m.mat.Cols {
+		panic(ErrShape)
+	}
+	m.Copy(a)
+}
+
 func (m *Dense) TCopy(a Matrix) {
 	ar, ac := a.Dims()
 
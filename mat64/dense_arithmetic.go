@@ -97,7 +97,7 @@ func (m *Dense) Norm(ord float64) float64 {
 		}
 		return n
 	case ord == 2, ord == -2:
-		s := SVD(m, epsilon, small, false, false).Sigma
+		s := SVD(DenseCopyOf(m), epsilon, small, false, false).Sigma
 		if ord == 2 {
 			return s[0]
 		}
@@ -109,6 +109,10 @@ func (m *Dense) Norm(ord float64) float64 {
 	return n
 }
 
+// Add places the element-wise sum of a and b in the receiver. The receiver may
+// be a or b, so m.Add(m, b) adds b to m in place without allocating. Add will
+// panic with ErrOverlap if the receiver shares storage with a or b other than
+// element for element.
 func (m *Dense) Add(a, b Matrix) {
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
@@ -127,6 +131,8 @@ func (m *Dense) Add(a, b Matrix) {
 	} else if ar != m.mat.Rows || ac != m.mat.Cols {
 		panic(ErrShape)
 	}
+	m.checkOverlap(a)
+	m.checkOverlap(b)
 
 	if a, ok := a.(RawMatrixer); ok {
 		if b, ok := b.(RawMatrixer); ok {
@@ -162,6 +168,8 @@ func (m *Dense) Add(a, b Matrix) {
 	}
 }
 
+// Sub places the element-wise difference of a and b in the receiver. The
+// receiver may be a or b, as described for Add.
 func (m *Dense) Sub(a, b Matrix) {
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
@@ -180,6 +188,8 @@ func (m *Dense) Sub(a, b Matrix) {
 	} else if ar != m.mat.Rows || ac != m.mat.Cols {
 		panic(ErrShape)
 	}
+	m.checkOverlap(a)
+	m.checkOverlap(b)
 
 	if a, ok := a.(RawMatrixer); ok {
 		if b, ok := b.(RawMatrixer); ok {
@@ -215,6 +225,8 @@ func (m *Dense) Sub(a, b Matrix) {
 	}
 }
 
+// MulElem places the element-wise product of a and b in the receiver. The
+// receiver may be a or b, as described for Add.
 func (m *Dense) MulElem(a, b Matrix) {
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
@@ -233,6 +245,8 @@ func (m *Dense) MulElem(a, b Matrix) {
 	} else if ar != m.mat.Rows || ac != m.mat.Cols {
 		panic(ErrShape)
 	}
+	m.checkOverlap(a)
+	m.checkOverlap(b)
 
 	if a, ok := a.(RawMatrixer); ok {
 		if b, ok := b.(RawMatrixer); ok {
@@ -306,6 +320,13 @@ func (m *Dense) Dot(b Matrix) float64 {
 	return d
 }
 
+// Mul places the matrix product of a and b in the receiver. The receiver may
+// share storage with a or b, in which case the product is formed in a temporary
+// matrix taken from the package's buffer pool and copied into the receiver's
+// existing storage, so that views of the receiver remain valid. If the receiver
+// is a or b and the product has a different shape, the receiver is replaced by
+// the product. A ColDense operand is multiplied without copying as described
+// for MulAdd.
 func (m *Dense) Mul(a, b Matrix) {
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
@@ -313,74 +334,115 @@ func (m *Dense) Mul(a, b Matrix) {
 	if ac != br {
 		panic(ErrShape)
 	}
+	if p := startProfile(); p != nil {
+		defer p.stop("Mul", 2*ar*ac*bc)
+	}
 
-	var w Dense
-	if m != a && m != b {
-		w = *m
+	_, colA := a.(*ColDense)
+	_, colB := b.(*ColDense)
+	if colA || colB {
+		m.MulAdd(1, a, false, b, false, 0, nil)
+		return
 	}
-	if w.isZero() {
-		w.mat = RawMatrix{
+
+	if m.overlaps(a) || m.overlaps(b) {
+		w := GetDense(ar, bc, false)
+		w.mul(a, b)
+		switch {
+		case ar == m.mat.Rows && bc == m.mat.Cols:
+			m.Copy(w)
+			PutDense(w)
+		case m == a || m == b:
+			// The receiver is an operand of a different shape,
+			// so it takes the storage of the result.
+			*m = *w
+		default:
+			PutDense(w)
+			panic(ErrShape)
+		}
+		return
+	}
+
+	if m.isZero() {
+		m.mat = RawMatrix{
 			Rows:   ar,
 			Cols:   bc,
 			Stride: bc,
-			Data:   use(w.mat.Data, ar*bc),
+			Data:   use(m.mat.Data, ar*bc),
 		}
-	} else if ar != w.mat.Rows || bc != w.mat.Cols {
+	} else if ar != m.mat.Rows || bc != m.mat.Cols {
 		panic(ErrShape)
 	}
+	m.mul(a, b)
+}
+
+// mul places the matrix product of a and b in the receiver, which must have the
+// correct shape and must not share storage with a or b.
+func (m *Dense) mul(a, b Matrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
 
 	if a, ok := a.(RawMatrixer); ok {
 		if b, ok := b.(RawMatrixer); ok {
 			amat, bmat := a.RawMatrix(), b.RawMatrix()
-			if blasEngine == nil {
-				panic(ErrNoEngine)
-			}
-			blasEngine.Dgemm(
-				blas.NoTrans, blas.NoTrans,
-				ar, bc, ac,
-				1.,
-				amat.Data, amat.Stride,
-				bmat.Data, bmat.Stride,
-				0.,
-				w.mat.Data, w.mat.Stride)
-			*m = w
-			return
-		}
-	}
-
-	if a, ok := a.(Vectorer); ok {
-		if b, ok := b.(Vectorer); ok {
-			row := make([]float64, ac)
-			col := make([]float64, br)
-			if blasEngine == nil {
-				panic(ErrNoEngine)
-			}
-			for r := 0; r < ar; r++ {
-				for c := 0; c < bc; c++ {
-					w.mat.Data[r*w.mat.Stride+c] = blasEngine.Ddot(ac, a.Row(row, r), 1, b.Col(col, c), 1)
+			bs := mulBlockSize
+			parallelRows(ar, ac*bc, func(lo, hi int) {
+				if blasEngine == nil {
+					gemmBlocked(hi-lo, bc, ac,
+						amat.Data[lo*amat.Stride:], amat.Stride,
+						bmat.Data, bmat.Stride,
+						m.mat.Data[lo*m.mat.Stride:], m.mat.Stride,
+						bs)
+					return
 				}
-			}
-			*m = w
+				blasEngine.Dgemm(
+					blas.NoTrans, blas.NoTrans,
+					hi-lo, bc, ac,
+					1.,
+					amat.Data[lo*amat.Stride:], amat.Stride,
+					bmat.Data, bmat.Stride,
+					0.,
+					m.mat.Data[lo*m.mat.Stride:], m.mat.Stride)
+			})
 			return
 		}
 	}
 
-	row := make([]float64, ac)
-	for r := 0; r < ar; r++ {
-		for i := range row {
-			row[i] = a.At(r, i)
+	// Pack the operands into contiguous storage for the native kernel.
+	bp := make([]float64, br*bc)
+	if bv, ok := b.(Vectorer); ok {
+		for r := 0; r < br; r++ {
+			bv.Row(bp[r*bc:(r+1)*bc], r)
 		}
-		for c := 0; c < bc; c++ {
-			var v float64
-			for i, e := range row {
-				v += e * b.At(i, c)
+	} else {
+		for r := 0; r < br; r++ {
+			for c := 0; c < bc; c++ {
+				bp[r*bc+c] = b.At(r, c)
 			}
-			w.mat.Data[r*w.mat.Stride+c] = v
 		}
 	}
-	*m = w
+	bs := mulBlockSize
+	parallelRows(ar, ac*bc, func(lo, hi int) {
+		ap := make([]float64, (hi-lo)*ac)
+		if av, ok := a.(Vectorer); ok {
+			for r := lo; r < hi; r++ {
+				av.Row(ap[(r-lo)*ac:(r-lo+1)*ac], r)
+			}
+		} else {
+			for r := lo; r < hi; r++ {
+				for c := 0; c < ac; c++ {
+					ap[(r-lo)*ac+c] = a.At(r, c)
+				}
+			}
+		}
+		gemmBlocked(hi-lo, bc, ac, ap, ac, bp, bc, m.mat.Data[lo*m.mat.Stride:], m.mat.Stride, bs)
+	})
 }
 
+// Scale places f times the elements of a in the receiver. The receiver may be a,
+// so m.Scale(f, m) scales m in place without allocating. Scale will panic with
+// ErrOverlap if the receiver shares storage with a other than element for
+// element.
 func (m *Dense) Scale(f float64, a Matrix) {
 	ar, ac := a.Dims()
 
@@ -394,13 +456,14 @@ func (m *Dense) Scale(f float64, a Matrix) {
 	} else if ar != m.mat.Rows || ac != m.mat.Cols {
 		panic(ErrShape)
 	}
+	m.checkOverlap(a)
 
 	if a, ok := a.(RawMatrixer); ok {
 		amat := a.RawMatrix()
 		for ja, jm := 0, 0; ja < ar*amat.Stride; ja, jm = ja+amat.Stride, jm+m.mat.Stride {
-			for i, v := range amat.Data[ja : ja+ac] {
-				m.mat.Data[i+jm] = v * f
-			}
+			dst := m.mat.Data[jm : jm+ac]
+			copy(dst, amat.Data[ja:ja+ac])
+			scalUnitary(f, dst)
 		}
 		return
 	}
@@ -408,9 +471,7 @@ func (m *Dense) Scale(f float64, a Matrix) {
 	if a, ok := a.(Vectorer); ok {
 		row := make([]float64, ac)
 		for r := 0; r < ar; r++ {
-			for i, v := range a.Row(row, r) {
-				row[i] = f * v
-			}
+			scalUnitary(f, a.Row(row, r))
 			copy(m.rowView(r), row)
 		}
 		return
@@ -423,6 +484,8 @@ func (m *Dense) Scale(f float64, a Matrix) {
 	}
 }
 
+// Apply places the result of f applied to each element of a, with its row and
+// column indices, in the receiver. The receiver may be a, as described for Scale.
 func (m *Dense) Apply(f ApplyFunc, a Matrix) {
 	ar, ac := a.Dims()
 
@@ -436,6 +499,7 @@ func (m *Dense) Apply(f ApplyFunc, a Matrix) {
 	} else if ar != m.mat.Rows || ac != m.mat.Cols {
 		panic(ErrShape)
 	}
+	m.checkOverlap(a)
 
 	if a, ok := a.(RawMatrixer); ok {
 		amat := a.RawMatrix()
@@ -465,6 +529,134 @@ func (m *Dense) Apply(f ApplyFunc, a Matrix) {
 	}
 }
 
+// ApplyParallel is like Apply, but shares the rows of the matrix among up to
+// MaxProcs goroutines, each processing a contiguous block of at least chunkRows
+// rows. If chunkRows is less than 1 a block size is chosen so that small
+// matrices are processed by the calling goroutine. f is called concurrently and
+// must be safe for concurrent use, and the At or Row methods of a must be safe
+// for concurrent reads.
+func (m *Dense) ApplyParallel(f ApplyFunc, a Matrix, chunkRows int) {
+	ar, ac := a.Dims()
+
+	if m.isZero() {
+		m.mat = RawMatrix{
+			Rows:   ar,
+			Cols:   ac,
+			Stride: ac,
+			Data:   use(m.mat.Data, ar*ac),
+		}
+	} else if ar != m.mat.Rows || ac != m.mat.Cols {
+		panic(ErrShape)
+	}
+	m.checkOverlap(a)
+
+	if chunkRows < 1 {
+		chunkRows = max(minParallelWork/max(ac, 1), 1)
+	}
+	procs := min(maxProcs, ar/chunkRows)
+
+	if a, ok := a.(RawMatrixer); ok {
+		amat := a.RawMatrix()
+		parallelBlocks(ar, procs, func(lo, hi int) {
+			for r := lo; r < hi; r++ {
+				row := m.mat.Data[r*m.mat.Stride : r*m.mat.Stride+ac]
+				for c, v := range amat.Data[r*amat.Stride : r*amat.Stride+ac] {
+					row[c] = f(r, c, v)
+				}
+			}
+		})
+		return
+	}
+
+	if a, ok := a.(Vectorer); ok {
+		parallelBlocks(ar, procs, func(lo, hi int) {
+			row := make([]float64, ac)
+			for r := lo; r < hi; r++ {
+				for c, v := range a.Row(row, r) {
+					row[c] = f(r, c, v)
+				}
+				copy(m.rowView(r), row)
+			}
+		})
+		return
+	}
+
+	parallelBlocks(ar, procs, func(lo, hi int) {
+		for r := lo; r < hi; r++ {
+			row := m.rowView(r)
+			for c := range row {
+				row[c] = f(r, c, a.At(r, c))
+			}
+		}
+	})
+}
+
+// ExpElem places the element-wise exponential of a in the receiver.
+func (m *Dense) ExpElem(a Matrix) { m.elem(math.Exp, a) }
+
+// LogElem places the element-wise natural logarithm of a in the receiver.
+func (m *Dense) LogElem(a Matrix) { m.elem(math.Log, a) }
+
+// SqrtElem places the element-wise square root of a in the receiver.
+func (m *Dense) SqrtElem(a Matrix) { m.elem(math.Sqrt, a) }
+
+// AbsElem places the element-wise absolute value of a in the receiver.
+func (m *Dense) AbsElem(a Matrix) { m.elem(math.Abs, a) }
+
+// PowElem places the elements of a raised to the power p in the receiver.
+func (m *Dense) PowElem(a Matrix, p float64) {
+	switch p {
+	case 2:
+		m.elem(func(v float64) float64 { return v * v }, a)
+	default:
+		m.elem(func(v float64) float64 { return math.Pow(v, p) }, a)
+	}
+}
+
+// elem applies f to each element of a, placing the result in the receiver.
+func (m *Dense) elem(f func(float64) float64, a Matrix) {
+	ar, ac := a.Dims()
+
+	if m.isZero() {
+		m.mat = RawMatrix{
+			Rows:   ar,
+			Cols:   ac,
+			Stride: ac,
+			Data:   use(m.mat.Data, ar*ac),
+		}
+	} else if ar != m.mat.Rows || ac != m.mat.Cols {
+		panic(ErrShape)
+	}
+	m.checkOverlap(a)
+
+	if a, ok := a.(RawMatrixer); ok {
+		amat := a.RawMatrix()
+		for ja, jm := 0, 0; ja < ar*amat.Stride; ja, jm = ja+amat.Stride, jm+m.mat.Stride {
+			for i, v := range amat.Data[ja : ja+ac] {
+				m.mat.Data[i+jm] = f(v)
+			}
+		}
+		return
+	}
+
+	if a, ok := a.(Vectorer); ok {
+		row := make([]float64, ac)
+		for r := 0; r < ar; r++ {
+			for i, v := range a.Row(row, r) {
+				row[i] = f(v)
+			}
+			copy(m.rowView(r), row)
+		}
+		return
+	}
+
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.Set(r, c, f(a.At(r, c)))
+		}
+	}
+}
+
 func (m *Dense) Sum() float64 {
 	l := m.mat.Cols
 	var s float64
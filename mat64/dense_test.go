@@ -7,6 +7,7 @@ package mat64
 import (
 	"github.com/gonum/floats"
 
+	"math"
 	"math/rand"
 	"testing"
 
@@ -123,6 +124,34 @@ func (s *S) TestNewDense(c *check.C) {
 	}
 }
 
+func (s *S) TestNewDenseStride(c *check.C) {
+	buf := []float64{
+		1, 2, 3, -1,
+		4, 5, 6, -1,
+		7, 8, 9,
+	}
+	m := NewDenseStride(3, 3, 4, buf)
+	c.Check(m.Equals(NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})), check.Equals, true)
+
+	// The buffer is shared, not copied.
+	m.Set(1, 1, 50)
+	c.Check(buf[5], check.Equals, 50.)
+	buf[8] = 70
+	c.Check(m.At(2, 0), check.Equals, 70.)
+	d := NewDense(2, 2, buf[:4])
+	d.Set(0, 0, 10)
+	c.Check(buf[0], check.Equals, 10.)
+
+	var r Dense
+	r.SetRawMatrix(RawMatrix{Rows: 2, Cols: 2, Stride: 4, Data: buf[1:]})
+	c.Check(r.At(1, 1), check.Equals, 6.)
+	c.Check(r.RawMatrix().Stride, check.Equals, 4)
+
+	c.Check(func() { NewDenseStride(3, 3, 2, buf) }, check.PanicMatches, string(ErrIllegalStride))
+	c.Check(func() { NewDenseStride(3, 3, 4, buf[:10]) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { r.SetRawMatrix(RawMatrix{Rows: -1, Cols: 2, Stride: 2}) }, check.PanicMatches, string(ErrShape))
+}
+
 func (s *S) TestAtSet(c *check.C) {
 	for test, af := range [][][]float64{
 		{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, // even
@@ -208,6 +237,68 @@ func (s *S) TestSetRowColumn(c *check.C) {
 	}
 }
 
+func (s *S) TestDiag(c *check.C) {
+	for i, test := range []struct {
+		a    [][]float64
+		k    int
+		diag []float64
+	}{
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, 0, []float64{1, 5, 9}},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, 1, []float64{2, 6}},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, -2, []float64{7}},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10, 11, 12}}, -1, []float64{4, 8, 12}},
+		{[][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}, 1, []float64{2, 7, 12}},
+		{[][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}, 3, []float64{4}},
+	} {
+		a := NewDense(flatten(test.a))
+		c.Check(a.Diag(test.k), check.DeepEquals, test.diag, check.Commentf("Test %d", i))
+
+		a.SetDiag(test.k, make([]float64, len(test.diag)))
+		c.Check(a.Sum(), check.Equals, NewDense(flatten(test.a)).Sum()-floats.Sum(test.diag), check.Commentf("Test %d", i))
+
+		r, cols := a.Dims()
+		c.Check(func() { a.Diag(cols) }, check.PanicMatches, string(ErrIndexOutOfRange), check.Commentf("Test %d", i))
+		c.Check(func() { a.Diag(-r) }, check.PanicMatches, string(ErrIndexOutOfRange), check.Commentf("Test %d", i))
+		c.Check(func() { a.SetDiag(test.k, nil) }, check.PanicMatches, string(ErrShape), check.Commentf("Test %d", i))
+	}
+}
+
+func (s *S) TestTriuTril(c *check.C) {
+	a := [][]float64{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{9, 10, 11, 12},
+	}
+	for i, test := range []struct {
+		k     int
+		upper bool
+		want  [][]float64
+	}{
+		{0, true, [][]float64{{1, 2, 3, 4}, {0, 6, 7, 8}, {0, 0, 11, 12}}},
+		{1, true, [][]float64{{0, 2, 3, 4}, {0, 0, 7, 8}, {0, 0, 0, 12}}},
+		{-1, true, [][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {0, 10, 11, 12}}},
+		{5, true, [][]float64{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}},
+		{0, false, [][]float64{{1, 0, 0, 0}, {5, 6, 0, 0}, {9, 10, 11, 0}}},
+		{-1, false, [][]float64{{0, 0, 0, 0}, {5, 0, 0, 0}, {9, 10, 0, 0}}},
+		{2, false, [][]float64{{1, 2, 3, 0}, {5, 6, 7, 8}, {9, 10, 11, 12}}},
+		{-3, false, [][]float64{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}},
+	} {
+		want := NewDense(flatten(test.want))
+
+		var m Dense
+		inPlace := NewDense(flatten(a))
+		if test.upper {
+			m.Triu(NewDense(flatten(a)), test.k)
+			inPlace.Triu(inPlace, test.k)
+		} else {
+			m.Tril(NewDense(flatten(a)), test.k)
+			inPlace.Tril(inPlace, test.k)
+		}
+		c.Check(m.Equals(want), check.Equals, true, check.Commentf("Test %d: got %v", i, m.mat.Data))
+		c.Check(inPlace.Equals(want), check.Equals, true, check.Commentf("Test %d: in place got %v", i, inPlace.mat.Data))
+	}
+}
+
 func (s *S) TestAdd(c *check.C) {
 	for i, test := range []struct {
 		a, b, r [][]float64
@@ -690,6 +781,57 @@ func (s *S) TestApply(c *check.C) {
 	}
 }
 
+func (s *S) TestFuseApply(c *check.C) {
+	a := NewDense(2, 3, []float64{-1, 2, 3, 4, -5, 6})
+	double := func(r, c int, v float64) float64 { return 2 * v }
+	shift := func(r, c int, v float64) float64 { return v + float64(r) }
+	clip := func(r, c int, v float64) float64 { return math.Max(v, 0) }
+
+	var want Dense
+	want.Apply(double, a)
+	want.Apply(shift, &want)
+	want.Apply(clip, &want)
+
+	var got Dense
+	got.Apply(FuseApply(double, shift, clip), a)
+	c.Check(got.Equals(&want), check.Equals, true)
+
+	got.Apply(FuseApply(), a)
+	c.Check(got.Equals(a), check.Equals, true)
+}
+
+func (s *S) TestElemFuncs(c *check.C) {
+	a := [][]float64{{1, 2, 3}, {4, 0.5, 6}}
+	for i, test := range []struct {
+		fn   func(m *Dense, a Matrix)
+		want func(float64) float64
+	}{
+		{(*Dense).ExpElem, math.Exp},
+		{(*Dense).LogElem, math.Log},
+		{(*Dense).SqrtElem, math.Sqrt},
+		{(*Dense).AbsElem, math.Abs},
+		{
+			func(m *Dense, a Matrix) { m.PowElem(a, 2) },
+			func(v float64) float64 { return v * v },
+		},
+		{
+			func(m *Dense, a Matrix) { m.PowElem(a, -1.5) },
+			func(v float64) float64 { return math.Pow(v, -1.5) },
+		},
+	} {
+		src := NewDense(flatten(a))
+		var t Dense
+		t.Apply(func(_, _ int, v float64) float64 { return test.want(v) }, src)
+
+		var r Dense
+		test.fn(&r, src)
+		c.Check(r.EqualsApprox(&t, 1e-15), check.Equals, true, check.Commentf("Test %d: obtained %v expect: %v", i, r.mat.Data, t.mat.Data))
+
+		test.fn(src, src)
+		c.Check(src.Equals(&r), check.Equals, true, check.Commentf("Test %d: in place obtained %v expect: %v", i, src.mat.Data, r.mat.Data))
+	}
+}
+
 func (s *S) TestClone(c *check.C) {
 	for i, test := range []struct {
 		a    [][]float64
@@ -716,6 +858,20 @@ func (s *S) TestClone(c *check.C) {
 	}
 }
 
+func (s *S) TestCopy(c *check.C) {
+	src := NewDense(2, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+	for i, a := range []Matrix{src, (*basicVectorer)(src), (*basicMatrix)(src)} {
+		m := NewDense(3, 2, nil)
+		r, cols := m.Copy(a)
+		c.Check(r, check.Equals, 2, check.Commentf("Test %d", i))
+		c.Check(cols, check.Equals, 2, check.Commentf("Test %d", i))
+		c.Check(m.Equals(NewDense(3, 2, []float64{1, 2, 4, 5, 0, 0})), check.Equals, true, check.Commentf("Test %d: got %v", i, m))
+	}
+}
+
 func (s *S) TestStack(c *check.C) {
 	for i, test := range []struct {
 		a, b, e [][]float64
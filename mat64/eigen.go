@@ -24,6 +24,14 @@ func symmetric(m *Dense) bool {
 type EigenFactors struct {
 	V    *Dense
 	d, e []float64
+
+	// vWork and ort are the storage reused by Factorize for the
+	// eigenvectors of a non-symmetric matrix and the Householder
+	// vectors of the Hessenberg reduction, and rot holds the
+	// rotations of the QL sweeps of a symmetric matrix.
+	vWork *Dense
+	ort   []float64
+	rot   []float64
 }
 
 // Eigen returns the Eigenvalues and eigenvectors of a square real matrix.
@@ -38,32 +46,100 @@ type EigenFactors struct {
 // i.e. a.v equals v.D. The matrix v may be badly conditioned, or even
 // singular, so the validity of the equation a = v*D*inverse(v) depends
 // upon the 2-norm condition number of v.
+//
+// The eigenvalues of a symmetric matrix are returned in ascending order and
+// those of a non-symmetric matrix in no particular order. The signs of the
+// eigenvectors are those arising in the computation and may differ between
+// backends; Sort and NormalizeSigns put the decomposition in a reproducible
+// form.
+//
+// The test for symmetry is exact, so a matrix that is symmetric only to rounding
+// error takes the slower non-symmetric path; IsSymmetric with a tolerance and
+// Symmetrize can be used to prepare such a matrix.
+//
+// EigenLeft additionally returns the left eigenvectors of a non-symmetric matrix.
+//
+// If the registered LAPACK backend implements LapackEigen, the decomposition is
+// computed by its Dsyev or Dgeev and epsilon is not used.
 func Eigen(a *Dense, epsilon float64) EigenFactors {
+	return eigen(a, epsilon, nil)
+}
+
+// eigen implements Eigen, abandoning the iteration by panicking with canceled
+// if done is closed.
+func eigen(a *Dense, epsilon float64, done <-chan struct{}) EigenFactors {
+	if p := startProfile(); p != nil {
+		n, _ := a.Dims()
+		flops := 25 * n * n * n
+		if symmetric(a) {
+			flops = 9 * n * n * n
+		}
+		defer p.stop("Eigen", flops)
+	}
+	var f EigenFactors
+	f.factorize(a, epsilon, done)
+
+	// The rotations are only needed again by a later call to Factorize.
+	f.rot = nil
+	return f
+}
+
+// Factorize computes the eigen decomposition of the square matrix a as described
+// for Eigen, storing the result in the receiver. The storage held by the receiver
+// from a previous call is reused when a has the same size, so that repeated
+// decompositions of matrices of the same size do not allocate after the first.
+// The values and vectors of any previous decomposition held by the receiver are
+// overwritten.
+func (f *EigenFactors) Factorize(a *Dense, epsilon float64) {
+	f.factorize(a, epsilon, nil)
+}
+
+// factorize implements Factorize, abandoning the iteration by panicking with
+// canceled if done is closed.
+func (f *EigenFactors) factorize(a *Dense, epsilon float64, done <-chan struct{}) {
 	m, n := a.Dims()
 	if m != n {
 		panic(ErrSquare)
 	}
+	checkFiniteDense("Eigen", 0, a)
+
+	if cap(f.d) < n || cap(f.e) < n {
+		f.d = make([]float64, n)
+		f.e = make([]float64, n)
+	} else if n > 0 {
+		f.d, f.e = f.d[:n], f.e[:n]
+		zero(f.d)
+		zero(f.e)
+	}
+	d, e := f.d, f.e
+	sym := symmetric(a)
+	if !sym && (f.vWork == nil || f.vWork.mat.Rows != n) {
+		f.vWork = NewDense(n, n, nil)
+		f.ort = make([]float64, n)
+	}
+	if sym && cap(f.rot) < 2*n {
+		f.rot = make([]float64, 2*n)
+	}
 
-	var v *Dense
-	d := make([]float64, n)
-	e := make([]float64, n)
+	if l, ok := lapackEngine.(LapackEigen); ok {
+		f.V = eigenLapack(l, a, d, e, f.vWork)
+		return
+	}
 
-	if symmetric(a) {
+	if sym {
 		// Tridiagonalize.
-		v = tred2(a, d, e)
+		f.V = tred2(a, d, e)
 
 		// Diagonalize.
-		tql2(d, e, v, epsilon)
+		tql2(d, e, f.V, epsilon, f.rot, done)
 	} else {
 		// Reduce to Hessenberg form.
 		var hess *Dense
-		hess, v = orthes(a)
+		hess, f.V = orthes(a, f.vWork, f.ort)
 
 		// Reduce Hessenberg to real Schur form.
-		hqr2(d, e, hess, v, epsilon)
+		hqr2(d, e, hess, f.V, epsilon, true, done)
 	}
-
-	return EigenFactors{v, d, e}
 }
 
 // Symmetric Householder reduction to tridiagonal form.
@@ -72,9 +148,9 @@ func Eigen(a *Dense, epsilon float64) EigenFactors {
 // Bowdler, Martin, Reinsch, and Wilkinson, Handbook for
 // Auto. Comp., Vol.ii-Linear Algebra, and the corresponding
 // Fortran subroutine in EISPACK.
-func tred2(a *Dense, d, e []float64) (v *Dense) {
+func tred2(a *Dense, d, e []float64) *Dense {
 	n := len(d)
-	v = a
+	v := a
 
 	for j := 0; j < n; j++ {
 		d[j] = v.At(n-1, j)
@@ -116,31 +192,31 @@ func tred2(a *Dense, d, e []float64) (v *Dense) {
 			}
 
 			// Apply similarity transformation to remaining columns.
+			// The product of the lower triangle of the remaining
+			// matrix with the Householder vector is formed one
+			// element of e per row, so that the rows can be
+			// shared among goroutines.
 			for j := 0; j < i; j++ {
-				f = d[j]
-				v.Set(j, i, f)
-				g = e[j] + v.At(j, j)*f
-				for k := j + 1; k <= i-1; k++ {
-					g += v.At(k, j) * d[k]
-					e[k] += v.At(k, j) * f
-				}
-				e[j] = g
+				v.Set(j, i, d[j])
 			}
-			f = 0
-			for j := 0; j < i; j++ {
-				e[j] /= h
-				f += e[j] * d[j]
+			if rowProcs(i, i) <= 1 {
+				tred2Product(v, d, e, h, i, 0, i)
+			} else {
+				// Copies that are never reassigned are captured
+				// by value, so nothing is moved to the heap when
+				// the work is not shared.
+				i, h := i, h
+				parallelRows(i, i, func(lo, hi int) { tred2Product(v, d, e, h, i, lo, hi) })
 			}
+			f = dotUnitary(e[:i], d)
 			hh := f / (h + h)
-			for j := 0; j < i; j++ {
-				e[j] -= hh * d[j]
+			axpyUnitary(-hh, d[:i], e)
+			if rowProcs(i, i/2+1) <= 1 {
+				tred2Update(v, d, e, 0, i)
+			} else {
+				parallelRows(i, i/2+1, func(lo, hi int) { tred2Update(v, d, e, lo, hi) })
 			}
 			for j := 0; j < i; j++ {
-				f = d[j]
-				g = e[j]
-				for k := j; k <= i-1; k++ {
-					v.Set(k, j, v.At(k, j)-(f*e[k]+g*d[k]))
-				}
 				d[j] = v.At(i-1, j)
 				v.Set(i, j, 0)
 			}
@@ -157,14 +233,11 @@ func tred2(a *Dense, d, e []float64) (v *Dense) {
 			for k := 0; k <= i; k++ {
 				d[k] = v.At(k, i+1) / h
 			}
-			for j := 0; j <= i; j++ {
-				var g float64
-				for k := 0; k <= i; k++ {
-					g += v.At(k, i+1) * v.At(k, j)
-				}
-				for k := 0; k <= i; k++ {
-					v.Set(k, j, v.At(k, j)-g*d[k])
-				}
+			if rowProcs(i+1, 2*(i+1)) <= 1 {
+				tred2Accumulate(v, d, i, 0, i+1)
+			} else {
+				i := i
+				parallelRows(i+1, 2*(i+1), func(lo, hi int) { tred2Accumulate(v, d, i, lo, hi) })
 			}
 		}
 		for k := 0; k <= i; k++ {
@@ -181,13 +254,52 @@ func tred2(a *Dense, d, e []float64) (v *Dense) {
 	return v
 }
 
-// Symmetric tridiagonal QL algorithm.
+// tred2Product sets e[lo:hi] to the corresponding elements of the product of
+// the symmetric matrix held in the lower triangle of v[:i, :i] with the
+// Householder vector d[:i], divided by h.
+func tred2Product(v *Dense, d, e []float64, h float64, i, lo, hi int) {
+	for j := lo; j < hi; j++ {
+		g := dotUnitary(v.rowView(j)[:j+1], d)
+		for k := j + 1; k < i; k++ {
+			g += v.At(k, j) * d[k]
+		}
+		e[j] = g / h
+	}
+}
+
+// tred2Update applies the rank two update of the Householder similarity
+// transformation to rows [lo, hi) of the lower triangle of v.
+func tred2Update(v *Dense, d, e []float64, lo, hi int) {
+	for k := lo; k < hi; k++ {
+		row := v.rowView(k)[:k+1]
+		axpyUnitary(-e[k], d[:k+1], row)
+		axpyUnitary(-d[k], e[:k+1], row)
+	}
+}
+
+// tred2Accumulate applies the Householder transformation held in column i+1 of
+// v, scaled by d, to columns [lo, hi) of v.
+func tred2Accumulate(v *Dense, d []float64, i, lo, hi int) {
+	for j := lo; j < hi; j++ {
+		var g float64
+		for k := 0; k <= i; k++ {
+			g += v.At(k, i+1) * v.At(k, j)
+		}
+		for k := 0; k <= i; k++ {
+			v.Set(k, j, v.At(k, j)-g*d[k])
+		}
+	}
+}
+
+// Symmetric tridiagonal QL algorithm. The rotations of each sweep are held
+// in rot, which is allocated if it is shorter than 2*len(d). If done is
+// closed, the iteration is abandoned by panicking with canceled.
 //
 // This is derived from the Algol procedures tql2, by
 // Bowdler, Martin, Reinsch, and Wilkinson, Handbook for
 // Auto. Comp., Vol.ii-Linear Algebra, and the corresponding
 // Fortran subroutine in EISPACK.
-func tql2(d, e []float64, v *Dense, epsilon float64) {
+func tql2(d, e []float64, v *Dense, epsilon float64, rot []float64, done <-chan struct{}) {
 	n := len(d)
 	for i := 1; i < n; i++ {
 		e[i-1] = e[i]
@@ -198,6 +310,15 @@ func tql2(d, e []float64, v *Dense, epsilon float64) {
 		f    float64
 		tst1 float64
 	)
+
+	// The rotations of each QL sweep are recorded and then applied to
+	// the rows of v, which are independent and may be shared among
+	// goroutines.
+	if len(rot) < 2*n {
+		rot = make([]float64, 2*n)
+	}
+	rc, rs := rot[:n], rot[n:2*n]
+	var sweeps int
 	for l := 0; l < n; l++ {
 		// Find small subdiagonal element
 		tst1 = math.Max(tst1, math.Abs(d[l])+math.Abs(e[l]))
@@ -251,17 +372,26 @@ func tql2(d, e []float64, v *Dense, epsilon float64) {
 					c = p / r
 					p = c*d[i] - s*g
 					d[i+1] = h + s*(c*g+s*d[i])
+					rc[i], rs[i] = c, s
+				}
 
-					// Accumulate transformation.
-					for k := 0; k < n; k++ {
-						h = v.At(k, i+1)
-						v.Set(k, i+1, s*v.At(k, i)+c*h)
-						v.Set(k, i, c*v.At(k, i)-s*h)
-					}
+				// Accumulate transformation.
+				if rowProcs(n, 6*(m-l)) <= 1 {
+					tql2Rotate(v, rc, rs, l, m, 0, n)
+				} else {
+					l, m := l, m
+					parallelRows(n, 6*(m-l), func(lo, hi int) { tql2Rotate(v, rc, rs, l, m, lo, hi) })
 				}
 				p = -s * s2 * c3 * el1 * e[l] / dl1
 				e[l] = s * p
 				d[l] = c * p
+				checkFiniteSlice("tql2", iter, "d", d)
+				checkFiniteSlice("tql2", iter, "e", e)
+				checkDone(done)
+				sweeps++
+				if progress != nil {
+					progress("tql2", sweeps, Vec(e[l:]).Norm(2))
+				}
 
 				// Check for convergence.
 				if math.Abs(e[l]) <= epsilon*tst1 {
@@ -295,17 +425,37 @@ func tql2(d, e []float64, v *Dense, epsilon float64) {
 	}
 }
 
+// tql2Rotate applies the rotations rc[i], rs[i] for i in [l, m) of a QL sweep to
+// rows [lo, hi) of v.
+func tql2Rotate(v *Dense, rc, rs []float64, l, m, lo, hi int) {
+	for k := lo; k < hi; k++ {
+		row := v.rowView(k)
+		for i := m - 1; i >= l; i-- {
+			h := row[i+1]
+			row[i+1] = rs[i]*row[i] + rc[i]*h
+			row[i] = rc[i]*row[i] - rs[i]*h
+		}
+	}
+}
+
 // Nonsymmetric reduction to Hessenberg form.
 //
 // This is derived from the Algol procedures orthes and ortran,
 // by Martin and Wilkinson, Handbook for Auto. Comp.,
 // Vol.ii-Linear Algebra, and the corresponding
 // Fortran subroutines in EISPACK.
-func orthes(a *Dense) (hess, v *Dense) {
+//
+// The accumulated transformations are stored in v and ort is used as scratch.
+// If v or ort is nil, it is allocated.
+func orthes(a, v *Dense, ort []float64) (hess, _ *Dense) {
 	n, _ := a.Dims()
 	hess = a
 
-	ort := make([]float64, n)
+	if ort == nil {
+		ort = make([]float64, n)
+	} else if n > 0 {
+		zero(ort)
+	}
 
 	low := 0
 	high := n - 1
@@ -359,7 +509,9 @@ func orthes(a *Dense) (hess, v *Dense) {
 	}
 
 	// Accumulate transformations (Algol's ortran).
-	v = NewDense(n, n, nil)
+	if v == nil {
+		v = NewDense(n, n, nil)
+	}
 	for i := 0; i < n; i++ {
 		for j := 0; j < n; j++ {
 			if i == j {
@@ -398,12 +550,16 @@ func cdiv(xr, xi, yr, yi float64) (float64, float64) {
 }
 
 // Nonsymmetric reduction from Hessenberg to real Schur form.
+// If vectors is false, hess and v are left holding the quasi-triangular
+// Schur form and the accumulated transformations, otherwise they are
+// overwritten by the eigenvectors. If done is closed, the iteration is
+// abandoned by panicking with canceled.
 //
 // This is derived from the Algol procedure hqr2,
 // by Martin and Wilkinson, Handbook for Auto. Comp.,
 // Vol.ii-Linear Algebra, and the corresponding
 // Fortran subroutine in EISPACK.
-func hqr2(d, e []float64, hess, v *Dense, epsilon float64) {
+func hqr2(d, e []float64, hess, v *Dense, epsilon float64, vectors bool, done <-chan struct{}) {
 	// Initialize
 	nn := len(d)
 	n := nn - 1
@@ -426,6 +582,7 @@ func hqr2(d, e []float64, hess, v *Dense, epsilon float64) {
 	}
 
 	// Outer loop over eigenvalue index
+	var sweeps int
 	for iter := 0; n >= low; {
 		// Look for single small sub-diagonal element
 		l := n
@@ -554,6 +711,16 @@ func hqr2(d, e []float64, hess, v *Dense, epsilon float64) {
 			}
 
 			iter++ // Could check iteration count here.
+			checkFiniteDense("hqr2", iter, hess)
+			checkDone(done)
+			sweeps++
+			if progress != nil {
+				var sub float64
+				for i := low + 1; i <= n; i++ {
+					sub = math.Hypot(sub, hess.At(i, i-1))
+				}
+				progress("hqr2", sweeps, sub)
+			}
 
 			// Look for two consecutive small sub-diagonal elements
 			m := n - 2
@@ -660,7 +827,7 @@ func hqr2(d, e []float64, hess, v *Dense, epsilon float64) {
 	}
 
 	// Backsubstitute to find vectors of upper triangular form
-	if norm == 0 {
+	if !vectors || norm == 0 {
 		return
 	}
 
@@ -803,6 +970,42 @@ func hqr2(d, e []float64, hess, v *Dense, epsilon float64) {
 	}
 }
 
+// Values returns the eigenvalues as complex numbers. Complex eigenvalues occur
+// in conjugate pairs with the eigenvalue having positive imaginary part first.
+func (f EigenFactors) Values() []complex128 {
+	vals := make([]complex128, len(f.d))
+	for i, v := range f.d {
+		vals[i] = complex(v, f.e[i])
+	}
+	return vals
+}
+
+// ComplexVectors returns the eigenvectors as the columns of a complex matrix,
+// with column j corresponding to the j-th eigenvalue returned by Values. In the
+// real representation held in V, a complex conjugate pair of eigenvalues is
+// represented by two columns holding the real and imaginary parts of the
+// eigenvector of the first eigenvalue of the pair; ComplexVectors expands each
+// such pair into the two conjugate complex eigenvectors.
+func (f EigenFactors) ComplexVectors() *CDense {
+	n, _ := f.V.Dims()
+	c := NewCDense(n, n, nil)
+	for j := 0; j < n; j++ {
+		if f.e[j] > 0 && j+1 < n {
+			for i := 0; i < n; i++ {
+				re, im := f.V.At(i, j), f.V.At(i, j+1)
+				c.Set(i, j, complex(re, im))
+				c.Set(i, j+1, complex(re, -im))
+			}
+			j++
+			continue
+		}
+		for i := 0; i < n; i++ {
+			c.Set(i, j, complex(f.V.At(i, j), 0))
+		}
+	}
+	return c
+}
+
 // D returns the block diagonal eigenvalue matrix from the real and imaginary
 // components d and e.
 func (f EigenFactors) D() *Dense {
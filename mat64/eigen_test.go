@@ -7,6 +7,8 @@ package mat64
 import (
 	check "launchpad.net/gocheck"
 	"math"
+	"math/rand"
+	"testing"
 )
 
 func (s *S) TestEigen(c *check.C) {
@@ -100,3 +102,126 @@ func (s *S) TestEigen(c *check.C) {
 		c.Check(t.a.EqualsApprox(ef.V, 1e-12), check.Equals, true)
 	}
 }
+
+func (s *S) TestEigenComplexVectors(c *check.C) {
+	for i, a := range []*Dense{
+		NewDense(2, 2, []float64{
+			0, -1,
+			1, 0,
+		}),
+		NewDense(3, 3, []float64{
+			1, 2, 3,
+			-4, 5, 6,
+			7, -8, 9,
+		}),
+		NewDense(3, 3, []float64{
+			1, 2, 1,
+			6, -1, 0,
+			-1, -2, -1,
+		}),
+	} {
+		ef := Eigen(DenseCopyOf(a), math.Pow(2, -52.0))
+		vals := ef.Values()
+		vecs := ef.ComplexVectors()
+		n, _ := a.Dims()
+		for j, lambda := range vals {
+			for k := 0; k < n; k++ {
+				var av complex128
+				for l := 0; l < n; l++ {
+					av += complex(a.At(k, l), 0) * vecs.At(l, j)
+				}
+				d := av - lambda*vecs.At(k, j)
+				c.Check(math.Hypot(real(d), imag(d)) < 1e-12, check.Equals, true,
+					check.Commentf("Test %d: eigenpair %d does not satisfy a.v = λ.v", i, j))
+			}
+		}
+
+		re := vecs.Real()
+		im := vecs.Imag()
+		for j := 0; j < n; j++ {
+			c.Check(real(vals[j]), check.Equals, ef.d[j], check.Commentf("Test %d", i))
+			c.Check(imag(vals[j]), check.Equals, ef.e[j], check.Commentf("Test %d", i))
+			if ef.e[j] == 0 {
+				c.Check(im.Col(nil, j), check.DeepEquals, make([]float64, n), check.Commentf("Test %d", i))
+				c.Check(re.Col(nil, j), check.DeepEquals, ef.V.Col(nil, j), check.Commentf("Test %d", i))
+			}
+		}
+	}
+}
+
+func (s *S) TestEigenFactorize(c *check.C) {
+	a := NewDense(4, 4, []float64{
+		1, 2, 0, -1,
+		3, 1, 1, 0,
+		0, 1, 2, 4,
+		1, 0, -2, 1,
+	})
+	sym := NewDense(3, 3, []float64{
+		2, 1, 0,
+		1, 3, 1,
+		0, 1, 4,
+	})
+	want := Eigen(DenseCopyOf(a), epsilon)
+	wantSym := Eigen(DenseCopyOf(sym), epsilon)
+
+	var f EigenFactors
+	work := NewDense(4, 4, nil)
+	for i := 0; i < 3; i++ {
+		work.Copy(a)
+		f.Factorize(work, epsilon)
+		c.Check(f.Values(), check.DeepEquals, want.Values())
+		c.Check(f.V.Equals(want.V), check.Equals, true)
+
+		// Changing size and symmetry reallocates as needed.
+		f.Factorize(DenseCopyOf(sym), epsilon)
+		c.Check(f.Values(), check.DeepEquals, wantSym.Values())
+		c.Check(f.V.Equals(wantSym.V), check.Equals, true)
+	}
+
+	// Repeated decompositions of the same size do not allocate.
+	f.Factorize(work, epsilon)
+	allocs := testing.AllocsPerRun(10, func() {
+		work.Copy(a)
+		f.Factorize(work, epsilon)
+	})
+	c.Check(allocs, check.Equals, 0.)
+
+	symWork := NewDense(3, 3, nil)
+	symWork.Copy(sym)
+	f.Factorize(symWork, epsilon)
+	allocs = testing.AllocsPerRun(10, func() {
+		symWork.Copy(sym)
+		f.Factorize(symWork, epsilon)
+	})
+	c.Check(allocs, check.Equals, 0.)
+}
+
+func (s *S) TestEigenSymParallel(c *check.C) {
+	defer SetMaxProcs(SetMaxProcs(1))
+
+	// Large enough for both the reduction and the QL sweeps to be shared.
+	const n = 400
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			v := rnd.NormFloat64()
+			a.Set(i, j, v)
+			a.Set(j, i, v)
+		}
+	}
+
+	want := Eigen(DenseCopyOf(a), epsilon)
+	SetMaxProcs(4)
+	got := Eigen(DenseCopyOf(a), epsilon)
+
+	for i, v := range got.d {
+		c.Check(math.Abs(v-want.d[i]) < 1e-10, check.Equals, true, check.Commentf("eigenvalue %d", i))
+	}
+	c.Check(isOrthonormal(got.V, 1e-10), check.Equals, true)
+
+	var av, vd Dense
+	av.Mul(a, got.V)
+	vd.Mul(got.V, got.D())
+	c.Check(av.EqualsApprox(&vd, 1e-9), check.Equals, true)
+}
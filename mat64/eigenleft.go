@@ -0,0 +1,188 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// EigenLeft returns the eigen decomposition of the square matrix a as described
+// for Eigen, together with the left eigenvectors of a as the columns of a
+// complex matrix. Column j of left is the unit vector y satisfying
+// y^H.a = λ.y^H for the j-th eigenvalue λ returned by Values of the
+// decomposition, so that the left and right eigenvectors are paired for
+// sensitivity analysis. The matrix a is overwritten during the decomposition.
+//
+// The left eigenvectors are computed from the real Schur form a = Z.T.Z' by
+// forward substitution with the quasi-triangular T, so that left eigenvectors
+// are obtained without forming the inverse of the right eigenvector matrix. For
+// a symmetric matrix the left and right eigenvectors coincide.
+func EigenLeft(a *Dense, epsilon float64) (f EigenFactors, left *CDense) {
+	m, n := a.Dims()
+	if m != n {
+		panic(ErrSquare)
+	}
+	if symmetric(a) {
+		f = Eigen(a, epsilon)
+		return f, f.ComplexVectors()
+	}
+
+	s := Schur(DenseCopyOf(a), epsilon)
+	f = Eigen(a, epsilon)
+
+	vals, vecs := schurLeftVectors(s.T, s.Z)
+
+	// Pair each eigenvalue of the decomposition with the nearest unused
+	// eigenvalue of the Schur form; with the native backend the two are
+	// computed identically and appear in the same order.
+	left = NewCDense(n, n, nil)
+	used := make([]bool, n)
+	for j, lambda := range f.Values() {
+		k := -1
+		for i, mu := range vals {
+			if used[i] {
+				continue
+			}
+			if k < 0 || cmplx.Abs(mu-lambda) < cmplx.Abs(vals[k]-lambda) {
+				k = i
+			}
+		}
+		used[k] = true
+		for i := 0; i < n; i++ {
+			left.Set(i, j, vecs[k][i])
+		}
+	}
+	return f, left
+}
+
+// schurLeftVectors returns the eigenvalues of the real Schur form a = z.t.z' in
+// the order of the diagonal blocks of t, with the eigenvalue having positive
+// imaginary part first in each complex pair, and the corresponding unit left
+// eigenvectors of a.
+func schurLeftVectors(t, z *Dense) (vals []complex128, vecs [][]complex128) {
+	n, _ := t.Dims()
+	var norm float64
+	for i := 0; i < n; i++ {
+		for _, v := range t.rowView(i) {
+			norm += math.Abs(v)
+		}
+	}
+	small := epsilon * math.Max(norm, 1)
+
+	w := make([]complex128, n)
+	for k := 0; k < n; {
+		p := schurBlockSize(t, k)
+		re, im := schurBlockEigenvalue(t, k, p)
+		lambda := complex(re, im)
+
+		// Left eigenvector of the diagonal block holding lambda.
+		for i := range w {
+			w[i] = 0
+		}
+		if p == 1 {
+			w[k] = 1
+		} else {
+			w[k] = complex(t.At(k+1, k), 0)
+			w[k+1] = lambda - complex(t.At(k, k), 0)
+		}
+
+		// Forward substitution for w.(t - λ.I) = 0 through the
+		// following diagonal blocks.
+		for j := k + p; j < n; {
+			q := schurBlockSize(t, j)
+			var s [2]complex128
+			for c := 0; c < q; c++ {
+				for i := k; i < j; i++ {
+					s[c] += w[i] * complex(t.At(i, j+c), 0)
+				}
+			}
+			if q == 1 {
+				den := complex(t.At(j, j), 0) - lambda
+				if den == 0 {
+					den = complex(small, 0)
+				}
+				w[j] = -s[0] / den
+			} else {
+				a := complex(t.At(j, j), 0) - lambda
+				b := complex(t.At(j, j+1), 0)
+				c := complex(t.At(j+1, j), 0)
+				d := complex(t.At(j+1, j+1), 0) - lambda
+				det := a*d - b*c
+				if det == 0 {
+					det = complex(small, 0)
+				}
+				w[j] = (c*s[1] - d*s[0]) / det
+				w[j+1] = (b*s[0] - a*s[1]) / det
+			}
+			j += q
+		}
+
+		// Transform back, y = z.conj(w), and normalize.
+		y := make([]complex128, n)
+		var ynorm float64
+		for i := range y {
+			row := z.rowView(i)
+			for j := k; j < n; j++ {
+				y[i] += complex(row[j], 0) * cmplx.Conj(w[j])
+			}
+			ynorm = math.Hypot(ynorm, cmplx.Abs(y[i]))
+		}
+		for i := range y {
+			y[i] /= complex(ynorm, 0)
+		}
+
+		vals = append(vals, lambda)
+		vecs = append(vecs, y)
+		if p == 2 {
+			conj := make([]complex128, n)
+			for i, v := range y {
+				conj[i] = cmplx.Conj(v)
+			}
+			vals = append(vals, cmplx.Conj(lambda))
+			vecs = append(vecs, conj)
+		}
+		k += p
+	}
+	return vals, vecs
+}
+
+// Conditions returns the condition numbers of the eigenvalues of the
+// decomposition, given the left eigenvectors returned with it by EigenLeft. The
+// condition number of the j-th eigenvalue is |x|.|y|/|y^H.x| for the right and
+// left eigenvectors x and y, and bounds the first order change in the eigenvalue
+// caused by a perturbation of a relative to the size of the perturbation. A
+// condition number near 1, as for every eigenvalue of a symmetric matrix,
+// indicates a well determined eigenvalue; a large one indicates an eigenvalue
+// that may be computed with little accuracy. The condition number of an
+// eigenvalue with orthogonal left and right eigenvectors, as for a defective
+// eigenvalue, is +Inf.
+//
+// Conditions will panic with ErrShape if left does not match the dimensions of
+// the decomposition.
+func (f EigenFactors) Conditions(left *CDense) []float64 {
+	n, _ := f.V.Dims()
+	if r, c := left.Dims(); r != n || c != n {
+		panic(ErrShape)
+	}
+	right := f.ComplexVectors()
+	cond := make([]float64, n)
+	for j := range cond {
+		var xnorm, ynorm float64
+		var yx complex128
+		for i := 0; i < n; i++ {
+			x, y := right.At(i, j), left.At(i, j)
+			xnorm = math.Hypot(xnorm, cmplx.Abs(x))
+			ynorm = math.Hypot(ynorm, cmplx.Abs(y))
+			yx += cmplx.Conj(y) * x
+		}
+		if yx == 0 {
+			cond[j] = math.Inf(1)
+			continue
+		}
+		cond[j] = xnorm * ynorm / cmplx.Abs(yx)
+	}
+	return cond
+}
@@ -0,0 +1,102 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math"
+	"math/cmplx"
+)
+
+func (s *S) TestEigenLeft(c *check.C) {
+	for i, a := range []*Dense{
+		NewDense(3, 3, []float64{
+			1, 2, 3,
+			-4, 5, 6,
+			7, -8, 9,
+		}),
+		NewDense(4, 4, []float64{
+			1, 2, 0, -1,
+			3, 1, 1, 0,
+			0, 1, 2, 4,
+			1, 0, -2, 1,
+		}),
+		NewDense(3, 3, []float64{
+			1, 5, 7,
+			0, 2, 11,
+			0, 0, 3,
+		}),
+		NewDense(3, 3, []float64{
+			2, 1, 0,
+			1, 3, 1,
+			0, 1, 4,
+		}),
+	} {
+		n, _ := a.Dims()
+		f, left := EigenLeft(DenseCopyOf(a), epsilon)
+		want := Eigen(DenseCopyOf(a), epsilon)
+		c.Check(f.Values(), check.DeepEquals, want.Values(), check.Commentf("Test %d", i))
+
+		for j, lambda := range f.Values() {
+			var ynorm float64
+			for k := 0; k < n; k++ {
+				ynorm = math.Hypot(ynorm, cmplx.Abs(left.At(k, j)))
+			}
+			c.Check(math.Abs(ynorm-1) < 1e-14, check.Equals, true, check.Commentf("Test %d: vector %d", i, j))
+
+			// y^H.a = λ.y^H
+			for k := 0; k < n; k++ {
+				var ya complex128
+				for l := 0; l < n; l++ {
+					ya += cmplx.Conj(left.At(l, j)) * complex(a.At(l, k), 0)
+				}
+				d := ya - lambda*cmplx.Conj(left.At(k, j))
+				c.Check(cmplx.Abs(d) < 1e-12, check.Equals, true,
+					check.Commentf("Test %d: eigenpair %d does not satisfy y^H.a = λ.y^H", i, j))
+			}
+		}
+	}
+}
+
+func (s *S) TestEigenConditions(c *check.C) {
+	// The eigenvalues of a symmetric matrix are perfectly conditioned.
+	f, left := EigenLeft(NewDense(3, 3, []float64{
+		2, 1, 0,
+		1, 3, 1,
+		0, 1, 4,
+	}), epsilon)
+	for j, v := range f.Conditions(left) {
+		c.Check(math.Abs(v-1) < 1e-12, check.Equals, true, check.Commentf("eigenvalue %d", j))
+	}
+
+	// For the upper triangular [1 t; 0 2] both eigenvalues have condition
+	// number sqrt(1+t^2).
+	for _, t := range []float64{0, 1, 1e4} {
+		f, left := EigenLeft(NewDense(2, 2, []float64{
+			1, t,
+			0, 2,
+		}), epsilon)
+		want := math.Sqrt(1 + t*t)
+		for j, v := range f.Conditions(left) {
+			c.Check(math.Abs(v-want) < 1e-10*want, check.Equals, true, check.Commentf("t=%v eigenvalue %d: got %v", t, j, v))
+		}
+	}
+
+	// Complex conjugate pairs share a condition number.
+	f, left = EigenLeft(NewDense(3, 3, []float64{
+		1, 2, 3,
+		-4, 5, 6,
+		7, -8, 9,
+	}), epsilon)
+	cond := f.Conditions(left)
+	for j, lambda := range f.Values() {
+		c.Check(cond[j] >= 1-1e-12, check.Equals, true)
+		if imag(lambda) > 0 {
+			c.Check(math.Abs(cond[j]-cond[j+1]) < 1e-12, check.Equals, true)
+		}
+	}
+
+	c.Check(func() { f.Conditions(NewCDense(2, 2, nil)) }, check.PanicMatches, string(ErrShape))
+}
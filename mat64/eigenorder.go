@@ -0,0 +1,153 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// EigenOrder specifies an ordering of the eigenvalues of an EigenFactors.
+type EigenOrder int
+
+const (
+	// EigenAscending orders the eigenvalues by increasing real part,
+	// and then by increasing imaginary part. This is the order in
+	// which Eigen returns the eigenvalues of a symmetric matrix, and
+	// the order used by NumPy's eigh.
+	EigenAscending EigenOrder = iota
+
+	// EigenDescending orders the eigenvalues by decreasing real part,
+	// and then by decreasing imaginary part.
+	EigenDescending
+
+	// EigenByMagnitude orders the eigenvalues by decreasing modulus,
+	// and then as for EigenDescending.
+	EigenByMagnitude
+)
+
+// ErrEigenOrder is the panic value used by Sort for an invalid EigenOrder.
+const ErrEigenOrder = Error("mat64: invalid eigenvalue order")
+
+// Sort reorders the eigenvalues and the corresponding columns of V according to
+// order. A complex conjugate pair is kept together, with the eigenvalue having
+// positive imaginary part first, and is ordered by that eigenvalue. The
+// ordering is stable. Sort will panic with ErrEigenOrder if order is not a valid
+// EigenOrder.
+func (f *EigenFactors) Sort(order EigenOrder) {
+	if order < EigenAscending || order > EigenByMagnitude {
+		panic(ErrEigenOrder)
+	}
+	n := len(f.d)
+	var blocks []eigenBlock
+	for j := 0; j < n; j++ {
+		b := eigenBlock{start: j, size: 1, val: complex(f.d[j], f.e[j])}
+		if f.e[j] > 0 && j+1 < n {
+			b.size = 2
+			j++
+		}
+		blocks = append(blocks, b)
+	}
+	sort.Stable(eigenBlocks{blocks, order})
+
+	d := make([]float64, n)
+	e := make([]float64, n)
+	v := NewDense(n, n, nil)
+	var j int
+	for _, b := range blocks {
+		for k := 0; k < b.size; k++ {
+			d[j], e[j] = f.d[b.start+k], f.e[b.start+k]
+			for i := 0; i < n; i++ {
+				v.Set(i, j, f.V.at(i, b.start+k))
+			}
+			j++
+		}
+	}
+	copy(f.d, d)
+	copy(f.e, e)
+	f.V.Copy(v)
+}
+
+// NormalizeSigns scales each eigenvector held in V so that its component of
+// largest magnitude, the first such if there are several, is real and positive.
+// For a real eigenvector this fixes its sign, and for a complex conjugate pair
+// it fixes the phase of the complex eigenvector represented by the pair of
+// columns. The normalized vectors do not depend on the arbitrary choice of sign
+// made by a particular algorithm or backend, so they are reproducible and may
+// be compared directly with those of other libraries after applying the same
+// convention.
+func (f *EigenFactors) NormalizeSigns() {
+	n := len(f.d)
+	for j := 0; j < n; j++ {
+		if f.e[j] > 0 && j+1 < n {
+			var (
+				big  complex128
+				bigM float64
+				bigI int
+			)
+			for i := 0; i < n; i++ {
+				z := complex(f.V.at(i, j), f.V.at(i, j+1))
+				if m := cmplx.Abs(z); m > bigM {
+					big, bigM, bigI = z, m, i
+				}
+			}
+			if bigM != 0 {
+				rot := cmplx.Conj(big) / complex(bigM, 0)
+				for i := 0; i < n; i++ {
+					z := complex(f.V.at(i, j), f.V.at(i, j+1)) * rot
+					f.V.Set(i, j, real(z))
+					f.V.Set(i, j+1, imag(z))
+				}
+				f.V.Set(bigI, j, bigM)
+				f.V.Set(bigI, j+1, 0)
+			}
+			j++
+			continue
+		}
+		var big, bigM float64
+		for i := 0; i < n; i++ {
+			if v := f.V.at(i, j); math.Abs(v) > bigM {
+				big, bigM = v, math.Abs(v)
+			}
+		}
+		if big < 0 {
+			for i := 0; i < n; i++ {
+				f.V.Set(i, j, -f.V.at(i, j))
+			}
+		}
+	}
+}
+
+type eigenBlock struct {
+	start, size int
+	val         complex128
+}
+
+type eigenBlocks struct {
+	b     []eigenBlock
+	order EigenOrder
+}
+
+func (b eigenBlocks) Len() int      { return len(b.b) }
+func (b eigenBlocks) Swap(i, j int) { b.b[i], b.b[j] = b.b[j], b.b[i] }
+func (b eigenBlocks) Less(i, j int) bool {
+	x, y := b.b[i].val, b.b[j].val
+	switch b.order {
+	case EigenAscending:
+		if real(x) != real(y) {
+			return real(x) < real(y)
+		}
+		return imag(x) < imag(y)
+	case EigenByMagnitude:
+		if mx, my := cmplx.Abs(x), cmplx.Abs(y); mx != my {
+			return mx > my
+		}
+	}
+	if real(x) != real(y) {
+		return real(x) > real(y)
+	}
+	return imag(x) > imag(y)
+}
@@ -0,0 +1,103 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+
+	check "launchpad.net/gocheck"
+)
+
+func checkEigenFactors(c *check.C, a *Dense, f EigenFactors, comment check.CommentInterface) {
+	var av, vd Dense
+	av.Mul(a, f.V)
+	vd.Mul(f.V, f.D())
+	c.Check(av.EqualsApprox(&vd, 1e-12), check.Equals, true, comment)
+}
+
+func (s *S) TestEigenSort(c *check.C) {
+	a := schurTestMatrix()
+	want := Eigen(DenseCopyOf(a), epsilon)
+
+	for _, test := range []struct {
+		order EigenOrder
+		less  func(x, y complex128) bool
+	}{
+		{EigenAscending, func(x, y complex128) bool { return real(x) < real(y) || real(x) == real(y) && imag(x) <= imag(y) }},
+		{EigenDescending, func(x, y complex128) bool { return real(x) > real(y) || real(x) == real(y) && imag(x) >= imag(y) }},
+		{EigenByMagnitude, func(x, y complex128) bool { return cmplx.Abs(x) >= cmplx.Abs(y) }},
+	} {
+		f := Eigen(DenseCopyOf(a), epsilon)
+		f.Sort(test.order)
+		comment := check.Commentf("order %d", test.order)
+		checkEigenFactors(c, a, f, comment)
+
+		vals := f.Values()
+		c.Check(len(vals), check.Equals, len(want.Values()))
+		var prev complex128
+		first := true
+		for j, v := range vals {
+			if imag(v) < 0 {
+				// The conjugate follows its partner.
+				c.Check(v, check.Equals, cmplx.Conj(vals[j-1]), comment)
+				continue
+			}
+			if !first {
+				c.Check(test.less(prev, v), check.Equals, true, comment)
+			}
+			prev, first = v, false
+		}
+	}
+
+	c.Check(func() { want.Sort(EigenOrder(5)) }, check.PanicMatches, string(ErrEigenOrder))
+}
+
+func (s *S) TestEigenNormalizeSigns(c *check.C) {
+	for _, a := range []*Dense{
+		schurTestMatrix(),
+		NewDense(3, 3, []float64{
+			1, 6, -1,
+			6, -1, -2,
+			-1, -2, -1,
+		}),
+	} {
+		f := Eigen(DenseCopyOf(a), epsilon)
+		f.NormalizeSigns()
+		checkEigenFactors(c, a, f, check.Commentf("normalized"))
+
+		// Flipping the signs and renormalizing recovers the same vectors.
+		g := Eigen(DenseCopyOf(a), epsilon)
+		g.V.Scale(-1, g.V)
+		g.NormalizeSigns()
+		c.Check(g.V.EqualsApprox(f.V, 1e-14), check.Equals, true)
+
+		n, _ := f.V.Dims()
+		for j := 0; j < n; j++ {
+			if f.e[j] > 0 {
+				// The largest component of the complex vector is real
+				// and positive.
+				var bigI int
+				var bigM float64
+				for i := 0; i < n; i++ {
+					if m := math.Hypot(f.V.At(i, j), f.V.At(i, j+1)); m > bigM {
+						bigI, bigM = i, m
+					}
+				}
+				c.Check(f.V.At(bigI, j) > 0, check.Equals, true)
+				c.Check(f.V.At(bigI, j+1), check.Equals, 0.)
+				j++
+				continue
+			}
+			var big float64
+			for i := 0; i < n; i++ {
+				if v := f.V.At(i, j); math.Abs(v) > math.Abs(big) {
+					big = v
+				}
+			}
+			c.Check(big > 0, check.Equals, true)
+		}
+	}
+}
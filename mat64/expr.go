@@ -0,0 +1,274 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"github.com/gonum/blas"
+)
+
+// opDims returns the dimensions of a, or of its transpose if trans is true.
+func opDims(a Matrix, trans bool) (r, c int) {
+	r, c = a.Dims()
+	if trans {
+		return c, r
+	}
+	return r, c
+}
+
+// packOp places rows [lo, hi) of a, or of its transpose if trans is true, in
+// dst in row-major order without padding.
+func packOp(dst []float64, a Matrix, trans bool, lo, hi int) {
+	_, c := opDims(a, trans)
+	if ra, ok := a.(RawMatrixer); ok {
+		amat := ra.RawMatrix()
+		for i := lo; i < hi; i++ {
+			row := dst[(i-lo)*c : (i-lo+1)*c]
+			if !trans {
+				copy(row, amat.Data[i*amat.Stride:i*amat.Stride+c])
+				continue
+			}
+			for j := range row {
+				row[j] = amat.Data[j*amat.Stride+i]
+			}
+		}
+		return
+	}
+	if av, ok := a.(Vectorer); ok && !trans {
+		for i := lo; i < hi; i++ {
+			av.Row(dst[(i-lo)*c:(i-lo+1)*c], i)
+		}
+		return
+	}
+	for i := lo; i < hi; i++ {
+		row := dst[(i-lo)*c : (i-lo+1)*c]
+		for j := range row {
+			if trans {
+				row[j] = a.At(j, i)
+			} else {
+				row[j] = a.At(i, j)
+			}
+		}
+	}
+}
+
+// MulAdd places alpha*op(a)*op(b) + beta*c in the receiver, where op(x) is x,
+// or its transpose if the corresponding trans flag is true. The product is
+// formed in row blocks that are scaled and added to c as they are completed,
+// so neither the transposes nor the unscaled product are held in temporary
+// matrices. If c is nil it is treated as zero.
+//
+// A ColDense operand is used through the row-major storage of its transpose.
+// The receiver may be c, so m.MulAdd(1, a, true, b, false, 1, m) adds the
+// product of a transpose and b to m in place. MulAdd will panic with ErrShape
+// if the dimensions of the operands do not agree, and with ErrOverlap if the
+// receiver shares storage with c other than element for element.
+func (m *Dense) MulAdd(alpha float64, a Matrix, transA bool, b Matrix, transB bool, beta float64, c Matrix) {
+	a, transA = rowMajorOp(a, transA)
+	b, transB = rowMajorOp(b, transB)
+	ar, ac := opDims(a, transA)
+	br, bc := opDims(b, transB)
+	if ac != br {
+		panic(ErrShape)
+	}
+	if c != nil {
+		if cr, cc := c.Dims(); cr != ar || cc != bc {
+			panic(ErrShape)
+		}
+	}
+	if p := startProfile(); p != nil {
+		defer p.stop("MulAdd", 2*ar*ac*bc+3*ar*bc)
+	}
+
+	if m.overlaps(a) || m.overlaps(b) {
+		if m.mat.Rows != ar || m.mat.Cols != bc {
+			panic(ErrShape)
+		}
+		m.checkOverlap(c)
+		w := GetDense(ar, bc, false)
+		w.MulAdd(alpha, a, transA, b, transB, beta, c)
+		m.Copy(w)
+		PutDense(w)
+		return
+	}
+
+	if m.isZero() {
+		m.mat = RawMatrix{
+			Rows:   ar,
+			Cols:   bc,
+			Stride: bc,
+			Data:   use(m.mat.Data, ar*bc),
+		}
+	} else if ar != m.mat.Rows || bc != m.mat.Cols {
+		panic(ErrShape)
+	}
+	if c != nil {
+		m.checkOverlap(c)
+	}
+	if c == nil {
+		beta = 0
+	}
+
+	if blasEngine != nil {
+		if ra, ok := a.(RawMatrixer); ok {
+			if rb, ok := b.(RawMatrixer); ok {
+				m.mulAddBlas(alpha, ra.RawMatrix(), transA, rb.RawMatrix(), transB, beta, c)
+				return
+			}
+		}
+	}
+
+	// Use b directly when it is held in row-major order, otherwise pack
+	// op(b) once for all row blocks.
+	var (
+		bp  []float64
+		ldb int
+	)
+	if rb, ok := b.(RawMatrixer); ok && !transB {
+		bmat := rb.RawMatrix()
+		bp, ldb = bmat.Data, bmat.Stride
+	} else {
+		bp, ldb = make([]float64, br*bc), bc
+		packOp(bp, b, transB, 0, br)
+	}
+
+	bs := mulBlockSize
+	parallelRows(ar, ac*bc, func(lo, hi int) {
+		ap := make([]float64, min(bs, hi-lo)*ac)
+		prod := make([]float64, min(bs, hi-lo)*bc)
+		crow := make([]float64, bc)
+		for i0 := lo; i0 < hi; i0 += bs {
+			i1 := min(i0+bs, hi)
+			packOp(ap, a, transA, i0, i1)
+			gemmBlocked(i1-i0, bc, ac, ap, ac, bp, ldb, prod, bc, bs)
+			for i := i0; i < i1; i++ {
+				dst := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+bc]
+				p := prod[(i-i0)*bc : (i-i0+1)*bc]
+				if beta == 0 {
+					for j, v := range p {
+						dst[j] = alpha * v
+					}
+					continue
+				}
+				packOp(crow, c, false, i, i+1)
+				for j, v := range p {
+					dst[j] = alpha*v + beta*crow[j]
+				}
+			}
+		}
+	})
+}
+
+// mulAddBlas performs MulAdd for operands that provide their backing data
+// using the registered BLAS engine. The receiver must have the correct shape
+// and must not share storage with a or b.
+func (m *Dense) mulAddBlas(alpha float64, amat RawMatrix, transA bool, bmat RawMatrix, transB bool, beta float64, c Matrix) {
+	ar, ac := amat.Rows, amat.Cols
+	if transA {
+		ar, ac = ac, ar
+	}
+	bc := bmat.Cols
+	if transB {
+		bc = bmat.Rows
+	}
+	if beta != 0 {
+		if cd, ok := c.(*Dense); !ok || cd != m {
+			m.Copy(c)
+		}
+	}
+	ta, tb := blas.NoTrans, blas.NoTrans
+	if transA {
+		ta = blas.Trans
+	}
+	if transB {
+		tb = blas.Trans
+	}
+	parallelRows(ar, ac*bc, func(lo, hi int) {
+		// Rows lo to hi of op(a) start at row lo of a, or at
+		// column lo of a if it is transposed.
+		off := lo * amat.Stride
+		if transA {
+			off = lo
+		}
+		blasEngine.Dgemm(
+			ta, tb,
+			hi-lo, bc, ac,
+			alpha,
+			amat.Data[off:], amat.Stride,
+			bmat.Data, bmat.Stride,
+			beta,
+			m.mat.Data[lo*m.mat.Stride:], m.mat.Stride)
+	})
+}
+
+// Product returns the product of the given matrices, evaluated in the order
+// that minimizes the number of scalar multiplications. For a chain such as a
+// tall matrix, a wide matrix and a vector, the product of the last two factors
+// is formed first, which is much cheaper than evaluating from the left. The
+// factors are not modified. Product will panic with ErrShape if no factors are
+// given or the dimensions of adjacent factors do not agree.
+func Product(factors ...Matrix) *Dense {
+	n := len(factors)
+	if n == 0 {
+		panic(ErrShape)
+	}
+
+	// dims[i] and dims[i+1] are the dimensions of factors[i].
+	dims := make([]int, n+1)
+	dims[0], _ = factors[0].Dims()
+	for i, f := range factors {
+		r, c := f.Dims()
+		if r != dims[i] {
+			panic(ErrShape)
+		}
+		dims[i+1] = c
+	}
+	if n == 1 {
+		return DenseCopyOf(factors[0])
+	}
+
+	// cost[i][j] is the least number of scalar multiplications needed to
+	// form the product of factors i to j inclusive, and split[i][j] the
+	// last factor of the left subproduct in the optimal order.
+	cost := make([][]int, n)
+	split := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]int, n)
+		split[i] = make([]int, n)
+	}
+	for l := 1; l < n; l++ {
+		for i := 0; i+l < n; i++ {
+			j := i + l
+			cost[i][j] = -1
+			for k := i; k < j; k++ {
+				c := cost[i][k] + cost[k+1][j] + dims[i]*dims[k+1]*dims[j+1]
+				if cost[i][j] < 0 || c < cost[i][j] {
+					cost[i][j] = c
+					split[i][j] = k
+				}
+			}
+		}
+	}
+
+	var chain func(i, j int) (Matrix, bool)
+	chain = func(i, j int) (p Matrix, temp bool) {
+		if i == j {
+			return factors[i], false
+		}
+		k := split[i][j]
+		a, ta := chain(i, k)
+		b, tb := chain(k+1, j)
+		w := GetDense(dims[i], dims[j+1], false)
+		w.MulAdd(1, a, false, b, false, 0, nil)
+		if ta {
+			PutDense(a.(*Dense))
+		}
+		if tb {
+			PutDense(b.(*Dense))
+		}
+		return w, true
+	}
+	p, _ := chain(0, n-1)
+	return p.(*Dense)
+}
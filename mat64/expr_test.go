@@ -0,0 +1,126 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/rand"
+
+	"github.com/gonum/blas"
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestMulAdd(c *check.C) {
+	// Check both the registered engine and the native kernel.
+	engine := Registered()
+	defer Register(engine)
+	for _, e := range []blas.Float64{engine, nil} {
+		Register(e)
+		testMulAdd(c)
+	}
+}
+
+func testMulAdd(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ m, n, k int }{
+		{1, 1, 1}, {3, 4, 5}, {7, 2, 9}, {70, 65, 130}, {0, 3, 2},
+	} {
+		for _, trans := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+			ta, tb := trans[0], trans[1]
+			ar, ac := test.m, test.k
+			if ta {
+				ar, ac = ac, ar
+			}
+			br, bc := test.k, test.n
+			if tb {
+				br, bc = bc, br
+			}
+			a := NewNormal(ar, ac, 0, 1, rnd)
+			b := NewNormal(br, bc, 0, 1, rnd)
+			cm := NewNormal(test.m, test.n, 0, 1, rnd)
+
+			var opA, opB, want, sum Dense
+			if ta {
+				opA.TCopy(a)
+			} else {
+				opA.Clone(a)
+			}
+			if tb {
+				opB.TCopy(b)
+			} else {
+				opB.Clone(b)
+			}
+			want.Mul(&opA, &opB)
+			want.Scale(2, &want)
+			sum.Scale(-0.5, cm)
+			sum.Add(&want, &sum)
+
+			for _, ops := range []struct{ a, b Matrix }{
+				{a, b},
+				{(*basicMatrix)(a), (*basicMatrix)(b)},
+				{(*basicVectorer)(a), b},
+			} {
+				var got Dense
+				got.MulAdd(2, ops.a, ta, ops.b, tb, -0.5, cm)
+				c.Check(got.EqualsApprox(&sum, 1e-12), check.Equals, true,
+					check.Commentf("%v trans=%v", test, trans))
+
+				got.MulAdd(2, ops.a, ta, ops.b, tb, 0.25, nil)
+				c.Check(got.EqualsApprox(&want, 1e-12), check.Equals, true)
+			}
+
+			// Accumulate into the receiver in place.
+			acc := DenseCopyOf(cm)
+			acc.MulAdd(2, a, ta, b, tb, -0.5, acc)
+			c.Check(acc.EqualsApprox(&sum, 1e-12), check.Equals, true)
+		}
+	}
+
+	// The receiver may be an operand.
+	a := NewNormal(4, 4, 0, 1, rnd)
+	var want Dense
+	want.Mul(a, a)
+	want.Add(&want, a)
+	a.MulAdd(1, a, false, a, false, 1, a)
+	c.Check(a.EqualsApprox(&want, 1e-12), check.Equals, true)
+
+	var m Dense
+	c.Check(func() { m.MulAdd(1, NewDense(2, 3, nil), false, NewDense(2, 3, nil), false, 0, nil) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { m.MulAdd(1, NewDense(2, 3, nil), true, NewDense(2, 3, nil), false, 0, NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+	big := NewDense(3, 3, nil)
+	var top, bottom Dense
+	top.View(big, 0, 0, 2, 2)
+	bottom.View(big, 1, 1, 2, 2)
+	c.Check(func() { top.MulAdd(1, eye(), false, eye(), false, 1, &bottom) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() {
+		top.MulAdd(1, NewDense(2, 2, nil), false, NewDense(2, 2, nil), false, 1, &bottom)
+	}, check.PanicMatches, string(ErrOverlap))
+}
+
+func (s *S) TestProduct(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, dims := range [][]int{
+		{3, 4},
+		{3, 4, 5},
+		{50, 2, 50, 1},
+		{10, 30, 5, 60},
+		{1, 40, 40, 40, 1},
+		{6, 1, 7, 3, 2, 8},
+	} {
+		var factors []Matrix
+		for i := 0; i+1 < len(dims); i++ {
+			factors = append(factors, NewNormal(dims[i], dims[i+1], 0, 1, rnd))
+		}
+		want := DenseCopyOf(factors[0])
+		for _, f := range factors[1:] {
+			want.Mul(want, f)
+		}
+		got := Product(factors...)
+		c.Check(got.EqualsApprox(want, 1e-10), check.Equals, true, check.Commentf("dims %v", dims))
+		c.Check(got != factors[0], check.Equals, true)
+	}
+
+	c.Check(func() { Product() }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { Product(NewDense(2, 3, nil), NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+}
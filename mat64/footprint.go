@@ -0,0 +1,77 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "unsafe"
+
+// floatBytes returns the size in bytes of the backing array of f.
+func floatBytes(f []float64) int64 {
+	return int64(cap(f)) * int64(unsafe.Sizeof(float64(0)))
+}
+
+// intBytes returns the size in bytes of the backing array of f.
+func intBytes(f []int) int64 {
+	return int64(cap(f)) * int64(unsafe.Sizeof(int(0)))
+}
+
+// denseBytes returns the Sizeof of m, or zero if m is nil.
+func denseBytes(m *Dense) int64 {
+	if m == nil {
+		return 0
+	}
+	return m.Sizeof()
+}
+
+// Capacity returns the number of elements the backing data of the receiver can
+// hold, which may exceed the number of elements of the matrix when it is a view
+// or has been reused for a smaller result.
+func (m *Dense) Capacity() int { return cap(m.mat.Data) }
+
+// Sizeof returns the memory held by the receiver in bytes, the size of the
+// matrix header and of the backing data to its capacity. The backing data of a
+// view is shared with the matrix it views and is counted by each.
+func (m *Dense) Sizeof() int64 {
+	return int64(unsafe.Sizeof(*m)) + floatBytes(m.mat.Data)
+}
+
+// Sizeof returns the memory held by the factors in bytes.
+func (f LUFactors) Sizeof() int64 {
+	return int64(unsafe.Sizeof(f)) + denseBytes(f.LU) + intBytes(f.Pivot)
+}
+
+// Sizeof returns the memory held by the factors in bytes.
+func (f QRFactor) Sizeof() int64 {
+	return int64(unsafe.Sizeof(f)) + denseBytes(f.QR) + floatBytes(f.rDiag)
+}
+
+// Sizeof returns the memory held by the factors in bytes.
+func (f LQFactor) Sizeof() int64 {
+	return int64(unsafe.Sizeof(f)) + denseBytes(f.LQ) + floatBytes(f.lDiag)
+}
+
+// Sizeof returns the memory held by the factor in bytes.
+func (f CholeskyFactor) Sizeof() int64 {
+	return int64(unsafe.Sizeof(f)) + denseBytes(f.L)
+}
+
+// Sizeof returns the memory held by the factors in bytes.
+func (f LDLFactors) Sizeof() int64 {
+	return int64(unsafe.Sizeof(f)) + denseBytes(f.L) + denseBytes(f.D) + intBytes(f.Pivot)
+}
+
+// Sizeof returns the memory held by the factors in bytes, including the
+// storage retained by Factorize for reuse.
+func (f EigenFactors) Sizeof() int64 {
+	n := int64(unsafe.Sizeof(f)) + denseBytes(f.V) + floatBytes(f.d) + floatBytes(f.e)
+	if f.vWork != f.V {
+		n += denseBytes(f.vWork)
+	}
+	return n + floatBytes(f.ort) + floatBytes(f.rot)
+}
+
+// Sizeof returns the memory held by the factors in bytes.
+func (f SVDFactors) Sizeof() int64 {
+	return int64(unsafe.Sizeof(f)) + denseBytes(f.U) + floatBytes(f.Sigma) + denseBytes(f.V)
+}
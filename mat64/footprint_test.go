@@ -0,0 +1,68 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/rand"
+	"unsafe"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSizeof(c *check.C) {
+	header := int64(unsafe.Sizeof(Dense{}))
+	m := NewDense(3, 4, nil)
+	c.Check(m.Capacity(), check.Equals, 12)
+	c.Check(m.Sizeof(), check.Equals, header+12*8)
+
+	// A view counts the storage it shares.
+	var v Dense
+	v.View(m, 1, 1, 2, 2)
+	c.Check(v.Capacity(), check.Equals, 12-5)
+	c.Check(v.Sizeof(), check.Equals, header+7*8)
+	var z Dense
+	c.Check(z.Sizeof(), check.Equals, header)
+
+	rnd := rand.New(rand.NewSource(1))
+	a := NewNormal(5, 5, 0, 1, rnd)
+	lu := LU(DenseCopyOf(a))
+	c.Check(lu.Sizeof() >= header+25*8+5*int64(unsafe.Sizeof(int(0))), check.Equals, true)
+	qr := QR(DenseCopyOf(a))
+	c.Check(qr.Sizeof() >= header+30*8, check.Equals, true)
+
+	spd := NewSPD([]float64{1, 2, 3, 4, 5}, rnd)
+	c.Check(Cholesky(spd).Sizeof() >= header+25*8, check.Equals, true)
+	c.Check(CholeskyFactor{}.Sizeof(), check.Equals, int64(unsafe.Sizeof(CholeskyFactor{})))
+
+	sym := Eigen(DenseCopyOf(spd), epsilon)
+	gen := Eigen(DenseCopyOf(a), epsilon)
+	c.Check(sym.Sizeof() >= header+25*8+10*8, check.Equals, true)
+	// The non-symmetric decomposition retains a Hessenberg workspace.
+	c.Check(gen.Sizeof() > sym.Sizeof(), check.Equals, true)
+
+	svd := SVD(DenseCopyOf(a), epsilon, small, true, true)
+	noVec := SVD(DenseCopyOf(a), epsilon, small, false, false)
+	c.Check(svd.Sizeof()-noVec.Sizeof() >= 2*25*8, check.Equals, true)
+}
+
+func (s *S) TestPoolStats(c *check.C) {
+	before := ReadPoolStats()
+	f := GetFloats(100, false)
+	PutFloats(f)
+	mid := ReadPoolStats()
+	c.Check(mid.Gets-before.Gets, check.Equals, int64(1))
+	c.Check(mid.Puts-before.Puts, check.Equals, int64(1))
+	// A slice taken from the pool and returned leaves the pool unchanged.
+	hits := mid.Hits - before.Hits
+	c.Check(mid.Bytes-before.Bytes, check.Equals, (1-hits)*int64(cap(f)*8))
+
+	d := GetDense(10, 10, true)
+	after := ReadPoolStats()
+	c.Check(after.Gets-mid.Gets, check.Equals, int64(1))
+	if after.Hits > mid.Hits {
+		c.Check(after.Bytes < mid.Bytes, check.Equals, true)
+	}
+	PutDense(d)
+}
@@ -151,3 +151,35 @@ func maxCellWidth(m Matrix, c rune, printed, prec int) ([]byte, int) {
 	}
 	return buf, max
 }
+
+// Format satisfies the fmt.Formatter interface, printing the matrix as described
+// for the Format function with no margin and with zero values represented by
+// the dot character when the '#' flag is used. The '#' flag with the 'v' verb
+// formats the matrix with Go syntax representation. Large matrices can be
+// printed in part using Excerpt.
+func (m *Dense) Format(fs fmt.State, c rune) {
+	if c == 'v' && fs.Flag('#') {
+		fmt.Fprintf(fs, "&mat64.Dense{mat:%#v}", m.mat)
+		return
+	}
+	Format(m, 0, '.', fs, c)
+}
+
+// Excerpt returns a fmt.Formatter that prints only the first and last margin
+// rows and columns of m, as described for the Format function.
+func Excerpt(m Matrix, margin int) fmt.Formatter {
+	return excerpt{m: m, margin: margin}
+}
+
+type excerpt struct {
+	m      Matrix
+	margin int
+}
+
+func (e excerpt) Format(fs fmt.State, c rune) {
+	if c == 'v' && fs.Flag('#') {
+		fmt.Fprintf(fs, "%#v", e.m)
+		return
+	}
+	Format(e.m, e.margin, '.', fs, c)
+}
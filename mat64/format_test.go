@@ -137,3 +137,26 @@ func (s *S) TestFormat(c *check.C) {
 		}
 	}
 }
+
+func (s *S) TestDenseFormat(c *check.C) {
+	m := NewDense(2, 3, []float64{1, 0, 2.5, -3, 4, 0})
+	for _, test := range []struct {
+		format string
+		output string
+	}{
+		{"%v", "⎡  1    0  2.5⎤\n⎣ -3    4    0⎦"},
+		{"%.2f", "⎡ 1.00   0.00   2.50⎤\n⎣-3.00   4.00   0.00⎦"},
+		{"%#g", "⎡  1    .  2.5⎤\n⎣ -3    4    .⎦"},
+		{"%#v", "&mat64.Dense{mat:mat64.RawMatrix{Rows:2, Cols:3, Stride:3, Data:[]float64{1, 0, 2.5, -3, 4, 0}}}"},
+		{"%d", "%!d(*mat64.Dense=Dims(2, 3))"},
+	} {
+		c.Check(fmt.Sprintf(test.format, m), check.Equals, test.output, check.Commentf("Format %q", test.format))
+	}
+
+	// Formatting is used for matrices held in other values.
+	c.Check(fmt.Sprint([]*Dense{NewDense(1, 2, []float64{1, 2})}), check.Equals, "[[1  2]]")
+
+	big := NewDense(1, 10, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	c.Check(fmt.Sprintf("%v", Excerpt(big, 3)), check.Equals, "Dims(1, 10)\n[ 1   2   3  ...  ...   8   9  10]")
+	c.Check(fmt.Sprintf("%#v", Excerpt(big, 3)), check.Equals, fmt.Sprintf("%#v", big))
+}
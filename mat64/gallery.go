@@ -0,0 +1,110 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// The functions in this file construct classic structured test matrices with
+// known properties, providing standard hard cases for testing and benchmarking
+// the decompositions. Vandermonde matrices are constructed by Vandermonde.
+
+// Hilbert returns the n-by-n Hilbert matrix with elements 1/(i+j+1). It is
+// symmetric positive definite and notoriously ill conditioned, with condition
+// number growing like exp(3.5n).
+func Hilbert(n int) *Dense {
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		row := m.rowView(i)
+		for j := range row {
+			row[j] = 1 / float64(i+j+1)
+		}
+	}
+	return m
+}
+
+// Pascal returns the n-by-n symmetric Pascal matrix with elements binomial(i+j, i).
+// It is symmetric positive definite with determinant one and eigenvalues in
+// reciprocal pairs, and its integer elements are exact in floating point for n
+// up to about 30.
+func Pascal(n int) *Dense {
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		row := m.rowView(i)
+		for j := range row {
+			if i == 0 || j == 0 {
+				row[j] = 1
+				continue
+			}
+			row[j] = m.at(i-1, j) + row[j-1]
+		}
+	}
+	return m
+}
+
+// Frank returns the n-by-n Frank matrix, the upper Hessenberg matrix with
+// elements n-max(i, j) for j >= i-1 and zero elsewhere. It has determinant one
+// and real positive eigenvalues, the smallest of which are very ill conditioned.
+func Frank(n int) *Dense {
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		row := m.rowView(i)
+		for j := max(i-1, 0); j < n; j++ {
+			row[j] = float64(n - max(i, j))
+		}
+	}
+	return m
+}
+
+// Companion returns the n-by-n companion matrix of the polynomial
+// c[0] + c[1]x + ... + c[n]x^n, with coefficients in the order used by PolyFit.
+// The eigenvalues of the matrix are the roots of the polynomial. Companion will
+// panic with ErrShape if the polynomial has degree less than one or c[n] is
+// zero.
+func Companion(c []float64) *Dense {
+	n := len(c) - 1
+	if n < 1 || c[n] == 0 {
+		panic(ErrShape)
+	}
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			m.Set(i, i-1, 1)
+		}
+		m.Set(i, n-1, -c[i]/c[n])
+	}
+	return m
+}
+
+// ToeplitzDense returns the len(c)-by-len(r) Toeplitz matrix with first column c
+// and first row r, which is constant along each diagonal. The diagonal is taken
+// from c[0] and r[0] is not used.
+func ToeplitzDense(c, r []float64) *Dense {
+	m := NewDense(len(c), len(r), nil)
+	for i := range c {
+		row := m.rowView(i)
+		for j := range row {
+			if i >= j {
+				row[j] = c[i-j]
+			} else {
+				row[j] = r[j-i]
+			}
+		}
+	}
+	return m
+}
+
+// CirculantDense returns the n-by-n circulant matrix with first column c, where
+// n = len(c), in which each column is the previous one rotated down by one
+// element. Its eigenvectors are the Fourier modes and its eigenvalues the
+// discrete Fourier transform of c.
+func CirculantDense(c []float64) *Dense {
+	n := len(c)
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		row := m.rowView(i)
+		for j := range row {
+			row[j] = c[(i-j+n)%n]
+		}
+	}
+	return m
+}
@@ -0,0 +1,94 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestGallery(c *check.C) {
+	h := Hilbert(4)
+	c.Check(h.Equals(NewDense(4, 4, []float64{
+		1, 1. / 2, 1. / 3, 1. / 4,
+		1. / 2, 1. / 3, 1. / 4, 1. / 5,
+		1. / 3, 1. / 4, 1. / 5, 1. / 6,
+		1. / 4, 1. / 5, 1. / 6, 1. / 7,
+	})), check.Equals, true)
+	c.Check(Cholesky(Hilbert(8)).SPD, check.Equals, true)
+
+	p := Pascal(5)
+	c.Check(p.Equals(NewDense(5, 5, []float64{
+		1, 1, 1, 1, 1,
+		1, 2, 3, 4, 5,
+		1, 3, 6, 10, 15,
+		1, 4, 10, 20, 35,
+		1, 5, 15, 35, 70,
+	})), check.Equals, true)
+	// The Cholesky factor of the Pascal matrix is the lower triangular
+	// Pascal matrix of binomial coefficients.
+	l := Cholesky(Pascal(6)).L
+	for i := 0; i < 6; i++ {
+		binom := 1.0
+		for j := 0; j <= i; j++ {
+			c.Check(math.Abs(l.At(i, j)-binom) < 1e-12, check.Equals, true)
+			binom = binom * float64(i-j) / float64(j+1)
+		}
+	}
+
+	c.Check(Frank(4).Equals(NewDense(4, 4, []float64{
+		4, 3, 2, 1,
+		3, 3, 2, 1,
+		0, 2, 2, 1,
+		0, 0, 1, 1,
+	})), check.Equals, true)
+	for _, n := range []int{1, 3, 6} {
+		c.Check(math.Abs(Det(Frank(n))-1) < 1e-10, check.Equals, true)
+	}
+
+	// (x-1)(x-2)(x-3) = x³ - 6x² + 11x - 6.
+	comp := Companion([]float64{-12, 22, -12, 2})
+	c.Check(comp.Equals(NewDense(3, 3, []float64{
+		0, 0, 6,
+		1, 0, -11,
+		0, 1, 6,
+	})), check.Equals, true)
+	var roots []float64
+	for _, v := range Eigen(comp, epsilon).Values() {
+		c.Check(math.Abs(imag(v)) < 1e-10, check.Equals, true)
+		roots = append(roots, real(v))
+	}
+	for _, want := range []float64{1, 2, 3} {
+		found := false
+		for _, r := range roots {
+			found = found || math.Abs(r-want) < 1e-8
+		}
+		c.Check(found, check.Equals, true, check.Commentf("roots %v", roots))
+	}
+	c.Check(func() { Companion([]float64{1}) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { Companion([]float64{1, 2, 0}) }, check.PanicMatches, string(ErrShape))
+
+	c.Check(ToeplitzDense([]float64{1, 2, 3}, []float64{9, 4, 5, 6}).Equals(NewDense(3, 4, []float64{
+		1, 4, 5, 6,
+		2, 1, 4, 5,
+		3, 2, 1, 4,
+	})), check.Equals, true)
+	r, cols := ToeplitzDense(nil, []float64{1, 2}).Dims()
+	c.Check(r == 0 && cols == 2, check.Equals, true)
+
+	circ := CirculantDense([]float64{4, 1, 0, 1})
+	c.Check(circ.Equals(NewDense(4, 4, []float64{
+		4, 1, 0, 1,
+		1, 4, 1, 0,
+		0, 1, 4, 1,
+		1, 0, 1, 4,
+	})), check.Equals, true)
+	// The eigenvalues are the DFT of the first column, 4 + 2cos(2πk/4).
+	got := symEigenvalues(circ)
+	for i, want := range []float64{2, 4, 4, 6} {
+		c.Check(math.Abs(got[i]-want) < 1e-12, check.Equals, true)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// ErrBlockSize is the panic value used by SetMulBlockSize when the block size
+// is not positive.
+const ErrBlockSize = Error("mat64: block size must be positive")
+
+// mulBlockSize is the tile size used by the native multiplication kernel.
+var mulBlockSize = 64
+
+// SetMulBlockSize sets the tile size used by the native multiplication kernel
+// and returns the previous setting. The kernel is used by Dense.Mul when an
+// operand does not provide its backing data. Tiles of b of size n-by-n are
+// packed into contiguous storage and should fit comfortably in the processor's
+// cache. SetMulBlockSize will panic with ErrBlockSize if n is less than 1.
+//
+// SetMulBlockSize must not be called concurrently with operations on matrices.
+func SetMulBlockSize(n int) int {
+	if n < 1 {
+		panic(ErrBlockSize)
+	}
+	prev := mulBlockSize
+	mulBlockSize = n
+	return prev
+}
+
+// gemmBlocked computes the m-by-n product c = a.b of the m-by-k matrix a and
+// the k-by-n matrix b held in row-major order with the given strides. The
+// product is accumulated over tiles of b of size bs-by-bs, each packed into
+// contiguous storage so that it stays in cache while it is applied to all rows
+// of a.
+func gemmBlocked(m, n, k int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, bs int) {
+	for i := 0; i < m; i++ {
+		zero(c[i*ldc : i*ldc+n])
+	}
+	pack := make([]float64, bs*bs)
+	for jj := 0; jj < n; jj += bs {
+		nb := min(bs, n-jj)
+		for kk := 0; kk < k; kk += bs {
+			kb := min(bs, k-kk)
+
+			// Pack the tile of b.
+			for l := 0; l < kb; l++ {
+				copy(pack[l*nb:(l+1)*nb], b[(kk+l)*ldb+jj:])
+			}
+
+			for i := 0; i < m; i++ {
+				ci := c[i*ldc+jj : i*ldc+jj+nb]
+				for l, av := range a[i*lda+kk : i*lda+kk+kb] {
+					for j, bv := range pack[l*nb : (l+1)*nb] {
+						ci[j] += av * bv
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestGemmBlocked(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ m, n, k, bs int }{
+		{1, 1, 1, 1},
+		{5, 7, 3, 2},
+		{17, 9, 33, 4},
+		{40, 65, 70, 64},
+		{3, 130, 129, 64},
+		{0, 4, 5, 8},
+	} {
+		lda, ldb, ldc := test.k+1, test.n+2, test.n+3
+		a := make([]float64, test.m*lda)
+		b := make([]float64, test.k*ldb)
+		for _, s := range [][]float64{a, b} {
+			for i := range s {
+				s[i] = rnd.NormFloat64()
+			}
+		}
+		got := make([]float64, test.m*ldc)
+		for i := range got {
+			got[i] = math.NaN()
+		}
+		gemmBlocked(test.m, test.n, test.k, a, lda, b, ldb, got, ldc, test.bs)
+
+		ok := true
+		for i := 0; i < test.m; i++ {
+			for j := 0; j < test.n; j++ {
+				var want float64
+				for l := 0; l < test.k; l++ {
+					want += a[i*lda+l] * b[l*ldb+j]
+				}
+				if math.Abs(got[i*ldc+j]-want) > 1e-12 {
+					ok = false
+				}
+			}
+		}
+		c.Check(ok, check.Equals, true, check.Commentf("%+v", test))
+	}
+}
+
+func (s *S) TestMulBlockSize(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(23, 31, nil)
+	b := NewDense(31, 19, nil)
+	for _, m := range []*Dense{a, b} {
+		for i := range m.mat.Data {
+			m.mat.Data[i] = rnd.NormFloat64()
+		}
+	}
+	var want Dense
+	want.Mul(a, b)
+
+	defer SetMulBlockSize(SetMulBlockSize(64))
+	for _, bs := range []int{1, 5, 16, 100} {
+		c.Check(SetMulBlockSize(bs) > 0, check.Equals, true)
+		var got Dense
+		got.Mul((*basicMatrix)(a), (*basicVectorer)(b))
+		c.Check(got.EqualsApprox(&want, 1e-12), check.Equals, true, check.Commentf("block size %d", bs))
+	}
+
+	// The native kernel is used when no engine is registered.
+	engine := Registered()
+	Register(nil)
+	var got Dense
+	got.Mul(a, b)
+	Register(engine)
+	c.Check(got.EqualsApprox(&want, 1e-12), check.Equals, true)
+
+	c.Check(func() { SetMulBlockSize(0) }, check.PanicMatches, string(ErrBlockSize))
+	c.Check(Maybe(func() { SetMulBlockSize(-1) }), check.Equals, ErrBlockSize)
+}
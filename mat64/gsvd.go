@@ -0,0 +1,116 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// GSVDFactors holds the generalized singular value decomposition of a matrix
+// pair (a, b), with a m-by-n and b p-by-n, such that
+//
+//  a = U.diag(C).X'
+//  b = V.diag(S).X'
+//
+// where X is n-by-n and nonsingular, the n cosines C and sines S satisfy
+// C[i]² + S[i]² = 1 with C in non-increasing order, and the columns of the
+// m-by-n U and p-by-n V corresponding to non-zero C and S respectively are
+// orthonormal. The columns of U and V corresponding to zero C and S are zero.
+type GSVDFactors struct {
+	U, V *Dense
+	X    *Dense
+	C, S []float64
+}
+
+// GSVD returns the generalized singular value decomposition of the pair (a, b),
+// which have the same number of columns. The decomposition simultaneously
+// diagonalizes a'.a and b'.b, and underlies Tikhonov regularization in general
+// form, min |a.x - y|² + λ²|b.x|², and canonical correlation analysis. The
+// matrices a and b are not modified.
+//
+// The decomposition is computed from the QR factorization [a; b] = Q.R, with Q
+// partitioned conformally as [Q1; Q2], and the singular value decomposition of
+// Q1 = U.diag(C).Z', giving Q2.Z = V.diag(S) and X = R'.Z, as in Golub and Van
+// Loan, "Matrix Computations", section 8.7.
+//
+// GSVD will panic with ErrShape if a and b do not have the same number of
+// columns, and with ErrRankDeficient if [a; b] does not have full column rank.
+func GSVD(a, b Matrix) GSVDFactors {
+	m, n := a.Dims()
+	p, bn := b.Dims()
+	if bn != n || m+p < n {
+		panic(ErrShape)
+	}
+
+	stacked := NewDense(m+p, n, nil)
+	stacked.Copy(a)
+	var lower Dense
+	lower.View(stacked, m, 0, p, n)
+	lower.Copy(b)
+	qr := QR(stacked)
+	if !qr.IsFullRank() {
+		panic(ErrRankDeficient)
+	}
+	q, r := qr.Q(), qr.R()
+	var q1, q2 Dense
+	q1.View(q, 0, 0, m, n)
+	q2.View(q, m, 0, p, n)
+
+	// The right singular vectors Z of Q1, padded with zero rows when
+	// it is wide so that Z is square.
+	padded := NewDense(max(m, n), n, nil)
+	padded.Copy(&q1)
+	z := SVD(padded, epsilon, small, false, true).V
+
+	f := GSVDFactors{
+		U: NewDense(m, n, nil),
+		V: NewDense(p, n, nil),
+	}
+	f.U.Mul(&q1, z)
+	f.V.Mul(&q2, z)
+	f.C = normalizeCols(f.U)
+	f.S = normalizeCols(f.V)
+
+	var rt Dense
+	rt.TCopy(r)
+	f.X = NewDense(n, n, nil)
+	f.X.Mul(&rt, z)
+	return f
+}
+
+// normalizeCols scales the non-zero columns of m to unit two norm, returning
+// the original norms.
+func normalizeCols(m *Dense) []float64 {
+	r, c := m.Dims()
+	norms := make([]float64, c)
+	for i := 0; i < r; i++ {
+		for j, v := range m.rowView(i) {
+			norms[j] = math.Hypot(norms[j], v)
+		}
+	}
+	for i := 0; i < r; i++ {
+		row := m.rowView(i)
+		for j, nj := range norms {
+			if nj != 0 {
+				row[j] /= nj
+			}
+		}
+	}
+	return norms
+}
+
+// Values returns the generalized singular values C[i]/S[i] of the pair, in
+// non-increasing order. A value is +Inf where S[i] is zero.
+func (f GSVDFactors) Values() []float64 {
+	v := make([]float64, len(f.C))
+	for i, c := range f.C {
+		if f.S[i] == 0 {
+			v[i] = math.Inf(1)
+			continue
+		}
+		v[i] = c / f.S[i]
+	}
+	return v
+}
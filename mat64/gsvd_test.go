@@ -0,0 +1,80 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+func (s *S) TestGSVD(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	randDense := func(r, c int) *Dense {
+		m := NewDense(r, c, nil)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				m.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		return m
+	}
+
+	for _, dims := range [][3]int{{6, 4, 5}, {3, 4, 4}, {4, 4, 2}, {5, 3, 3}} {
+		m, n, p := dims[0], dims[1], dims[2]
+		a, b := randDense(m, n), randDense(p, n)
+		origA, origB := DenseCopyOf(a), DenseCopyOf(b)
+		f := GSVD(a, b)
+		comment := check.Commentf("m=%d n=%d p=%d", m, n, p)
+		c.Check(a.Equals(origA) && b.Equals(origB), check.Equals, true, comment)
+
+		for i := range f.C {
+			c.Check(math.Abs(f.C[i]*f.C[i]+f.S[i]*f.S[i]-1) < 1e-12, check.Equals, true, comment)
+			if i > 0 {
+				c.Check(f.C[i] <= f.C[i-1]+1e-14, check.Equals, true, comment)
+			}
+		}
+
+		// a = U.diag(C).X' and b = V.diag(S).X'
+		var xt, uc, vs, got Dense
+		xt.TCopy(f.X)
+		uc.Mul(f.U, diag(f.C))
+		got.Mul(&uc, &xt)
+		c.Check(got.EqualsApprox(a, 1e-10), check.Equals, true, comment)
+		vs.Mul(f.V, diag(f.S))
+		got.Reset()
+		got.Mul(&vs, &xt)
+		c.Check(got.EqualsApprox(b, 1e-10), check.Equals, true, comment)
+
+		// With b of full column rank, the squared generalized singular
+		// values are the eigenvalues of (b'.b)^-1.a'.a.
+		if p >= n {
+			var at, bt, ata, btb Dense
+			at.TCopy(a)
+			bt.TCopy(b)
+			ata.Mul(&at, a)
+			btb.Mul(&bt, b)
+			ef := Eigen(Solve(&btb, &ata), epsilon)
+			want := append([]float64(nil), ef.d...)
+			sort.Sort(sort.Reverse(sort.Float64Slice(want)))
+			for i, v := range f.Values() {
+				c.Check(math.Abs(v*v-want[i]) < 1e-8*math.Max(1, want[i]), check.Equals, true, comment)
+			}
+		}
+	}
+
+	c.Check(func() { GSVD(NewDense(2, 2, nil), NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { GSVD(NewDense(2, 2, []float64{1, 0, 1, 0}), NewDense(1, 2, []float64{1, 0})) }, check.PanicMatches, string(ErrRankDeficient))
+}
+
+// diag returns the square diagonal matrix with diagonal d.
+func diag(d []float64) *Dense {
+	m := NewDense(len(d), len(d), nil)
+	for i, v := range d {
+		m.Set(i, i, v)
+	}
+	return m
+}
@@ -0,0 +1,157 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// Hankel returns the Hankel matrix with the given number of rows formed from the
+// sequence s, so that element (i, j) of the result is s[i+j]. The result has
+// len(s)-rows+1 columns. Hankel will panic with ErrShape if rows is not in
+// [1, len(s)].
+func Hankel(s Vec, rows int) *Dense {
+	if rows < 1 || rows > len(s) {
+		panic(ErrShape)
+	}
+	cols := len(s) - rows + 1
+	h := NewDense(rows, cols, nil)
+	for i := 0; i < rows; i++ {
+		copy(h.rowView(i), s[i:i+cols])
+	}
+	return h
+}
+
+// BlockHankel returns the block Hankel matrix with the given number of block
+// rows formed from the multivariate time series held in the rows of y, so that
+// block (i, j) of the result is the column vector y[i+j]'. For an n-by-p y the
+// result is (blockRows*p)-by-(n-blockRows+1). BlockHankel will panic with
+// ErrShape if blockRows is not in [1, n].
+func BlockHankel(y Matrix, blockRows int) *Dense {
+	n, _ := y.Dims()
+	if blockRows < 1 || blockRows > n {
+		panic(ErrShape)
+	}
+	return blockHankel(y, 0, blockRows, n-blockRows+1)
+}
+
+// blockHankel returns the block Hankel matrix of y with the given number of block
+// rows and columns starting from row start of y.
+func blockHankel(y Matrix, start, blockRows, cols int) *Dense {
+	n, p := y.Dims()
+	if start < 0 || start+blockRows+cols-1 > n {
+		panic(ErrShape)
+	}
+	h := NewDense(blockRows*p, cols, nil)
+	for i := 0; i < blockRows; i++ {
+		for k := 0; k < p; k++ {
+			row := h.rowView(i*p + k)
+			for j := range row {
+				row[j] = y.At(start+i+j, k)
+			}
+		}
+	}
+	return h
+}
+
+// SubspaceModel holds a discrete-time state-space model
+//
+//  x[k+1] = A.x[k] + B.u[k]
+//  y[k]   = C.x[k] + D.u[k]
+//
+// identified from input and output data, and the singular values of the
+// projected data used to choose the model order.
+type SubspaceModel struct {
+	A, B, C, D *Dense
+	Sigma      []float64
+}
+
+// N4SID identifies a state-space model of the given order from the input
+// sequence held in the rows of u and the corresponding output sequence held in
+// the rows of y, using a basic form of the subspace identification method of Van
+// Overschee and De Moor. The horizon is the number of block rows of the past and
+// future data Hankel matrices and must exceed the order; the data length must
+// allow more columns than rows in the stacked Hankel matrices. The returned
+// model is expressed in an arbitrary state basis and can be used with Simulate.
+//
+// The future outputs are obliquely projected along the future inputs onto the
+// past inputs and outputs, the state sequence is estimated from the dominant
+// singular subspace of the projection, and the system matrices are then found by
+// least squares. N4SID will panic with ErrShape if the data are inconsistent or
+// too short.
+func N4SID(u, y *Dense, order, horizon int) SubspaceModel {
+	n, m := u.Dims()
+	ny, p := y.Dims()
+	if n != ny || order < 1 || horizon <= order {
+		panic(ErrShape)
+	}
+	i := horizon
+	j := n - 2*i + 1
+	if j <= (2*m+p)*i {
+		panic(ErrShape)
+	}
+
+	up := blockHankel(u, 0, i, j)
+	uf := blockHankel(u, i, i, j)
+	yp := blockHankel(y, 0, i, j)
+	yf := blockHankel(y, i, i, j)
+
+	// Regress the future outputs on the future inputs and the past data
+	// and keep the part explained by the past data.
+	var upf, z Dense
+	upf.Stack(uf, up)
+	z.Stack(&upf, yp)
+	var zt, yft, l Dense
+	zt.TCopy(&z)
+	yft.TCopy(yf)
+	l.TCopy(RidgePath(&zt, &yft, []float64{0})[0])
+	var lw, wp, o Dense
+	lw.View(&l, 0, m*i, p*i, (m+p)*i)
+	wp.View(&z, m*i, 0, (m+p)*i, j)
+	o.Mul(&lw, &wp)
+
+	// Estimate the state sequence from the dominant singular subspace.
+	svd := SVD(DenseCopyOf(&o), epsilon, small, false, true)
+	if order > len(svd.Sigma) {
+		panic(ErrShape)
+	}
+	x := NewDense(order, j, nil)
+	for r := 0; r < order; r++ {
+		s := svd.Sigma[r]
+		row := x.rowView(r)
+		for c := range row {
+			row[c] = s * svd.V.At(c, r)
+		}
+	}
+
+	// Solve [x[k+1]; y[k]] = [A B; C D].[x[k]; u[k]] in the least
+	// squares sense over the estimated states.
+	phi := NewDense(j-1, order+m, nil)
+	psi := NewDense(j-1, order+p, nil)
+	for k := 0; k < j-1; k++ {
+		for r := 0; r < order; r++ {
+			phi.Set(k, r, x.At(r, k))
+			psi.Set(k, r, x.At(r, k+1))
+		}
+		for r := 0; r < m; r++ {
+			phi.Set(k, order+r, u.At(i+k, r))
+		}
+		for r := 0; r < p; r++ {
+			psi.Set(k, order+r, y.At(i+k, r))
+		}
+	}
+	theta := RidgePath(phi, psi, []float64{0})[0]
+
+	model := SubspaceModel{
+		A:     &Dense{},
+		B:     &Dense{},
+		C:     &Dense{},
+		D:     &Dense{},
+		Sigma: svd.Sigma,
+	}
+	var tt Dense
+	tt.TCopy(theta)
+	model.A.Submatrix(&tt, 0, 0, order, order)
+	model.B.Submatrix(&tt, 0, order, order, m)
+	model.C.Submatrix(&tt, order, 0, p, order)
+	model.D.Submatrix(&tt, order, order, p, m)
+	return model
+}
@@ -0,0 +1,93 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sort"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestHankel(c *check.C) {
+	h := Hankel(Vec{1, 2, 3, 4, 5}, 2)
+	c.Check(h.Equals(NewDense(2, 4, []float64{
+		1, 2, 3, 4,
+		2, 3, 4, 5,
+	})), check.Equals, true)
+	c.Check(func() { Hankel(Vec{1, 2}, 3) }, check.PanicMatches, string(ErrShape))
+
+	y := NewDense(4, 2, []float64{
+		1, 10,
+		2, 20,
+		3, 30,
+		4, 40,
+	})
+	h = BlockHankel(y, 2)
+	c.Check(h.Equals(NewDense(4, 3, []float64{
+		1, 2, 3,
+		10, 20, 30,
+		2, 3, 4,
+		20, 30, 40,
+	})), check.Equals, true)
+	c.Check(func() { BlockHankel(y, 0) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestN4SID(c *check.C) {
+	a := NewDense(2, 2, []float64{
+		0.7, 0.2,
+		-0.3, 0.5,
+	})
+	b := NewDense(2, 1, []float64{1, 0.5})
+	cm := NewDense(1, 2, []float64{1, -1})
+	d := NewDense(1, 1, []float64{0.1})
+
+	rnd := rand.New(rand.NewSource(1))
+	u := NewDense(200, 1, nil)
+	for i := 0; i < 200; i++ {
+		u.Set(i, 0, rnd.NormFloat64())
+	}
+	y, _ := Simulate(a, b, cm, d, u, Vec{0.5, -1})
+
+	model := N4SID(u, y, 2, 4)
+	c.Check(model.Sigma[2] < 1e-8*model.Sigma[0], check.Equals, true, check.Commentf("singular values %v", model.Sigma))
+
+	// The Markov parameters D, CB, CAB, ... are invariant to the state basis.
+	markov := func(a, b, cm, d *Dense) []float64 {
+		p := []float64{d.At(0, 0)}
+		x := DenseCopyOf(b)
+		for k := 0; k < 4; k++ {
+			var v Dense
+			v.Mul(cm, x)
+			p = append(p, v.At(0, 0))
+			x.Mul(a, x)
+		}
+		return p
+	}
+	want := markov(a, b, cm, d)
+	got := markov(model.A, model.B, model.C, model.D)
+	for k := range want {
+		c.Check(math.Abs(got[k]-want[k]) < 1e-8, check.Equals, true, check.Commentf("Markov parameter %d: got %v want %v", k, got[k], want[k]))
+	}
+
+	ev := func(a *Dense) []float64 {
+		var abs []float64
+		for _, v := range Eigen(DenseCopyOf(a), epsilon).Values() {
+			abs = append(abs, real(v), math.Abs(imag(v)), cmplx.Abs(v))
+		}
+		return abs
+	}
+	we, ge := ev(a), ev(model.A)
+	sort.Float64s(we)
+	sort.Float64s(ge)
+	for k := range we {
+		c.Check(math.Abs(we[k]-ge[k]) < 1e-8, check.Equals, true)
+	}
+
+	c.Check(func() { N4SID(u, y, 2, 2) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { N4SID(u, y, 2, 60) }, check.PanicMatches, string(ErrShape))
+}
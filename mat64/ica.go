@@ -0,0 +1,307 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"context"
+	"math"
+)
+
+// ICANonlinearity specifies the contrast function used by FastICA.
+type ICANonlinearity int
+
+const (
+	// LogCosh uses G(u) = log(cosh(u)), a good general purpose choice.
+	LogCosh ICANonlinearity = iota
+
+	// Exp uses G(u) = -exp(-u²/2), which is more robust when the sources
+	// are highly super-Gaussian.
+	Exp
+
+	// Cube uses G(u) = u⁴/4, which corresponds to kurtosis maximization.
+	Cube
+)
+
+// ErrNonlinearity is the panic value used by FastICA for an invalid
+// ICANonlinearity.
+const ErrNonlinearity = Error("mat64: invalid ICA nonlinearity")
+
+// ICAOptions holds the parameters for FastICA.
+type ICAOptions struct {
+	// Symmetric specifies that all components are estimated together with
+	// symmetric orthogonalization. Otherwise they are estimated one at a
+	// time with deflation.
+	Symmetric bool
+
+	// Nonlinearity is the contrast function.
+	Nonlinearity ICANonlinearity
+
+	// Tol is the convergence tolerance on the change in the unmixing
+	// directions. If Tol is zero, 1e-8 is used.
+	Tol float64
+
+	// MaxIter is the maximum number of fixed point iterations for each
+	// component, or for the whole unmixing matrix in symmetric mode. If
+	// MaxIter is zero, 200 is used.
+	MaxIter int
+
+	// Src is the source of the random initial unmixing directions. If Src
+	// is nil, the top-level math/rand functions are used.
+	Src Source
+}
+
+// ICAFactors holds the result of an independent component analysis of data
+// with observations in its rows, such that the sources are recovered by
+//
+//  Sources = (data - Mean).Unmixing'
+//
+// and the centered data are approximated by Sources.Mixing'.
+type ICAFactors struct {
+	// Unmixing is the k-by-p unmixing matrix, the product of the
+	// rotation found by the fixed point iteration and the whitening
+	// matrix.
+	Unmixing *Dense
+
+	// Mixing is the p-by-k mixing matrix, a pseudo-inverse of Unmixing.
+	Mixing *Dense
+
+	// Sources holds the n-by-k estimated sources, which have unit
+	// variance.
+	Sources *Dense
+
+	// Mean holds the column means of the data.
+	Mean []float64
+
+	// Converged reports whether every fixed point iteration converged.
+	Converged bool
+}
+
+// FastICA performs independent component analysis of the n-by-p data with
+// observations in its rows, estimating k statistically independent sources by
+// the FastICA fixed point algorithm of Hyvärinen and Oja, "Independent component
+// analysis: algorithms and applications", Neural Networks 13:411-430, 2000.
+//
+// The data are centered and whitened by projection onto the k leading
+// principal components, obtained from the symmetric eigen decomposition of the
+// covariance matrix. FastICA will panic with ErrShape if k is not in [1, p], and
+// with ErrSingular if the covariance matrix has fewer than k positive
+// eigenvalues, and with ErrNonlinearity if opts holds an invalid nonlinearity.
+// A nil opts uses the default options. The data are not modified.
+func FastICA(data *Dense, k int, opts *ICAOptions) ICAFactors {
+	return fastICA(data, k, opts, nil)
+}
+
+// FastICAContext performs independent component analysis as for FastICA. The
+// context is checked after each fixed point iteration and each sweep of the
+// whitening decomposition, and if it is cancelled or its deadline passes the
+// analysis is abandoned and ctx.Err() is returned.
+func FastICAContext(ctx context.Context, data *Dense, k int, opts *ICAOptions) (f ICAFactors, err error) {
+	err = withContext(ctx, func(done <-chan struct{}) {
+		f = fastICA(data, k, opts, done)
+	})
+	return f, err
+}
+
+// fastICA implements FastICA, abandoning the analysis by panicking with
+// canceled if done is closed.
+func fastICA(data *Dense, k int, opts *ICAOptions, done <-chan struct{}) ICAFactors {
+	n, p := data.Dims()
+	if k < 1 || k > p || n < 2 {
+		panic(ErrShape)
+	}
+	if opts == nil {
+		opts = &ICAOptions{}
+	}
+	if opts.Nonlinearity < LogCosh || opts.Nonlinearity > Cube {
+		panic(ErrNonlinearity)
+	}
+	tol := opts.Tol
+	if tol == 0 {
+		tol = 1e-8
+	}
+	maxIter := opts.MaxIter
+	if maxIter == 0 {
+		maxIter = 200
+	}
+	src := source(opts.Src)
+
+	x := DenseCopyOf(data)
+	mean := Center(x, false).Offset
+
+	// Whiten using the k leading eigenvectors of the covariance.
+	var xt, cov Dense
+	xt.TCopy(x)
+	cov.Mul(&xt, x)
+	cov.Scale(1/float64(n), &cov)
+	Symmetrize(&cov)
+	var eig EigenFactors
+	eig.factorize(&cov, epsilon, done)
+	vals := eig.Values()
+	whiten := NewDense(k, p, nil)
+	dewhiten := NewDense(p, k, nil)
+	for i := 0; i < k; i++ {
+		col := p - 1 - i
+		lambda := real(vals[col])
+		if lambda <= 0 {
+			panic(ErrSingular)
+		}
+		s := math.Sqrt(lambda)
+		for j := 0; j < p; j++ {
+			v := eig.V.At(j, col)
+			whiten.Set(i, j, v/s)
+			dewhiten.Set(j, i, v*s)
+		}
+	}
+	var wt, z Dense
+	wt.TCopy(whiten)
+	z.Mul(x, &wt)
+
+	w := NewNormal(k, k, 0, 1, src)
+	var converged bool
+	if opts.Symmetric {
+		converged = icaSymmetric(&z, w, opts.Nonlinearity, tol, maxIter, done)
+	} else {
+		converged = icaDeflation(&z, w, opts.Nonlinearity, tol, maxIter, done)
+	}
+
+	f := ICAFactors{
+		Unmixing:  &Dense{},
+		Mixing:    &Dense{},
+		Sources:   &Dense{},
+		Mean:      mean,
+		Converged: converged,
+	}
+	f.Unmixing.Mul(w, whiten)
+	var wtr Dense
+	wtr.TCopy(w)
+	f.Mixing.Mul(dewhiten, &wtr)
+	var ut Dense
+	ut.TCopy(f.Unmixing)
+	f.Sources.Mul(x, &ut)
+	return f
+}
+
+// icaContrast returns g(u) and g'(u) for the derivative g of the contrast
+// function.
+func icaContrast(nl ICANonlinearity, u float64) (g, dg float64) {
+	switch nl {
+	case LogCosh:
+		t := math.Tanh(u)
+		return t, 1 - t*t
+	case Exp:
+		e := math.Exp(-u * u / 2)
+		return u * e, (1 - u*u) * e
+	case Cube:
+		return u * u * u, 3 * u * u
+	default:
+		panic(ErrNonlinearity)
+	}
+}
+
+// icaUpdate sets wNew to the fixed point update E[z.g(w'z)] - E[g'(w'z)].w
+// over the rows of z.
+func icaUpdate(z *Dense, w, wNew []float64, nl ICANonlinearity) {
+	n, _ := z.Dims()
+	zero(wNew)
+	var mdg float64
+	for i := 0; i < n; i++ {
+		row := z.rowView(i)
+		g, dg := icaContrast(nl, dotUnitary(w, row))
+		axpyUnitary(g, row, wNew)
+		mdg += dg
+	}
+	scalUnitary(1/float64(n), wNew)
+	axpyUnitary(-mdg/float64(n), w, wNew)
+}
+
+// icaDeflation estimates the rows of the orthogonal w one at a time, starting
+// from the initial values in w, orthogonalizing each against those already found.
+func icaDeflation(z, w *Dense, nl ICANonlinearity, tol float64, maxIter int, done <-chan struct{}) bool {
+	k, _ := w.Dims()
+	converged := true
+	wNew := make([]float64, k)
+	var sweeps int
+	for c := 0; c < k; c++ {
+		wc := w.rowView(c)
+		icaOrthogonalize(w, c, wc)
+		var ok bool
+		for iter := 0; iter < maxIter && !ok; iter++ {
+			checkDone(done)
+			icaUpdate(z, wc, wNew, nl)
+			icaOrthogonalize(w, c, wNew)
+			change := math.Abs(math.Abs(dotUnitary(wNew, wc)) - 1)
+			ok = change < tol
+			copy(wc, wNew)
+			sweeps++
+			if progress != nil {
+				progress("FastICA", sweeps, change)
+			}
+		}
+		converged = converged && ok
+	}
+	return converged
+}
+
+// icaOrthogonalize makes v orthogonal to the first c rows of w and normalizes it.
+func icaOrthogonalize(w *Dense, c int, v []float64) {
+	for j := 0; j < c; j++ {
+		wj := w.rowView(j)
+		axpyUnitary(-dotUnitary(v, wj), wj, v)
+	}
+	scalUnitary(1/Vec(v).Norm(2), v)
+}
+
+// icaSymmetric estimates all rows of the orthogonal w together, starting from
+// the initial values in w, with symmetric orthogonalization after each update.
+func icaSymmetric(z, w *Dense, nl ICANonlinearity, tol float64, maxIter int, done <-chan struct{}) bool {
+	k, _ := w.Dims()
+	icaSymmetricDecorrelate(w)
+	wNew := NewDense(k, k, nil)
+	for iter := 0; iter < maxIter; iter++ {
+		checkDone(done)
+		for c := 0; c < k; c++ {
+			icaUpdate(z, w.rowView(c), wNew.rowView(c), nl)
+		}
+		icaSymmetricDecorrelate(wNew)
+
+		// Converged when each new direction is parallel to the old.
+		var change float64
+		for c := 0; c < k; c++ {
+			change = math.Max(change, math.Abs(math.Abs(dotUnitary(wNew.rowView(c), w.rowView(c)))-1))
+		}
+		w.Copy(wNew)
+		if progress != nil {
+			progress("FastICA", iter+1, change)
+		}
+		if change < tol {
+			return true
+		}
+	}
+	return false
+}
+
+// icaSymmetricDecorrelate replaces w with (w.w')^-1/2.w.
+func icaSymmetricDecorrelate(w *Dense) {
+	k, _ := w.Dims()
+	var wt, wwt Dense
+	wt.TCopy(w)
+	wwt.Mul(w, &wt)
+	Symmetrize(&wwt)
+	eig := Eigen(&wwt, epsilon)
+	vals := eig.Values()
+	s := NewDense(k, k, nil)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			var v float64
+			for l := 0; l < k; l++ {
+				v += eig.V.At(i, l) * eig.V.At(j, l) / math.Sqrt(real(vals[l]))
+			}
+			s.Set(i, j, v)
+		}
+	}
+	var t Dense
+	t.Mul(s, w)
+	w.Copy(&t)
+}
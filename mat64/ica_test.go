@@ -0,0 +1,93 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+// correlation returns the Pearson correlation of x and y.
+func correlation(x, y []float64) float64 {
+	var mx, my float64
+	for i := range x {
+		mx += x[i]
+		my += y[i]
+	}
+	mx /= float64(len(x))
+	my /= float64(len(y))
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+func (s *S) TestFastICA(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	n := 2000
+	sources := NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		t := float64(i) / 50
+		sources.Set(i, 0, math.Sin(2*t))
+		sources.Set(i, 1, 2*rnd.Float64()-1)
+	}
+	mix := NewDense(2, 3, []float64{
+		1, 0.5, 2,
+		-0.5, 1.5, 1,
+	})
+	var data Dense
+	data.Mul(sources, mix)
+	for i := 0; i < n; i++ {
+		for j := 0; j < 3; j++ {
+			data.Set(i, j, data.At(i, j)+1e-3*rnd.NormFloat64()+float64(j))
+		}
+	}
+	orig := DenseCopyOf(&data)
+
+	for _, opts := range []*ICAOptions{
+		nil,
+		{Symmetric: true, Src: rand.New(rand.NewSource(2))},
+		{Nonlinearity: Exp, Src: rand.New(rand.NewSource(3))},
+		{Nonlinearity: Cube, Symmetric: true, Src: rand.New(rand.NewSource(4))},
+	} {
+		f := FastICA(&data, 2, opts)
+		c.Check(data.Equals(orig), check.Equals, true)
+		c.Check(f.Converged, check.Equals, true, check.Commentf("%+v", opts))
+
+		// Each estimated source is strongly correlated with one true
+		// source and they are distinct.
+		var match [2]int
+		for j := 0; j < 2; j++ {
+			est := f.Sources.Col(nil, j)
+			var best float64
+			for l := 0; l < 2; l++ {
+				r := math.Abs(correlation(est, sources.Col(nil, l)))
+				if r > best {
+					best, match[j] = r, l
+				}
+			}
+			c.Check(best > 0.99, check.Equals, true, check.Commentf("%+v source %d: %v", opts, j, best))
+		}
+		c.Check(match[0] != match[1], check.Equals, true)
+
+		// The sources have unit variance and are recovered by the
+		// unmixing matrix.
+		for j := 0; j < 2; j++ {
+			c.Check(math.Abs(Vec(f.Sources.Col(nil, j)).Norm(2)/math.Sqrt(float64(n))-1) < 1e-8, check.Equals, true)
+		}
+		var mu Dense
+		mu.Mul(f.Unmixing, f.Mixing)
+		c.Check(mu.EqualsApprox(identityDense(2), 1e-8), check.Equals, true)
+	}
+
+	c.Check(func() { FastICA(&data, 4, nil) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { FastICA(&data, 2, &ICAOptions{Nonlinearity: 5}) }, check.PanicMatches, string(ErrNonlinearity))
+}
@@ -0,0 +1,55 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"fmt"
+	"math"
+)
+
+var finiteCheck bool
+
+// SetFiniteCheck enables or disables checking for NaN and Inf values during the
+// iterative decompositions Eigen and SVD. When checking is enabled, the input
+// matrix and the working values after each sweep of the iteration are inspected,
+// and the decomposition panics with an Error describing the first non-finite value
+// found, the routine and the sweep in which it was introduced. The panic may be
+// recovered with Maybe. Without checking, non-finite values propagate through the
+// decomposition and may prevent the iteration from converging.
+//
+// Checking is disabled by default, since it adds a pass over the working values
+// for each sweep.
+func SetFiniteCheck(on bool) { finiteCheck = on }
+
+// FiniteCheck returns whether checking for NaN and Inf values is enabled.
+func FiniteCheck() bool { return finiteCheck }
+
+// checkFiniteDense panics if finite checking is enabled and m holds a non-finite
+// value.
+func checkFiniteDense(op string, sweep int, m *Dense) {
+	if !finiteCheck {
+		return
+	}
+	for i := 0; i < m.mat.Rows; i++ {
+		for j, v := range m.rowView(i) {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				panic(Error(fmt.Sprintf("mat64: non-finite value %v at (%d, %d) in %s sweep %d", v, i, j, op, sweep)))
+			}
+		}
+	}
+}
+
+// checkFiniteSlice panics if finite checking is enabled and the working vector s,
+// identified by name, holds a non-finite value.
+func checkFiniteSlice(op string, sweep int, name string, s []float64) {
+	if !finiteCheck {
+		return
+	}
+	for i, v := range s {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			panic(Error(fmt.Sprintf("mat64: non-finite value %v at %s[%d] in %s sweep %d", v, name, i, op, sweep)))
+		}
+	}
+}
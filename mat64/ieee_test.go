@@ -0,0 +1,64 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestFiniteCheck(c *check.C) {
+	defer SetFiniteCheck(FiniteCheck())
+	SetFiniteCheck(true)
+
+	for i, test := range []struct {
+		a   *Dense
+		msg string
+	}{
+		{
+			a: NewDense(3, 3, []float64{
+				4, 1, 1,
+				1, 2, 3,
+				1, 3, 6,
+			}),
+		},
+		{
+			a: NewDense(3, 3, []float64{
+				1, 2, 1,
+				6, -1, 0,
+				-1, -2, -1,
+			}),
+		},
+		{
+			a: NewDense(3, 3, []float64{
+				4, 1, 1,
+				1, math.NaN(), 3,
+				1, 3, 6,
+			}),
+			msg: "mat64: non-finite value NaN at (1, 1) in Eigen sweep 0",
+		},
+		{
+			a: NewDense(2, 2, []float64{
+				1, math.Inf(1),
+				6, -1,
+			}),
+			msg: "mat64: non-finite value +Inf at (0, 1) in Eigen sweep 0",
+		},
+	} {
+		err := Maybe(func() { Eigen(DenseCopyOf(test.a), math.Pow(2, -52)) })
+		if test.msg == "" {
+			c.Check(err, check.Equals, nil, check.Commentf("Test %d", i))
+		} else {
+			c.Check(err, check.Equals, Error(test.msg), check.Commentf("Test %d", i))
+		}
+
+		err = Maybe(func() { SVD(DenseCopyOf(test.a), epsilon, small, true, true) })
+		c.Check(err == nil, check.Equals, test.msg == "", check.Commentf("Test %d", i))
+	}
+
+	c.Check(func() { checkFiniteSlice("test", 2, "d", []float64{0, math.Inf(-1)}) }, check.PanicMatches,
+		`mat64: non-finite value -Inf at d\[1\] in test sweep 2`)
+}
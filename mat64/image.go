@@ -0,0 +1,103 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// A Colormap returns the color representing a value v in [0, 1].
+type Colormap func(v float64) color.Color
+
+// Grayscale is a Colormap running from black at 0 to white at 1.
+func Grayscale(v float64) color.Color {
+	return color.Gray{Y: uint8(math.Floor(255*v + 0.5))}
+}
+
+// Diverging is a Colormap running from blue at 0 through white at 0.5 to red
+// at 1. With symmetric scaling it shows negative values in blue and positive
+// values in red.
+func Diverging(v float64) color.Color {
+	if v < 0.5 {
+		f := uint8(math.Floor(510*v + 0.5))
+		return color.RGBA{R: f, G: f, B: 255, A: 255}
+	}
+	f := uint8(math.Floor(510*(1-v) + 0.5))
+	return color.RGBA{R: 255, G: f, B: f, A: 255}
+}
+
+// ToImage returns a heat map image of a with one pixel for each element, with
+// element (i, j) at pixel (j, i). The elements are scaled linearly from the
+// range of the finite elements of a to [0, 1] before being mapped to colors by
+// cm; if symmetric is true the range is made symmetric about zero so that zero
+// maps to 0.5. NaN elements are transparent and infinite elements take the color
+// at the corresponding end of the range.
+func ToImage(a Matrix, cm Colormap, symmetric bool) *image.RGBA {
+	r, c := a.Dims()
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := a.At(i, j)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	if symmetric {
+		hi = math.Max(math.Abs(lo), math.Abs(hi))
+		lo = -hi
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, c, r))
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := a.At(i, j)
+			if math.IsNaN(v) {
+				continue
+			}
+			var f float64
+			switch {
+			case v >= hi:
+				f = 1
+			case v <= lo:
+				f = 0
+			default:
+				f = (v - lo) / (hi - lo)
+			}
+			if hi == lo && !math.IsInf(v, 0) {
+				f = 0.5
+			}
+			img.Set(j, i, cm(f))
+		}
+	}
+	return img
+}
+
+// Spy returns an image of the sparsity pattern of a, with non-zero elements
+// black and zero elements white.
+func Spy(a Matrix) *image.Gray {
+	r, c := a.Dims()
+	img := image.NewGray(image.Rect(0, 0, c, r))
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if a.At(i, j) == 0 {
+				img.SetGray(j, i, color.Gray{Y: 255})
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG writes the heat map image of a described for ToImage to w in PNG
+// format.
+func WritePNG(w io.Writer, a Matrix, cm Colormap, symmetric bool) error {
+	return png.Encode(w, ToImage(a, cm, symmetric))
+}
@@ -0,0 +1,56 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestToImage(c *check.C) {
+	a := NewDense(2, 3, []float64{
+		0, 1, 2,
+		math.NaN(), math.Inf(1), 4,
+	})
+	img := ToImage(a, Grayscale, false)
+	c.Check(img.Bounds().Dx(), check.Equals, 3)
+	c.Check(img.Bounds().Dy(), check.Equals, 2)
+	gray := func(x, y int) uint8 { return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y }
+	c.Check(gray(0, 0), check.Equals, uint8(0))
+	c.Check(gray(1, 0), check.Equals, uint8(64))
+	c.Check(gray(2, 0), check.Equals, uint8(128))
+	c.Check(gray(1, 1), check.Equals, uint8(255))
+	c.Check(gray(2, 1), check.Equals, uint8(255))
+	c.Check(img.RGBAAt(0, 1).A, check.Equals, uint8(0))
+
+	d := NewDense(1, 3, []float64{-1, 0, 2})
+	img = ToImage(d, Diverging, true)
+	c.Check(img.RGBAAt(0, 0), check.Equals, color.RGBA{R: 128, G: 128, B: 255, A: 255})
+	c.Check(img.RGBAAt(1, 0), check.Equals, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	c.Check(img.RGBAAt(2, 0), check.Equals, color.RGBA{R: 255, G: 0, B: 0, A: 255})
+
+	// A constant matrix maps to the middle of the colormap.
+	img = ToImage(NewDense(1, 1, []float64{3}), Grayscale, false)
+	c.Check(gray(0, 0), check.Equals, uint8(128))
+
+	var buf bytes.Buffer
+	c.Assert(WritePNG(&buf, a, Grayscale, false), check.IsNil)
+	decoded, err := png.Decode(&buf)
+	c.Assert(err, check.IsNil)
+	c.Check(decoded.Bounds().Dx(), check.Equals, 3)
+	c.Check(decoded.Bounds().Dy(), check.Equals, 2)
+}
+
+func (s *S) TestSpy(c *check.C) {
+	img := Spy(NewDense(2, 2, []float64{1, 0, 0, -3}))
+	c.Check(img.GrayAt(0, 0).Y, check.Equals, uint8(0))
+	c.Check(img.GrayAt(1, 0).Y, check.Equals, uint8(255))
+	c.Check(img.GrayAt(0, 1).Y, check.Equals, uint8(255))
+	c.Check(img.GrayAt(1, 1).Y, check.Equals, uint8(0))
+}
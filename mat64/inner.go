@@ -41,3 +41,58 @@ func Inner(x []float64, A Matrix, y []float64) float64 {
 	}
 	return sum
 }
+
+// Dot returns the Frobenius inner product of a and b, the sum of the element-wise
+// products of their elements, which is equal to tr(a'.b). If a is a Dotter its
+// Dot method is used. Dot will panic with ErrShape if the shapes of a and b differ.
+func Dot(a, b Matrix) float64 {
+	if a, ok := a.(Dotter); ok {
+		return a.Dot(b)
+	}
+	if b, ok := b.(Dotter); ok {
+		return b.Dot(a)
+	}
+
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+	var d float64
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			d += a.At(i, j) * b.At(i, j)
+		}
+	}
+	return d
+}
+
+// TraceMul returns the trace of the matrix product a.b without forming the
+// product. TraceMul will panic with ErrShape if a is m-by-n and b is not n-by-m.
+func TraceMul(a, b Matrix) float64 {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ac != br || ar != bc {
+		panic(ErrShape)
+	}
+
+	var t float64
+	if a, ok := a.(RawMatrixer); ok {
+		if b, ok := b.(RawMatrixer); ok {
+			amat, bmat := a.RawMatrix(), b.RawMatrix()
+			for i := 0; i < ar; i++ {
+				for k, v := range amat.Data[i*amat.Stride : i*amat.Stride+ac] {
+					t += v * bmat.Data[k*bmat.Stride+i]
+				}
+			}
+			return t
+		}
+	}
+
+	for i := 0; i < ar; i++ {
+		for k := 0; k < ac; k++ {
+			t += a.At(i, k) * b.At(k, i)
+		}
+	}
+	return t
+}
@@ -71,3 +71,29 @@ func (s *S) TestInner(c *check.C) {
 		c.Check(want, check.Equals, got, check.Commentf("Test %v: want %v, got %v", i, want, got))
 	}
 }
+
+func (s *S) TestDotTraceMul(c *check.C) {
+	a := NewDense(2, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+	b := NewDense(2, 3, []float64{
+		-1, 0, 2,
+		3, 1, -2,
+	})
+	bt := &Dense{}
+	bt.TCopy(b)
+
+	want := 10.0
+	c.Check(Dot(a, b), check.Equals, want)
+	c.Check(Dot((*basicMatrix)(a), (*basicMatrix)(b)), check.Equals, want)
+
+	var p Dense
+	p.Mul(a, bt)
+	c.Check(TraceMul(a, bt), check.Equals, p.Trace())
+	c.Check(TraceMul((*basicMatrix)(a), (*basicMatrix)(bt)), check.Equals, p.Trace())
+	c.Check(TraceMul(a, bt), check.Equals, want)
+
+	c.Check(func() { Dot((*basicMatrix)(a), (*basicMatrix)(bt)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { TraceMul(a, b) }, check.PanicMatches, string(ErrShape))
+}
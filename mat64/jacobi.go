@@ -0,0 +1,168 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// EigenSymJacobi returns the eigen decomposition of the symmetric matrix a as
+// described for Eigen, computed by the cyclic Jacobi method rather than by
+// tridiagonal reduction and the QL algorithm. The matrix a is overwritten during
+// the decomposition. The eigenvalues are returned in ascending order.
+//
+// The Jacobi method is slower than Eigen for all but small matrices, but it
+// determines the eigenvalues of graded and well scaled matrices to high relative
+// accuracy, which makes it useful for small problems and for validating the
+// results of Eigen. A rotation is applied only when the off-diagonal element is
+// not negligible relative to the corresponding diagonal elements, and the
+// iteration stops when a sweep applies no rotations.
+//
+// The rotations of each sweep are ordered in rounds of disjoint pairs of rows
+// and columns, and the rotations of a round are applied concurrently for large
+// matrices, subject to SetMaxProcs.
+//
+// EigenSymJacobi will panic with ErrSquare if a is not square and with
+// ErrNotSymmetric if a is not symmetric.
+func EigenSymJacobi(a *Dense, epsilon float64) EigenFactors {
+	m, n := a.Dims()
+	if m != n {
+		panic(ErrSquare)
+	}
+	if !symmetric(a) {
+		panic(ErrNotSymmetric)
+	}
+	checkFiniteDense("EigenSymJacobi", 0, a)
+
+	v := identityDense(n)
+	rounds := roundRobin(n)
+	rots := make([]jacobiRotation, 0, (n+1)/2)
+	for sweeps := 1; n > 1; sweeps++ {
+		rotated := false
+		for _, pairs := range rounds {
+			rots = rots[:0]
+			for _, pq := range pairs {
+				p, q := pq[0], pq[1]
+				apq := a.At(p, q)
+				app, aqq := a.At(p, p), a.At(q, q)
+				if math.Abs(apq) <= epsilon*math.Sqrt(math.Abs(app*aqq)) {
+					a.Set(p, q, 0)
+					a.Set(q, p, 0)
+					continue
+				}
+
+				theta := (aqq - app) / (2 * apq)
+				var t float64
+				if tt := theta * theta; math.IsInf(tt, 1) {
+					t = 1 / (2 * theta)
+				} else {
+					t = 1 / (math.Abs(theta) + math.Sqrt(1+tt))
+					if theta < 0 {
+						t = -t
+					}
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				rots = append(rots, jacobiRotation{
+					p: p, q: q,
+					c: c, s: -t * c,
+					app: app - t*apq, aqq: aqq + t*apq,
+				})
+			}
+			if len(rots) == 0 {
+				continue
+			}
+			rotated = true
+
+			// The rotations of a round act on disjoint rows and
+			// columns, so each is applied independently.
+			parallelRows(len(rots), 2*n, func(lo, hi int) {
+				for _, r := range rots[lo:hi] {
+					a.RotateRows(r.p, r.q, r.c, r.s)
+				}
+			})
+			parallelRows(n, 4*len(rots), func(lo, hi int) {
+				for i := lo; i < hi; i++ {
+					ar, vr := a.rowView(i), v.rowView(i)
+					for _, r := range rots {
+						x, y := ar[r.p], ar[r.q]
+						ar[r.p], ar[r.q] = r.c*x+r.s*y, r.c*y-r.s*x
+						x, y = vr[r.p], vr[r.q]
+						vr[r.p], vr[r.q] = r.c*x+r.s*y, r.c*y-r.s*x
+					}
+				}
+			})
+			for _, r := range rots {
+				a.Set(r.p, r.p, r.app)
+				a.Set(r.q, r.q, r.aqq)
+				a.Set(r.p, r.q, 0)
+				a.Set(r.q, r.p, 0)
+			}
+		}
+
+		if progress != nil {
+			var off float64
+			for i := 0; i < n; i++ {
+				for j, x := range a.rowView(i) {
+					if j != i {
+						off = math.Hypot(off, x)
+					}
+				}
+			}
+			progress("EigenSymJacobi", sweeps, off)
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = a.At(i, i)
+	}
+	f := EigenFactors{V: v, d: d, e: make([]float64, n)}
+	f.Sort(EigenAscending)
+	return f
+}
+
+// jacobiRotation is a rotation of rows and columns p and q of a symmetric
+// matrix that annihilates the (p, q) element, with the resulting diagonal
+// elements app and aqq.
+type jacobiRotation struct {
+	p, q     int
+	c, s     float64
+	app, aqq float64
+}
+
+// roundRobin returns an ordering of all pairs of [0, n) into n-1 rounds for
+// even n, or n rounds for odd n, such that the pairs in each round are disjoint.
+func roundRobin(n int) [][][2]int {
+	if n < 2 {
+		return nil
+	}
+	m := n + n%2
+	players := make([]int, m)
+	for i := range players {
+		players[i] = i
+	}
+	rounds := make([][][2]int, m-1)
+	for r := range rounds {
+		for i := 0; i < m/2; i++ {
+			p, q := players[i], players[m-1-i]
+			if p == n || q == n {
+				// Odd n: the player paired with the dummy sits out.
+				continue
+			}
+			if p > q {
+				p, q = q, p
+			}
+			rounds[r] = append(rounds[r], [2]int{p, q})
+		}
+		// Keep the first player fixed and rotate the others.
+		last := players[m-1]
+		copy(players[2:], players[1:m-1])
+		players[1] = last
+	}
+	return rounds
+}
@@ -0,0 +1,98 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math"
+	"math/rand"
+)
+
+func (s *S) TestEigenSymJacobi(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 7, 10} {
+		a := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j <= i; j++ {
+				v := rnd.NormFloat64()
+				a.Set(i, j, v)
+				a.Set(j, i, v)
+			}
+		}
+		want := Eigen(DenseCopyOf(a), epsilon)
+		got := EigenSymJacobi(DenseCopyOf(a), epsilon)
+		for i, v := range got.d {
+			c.Check(math.Abs(v-want.d[i]) < 1e-12, check.Equals, true, check.Commentf("n=%d eigenvalue %d", n, i))
+			c.Check(got.e[i], check.Equals, 0.)
+		}
+		c.Check(isOrthonormal(got.V, 1e-13), check.Equals, true, check.Commentf("n=%d", n))
+
+		var av, vd Dense
+		av.Mul(a, got.V)
+		vd.Mul(got.V, got.D())
+		c.Check(av.EqualsApprox(&vd, 1e-12), check.Equals, true, check.Commentf("n=%d", n))
+	}
+}
+
+func (s *S) TestEigenSymJacobiGraded(c *check.C) {
+	// The strongly graded matrix diag(1, 1e-10, 1e-20) plus coupling has
+	// eigenvalues close to the diagonal, which Jacobi determines to high
+	// relative accuracy.
+	a := NewDense(3, 3, []float64{
+		1, 1e-6, 0,
+		1e-6, 1e-10, 1e-16,
+		0, 1e-16, 1e-20,
+	})
+	f := EigenSymJacobi(DenseCopyOf(a), epsilon)
+
+	// The smallest eigenvalue is the reciprocal of the largest eigenvalue of
+	// the inverse, which is well conditioned for this matrix.
+	inv := Inverse(DenseCopyOf(a))
+	Symmetrize(inv)
+	g := EigenSymJacobi(inv, epsilon)
+	c.Check(math.Abs(f.d[0]*g.d[2]-1) < 1e-12, check.Equals, true, check.Commentf("got %v", f.d))
+}
+
+func (s *S) TestEigenSymJacobiParallel(c *check.C) {
+	defer SetMaxProcs(SetMaxProcs(4))
+
+	const n = 150
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			v := rnd.NormFloat64()
+			a.Set(i, j, v)
+			a.Set(j, i, v)
+		}
+	}
+	want := Eigen(DenseCopyOf(a), epsilon)
+	got := EigenSymJacobi(DenseCopyOf(a), epsilon)
+	for i, v := range got.d {
+		c.Check(math.Abs(v-want.d[i]) < 1e-10, check.Equals, true, check.Commentf("eigenvalue %d", i))
+	}
+	c.Check(isOrthonormal(got.V, 1e-10), check.Equals, true)
+}
+
+func (s *S) TestEigenSymJacobiPanics(c *check.C) {
+	c.Check(func() { EigenSymJacobi(NewDense(2, 3, nil), epsilon) }, check.PanicMatches, string(ErrSquare))
+	c.Check(func() { EigenSymJacobi(NewDense(2, 2, []float64{1, 2, 3, 4}), epsilon) }, check.PanicMatches, string(ErrNotSymmetric))
+}
+
+func (s *S) TestRoundRobin(c *check.C) {
+	for n := 0; n < 9; n++ {
+		seen := make(map[[2]int]bool)
+		for _, round := range roundRobin(n) {
+			used := make(map[int]bool)
+			for _, pq := range round {
+				c.Check(used[pq[0]] || used[pq[1]], check.Equals, false)
+				used[pq[0]], used[pq[1]] = true, true
+				c.Check(seen[pq], check.Equals, false)
+				seen[pq] = true
+			}
+		}
+		c.Check(len(seen), check.Equals, n*(n-1)/2)
+	}
+}
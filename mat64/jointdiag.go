@@ -0,0 +1,116 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// JointDiagFactors holds an approximate joint diagonalization of a set of
+// symmetric matrices a[k], such that V'.a[k].V = D[k] is as close to diagonal
+// as an orthogonal V allows, in the sense of minimizing the sum of squares of
+// the off-diagonal elements over all k.
+type JointDiagFactors struct {
+	V *Dense
+	D []*Dense
+
+	// Sweeps is the number of Jacobi sweeps performed.
+	Sweeps int
+}
+
+// JointDiagonalize computes an approximate joint diagonalization of the
+// symmetric n-by-n matrices in a using the Jacobi method of Cardoso and
+// Souloumiac, "Jacobi angles for simultaneous diagonalization", SIAM J. Matrix
+// Anal. Appl. 17(1):161-164, 1996. Each sweep applies to all the matrices a plane
+// rotation for every pair of indices, with the angle chosen to minimize the
+// off-diagonal mass of the pair jointly over the set. Iteration stops after a
+// sweep in which no rotation has sine greater than tol, or after maxSweeps
+// sweeps.
+//
+// If a single matrix is given, JointDiagonalize computes its eigenvectors by
+// the cyclic Jacobi method. JointDiagonalize will panic with ErrSquare or
+// ErrShape if the matrices are not square or of equal size, and with
+// ErrNotSymmetric if any is not symmetric. The matrices in a are not modified.
+func JointDiagonalize(a []*Dense, tol float64, maxSweeps int) JointDiagFactors {
+	if len(a) == 0 {
+		panic(ErrShape)
+	}
+	n, c := a[0].Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	d := make([]*Dense, len(a))
+	for k, m := range a {
+		if r, c := m.Dims(); r != c {
+			panic(ErrSquare)
+		} else if r != n {
+			panic(ErrShape)
+		}
+		if !symmetric(m) {
+			panic(ErrNotSymmetric)
+		}
+		d[k] = DenseCopyOf(m)
+	}
+
+	f := JointDiagFactors{V: identityDense(n), D: d}
+	for f.Sweeps < maxSweeps {
+		f.Sweeps++
+		var rotated bool
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				// Accumulate the 2-by-2 matrix G = g.g' where the
+				// columns of g are [a_pp - a_qq, a_pq + a_qp]' over
+				// the set.
+				var g11, g12, g22 float64
+				for _, m := range d {
+					h1 := m.at(p, p) - m.at(q, q)
+					h2 := m.at(p, q) + m.at(q, p)
+					g11 += h1 * h1
+					g12 += h1 * h2
+					g22 += h2 * h2
+				}
+				ton := g11 - g22
+				toff := 2 * g12
+				theta := 0.5 * math.Atan2(toff, ton+math.Hypot(ton, toff))
+				cs, sn := math.Cos(theta), math.Sin(theta)
+				if math.Abs(sn) <= tol {
+					continue
+				}
+				rotated = true
+
+				for _, m := range d {
+					m.RotateRows(p, q, cs, sn)
+					m.RotateCols(p, q, cs, sn)
+				}
+				f.V.RotateCols(p, q, cs, sn)
+			}
+		}
+		if progress != nil {
+			progress("JointDiagonalize", f.Sweeps, math.Sqrt(f.OffDiagonal()))
+		}
+		if !rotated {
+			break
+		}
+	}
+	return f
+}
+
+// OffDiagonal returns the sum of squares of the off-diagonal elements of the
+// matrices in D.
+func (f JointDiagFactors) OffDiagonal() float64 {
+	var off float64
+	for _, m := range f.D {
+		r, c := m.Dims()
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				if i != j {
+					v := m.at(i, j)
+					off += v * v
+				}
+			}
+		}
+	}
+	return off
+}
@@ -0,0 +1,98 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestJointDiagonalize(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	n := 5
+	g := NewDense(n, n, nil)
+	for i := range g.mat.Data {
+		g.mat.Data[i] = rnd.NormFloat64()
+	}
+	q := householderQ(g)
+
+	// Matrices sharing the eigenvectors q.
+	var a []*Dense
+	for k := 0; k < 4; k++ {
+		d := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			d.Set(i, i, rnd.NormFloat64())
+		}
+		var qd, qt, m Dense
+		qd.Mul(q, d)
+		qt.TCopy(q)
+		m.Mul(&qd, &qt)
+		// Symmetrize to remove rounding asymmetry.
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				v := (m.At(i, j) + m.At(j, i)) / 2
+				m.Set(i, j, v)
+				m.Set(j, i, v)
+			}
+		}
+		a = append(a, &m)
+	}
+	orig := DenseCopyOf(a[0])
+
+	f := JointDiagonalize(a, 1e-12, 100)
+	c.Check(a[0].Equals(orig), check.Equals, true)
+	c.Check(f.Sweeps < 100, check.Equals, true)
+	c.Check(isOrthonormal(f.V, 1e-12), check.Equals, true)
+	c.Check(f.OffDiagonal() < 1e-20, check.Equals, true, check.Commentf("off %v", f.OffDiagonal()))
+
+	// D[k] = V'.a[k].V
+	var vt Dense
+	vt.TCopy(f.V)
+	for k, m := range a {
+		var t, d Dense
+		t.Mul(&vt, m)
+		d.Mul(&t, f.V)
+		c.Check(d.EqualsApprox(f.D[k], 1e-12), check.Equals, true)
+	}
+
+	// Each column of V matches a column of q up to sign.
+	for j := 0; j < n; j++ {
+		var best float64
+		for l := 0; l < n; l++ {
+			var dot float64
+			for i := 0; i < n; i++ {
+				dot += f.V.At(i, j) * q.At(i, l)
+			}
+			best = math.Max(best, math.Abs(dot))
+		}
+		c.Check(math.Abs(best-1) < 1e-10, check.Equals, true)
+	}
+
+	// Matrices without common eigenvectors are only approximately
+	// diagonalized, but the off-diagonal mass is reduced.
+	var noisy []*Dense
+	var before float64
+	for _, m := range a {
+		m = DenseCopyOf(m)
+		for i := 0; i < n; i++ {
+			for j := 0; j <= i; j++ {
+				v := m.At(i, j) + 0.01*rnd.NormFloat64()
+				m.Set(i, j, v)
+				m.Set(j, i, v)
+				if i != j {
+					before += 2 * v * v
+				}
+			}
+		}
+		noisy = append(noisy, m)
+	}
+	c.Check(JointDiagonalize(noisy, 1e-12, 100).OffDiagonal() < before, check.Equals, true)
+
+	c.Check(func() { JointDiagonalize(nil, 1e-12, 10) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { JointDiagonalize([]*Dense{a[0], NewDense(2, 2, nil)}, 1e-12, 10) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { JointDiagonalize([]*Dense{NewDense(2, 2, []float64{1, 2, 3, 4})}, 1e-12, 10) }, check.PanicMatches, string(ErrNotSymmetric))
+}
@@ -0,0 +1,156 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// Lapack is the set of LAPACK routines that the package uses in place of its
+// pure Go implementations when a backend is registered. All matrices are held
+// in row-major order with the given leading dimension, as for the registered
+// BLAS engine, so that implementations wrapping LAPACKE must request
+// LAPACK_ROW_MAJOR.
+type Lapack interface {
+	// Dgetrf computes the LU factorization of the m-by-n matrix a with
+	// partial pivoting, overwriting a with the unit lower triangular L
+	// and the upper triangular U. On return, row i of a was interchanged
+	// with row ipiv[i], for i in [0, min(m, n)), with indices counted from
+	// zero. Dgetrf returns false if U is exactly singular.
+	Dgetrf(m, n int, a []float64, lda int, ipiv []int) (ok bool)
+
+	// Dpotrf computes the Cholesky factorization a = L.L' of the n-by-n
+	// symmetric matrix a, referencing and overwriting only the lower
+	// triangle of a. Dpotrf returns false if a is not positive definite.
+	Dpotrf(n int, a []float64, lda int) (ok bool)
+}
+
+// LapackEigen is implemented by LAPACK backends that provide eigensolvers.
+// When the registered backend implements LapackEigen, Eigen uses it in place of
+// the pure Go implementation.
+type LapackEigen interface {
+	// Dsyev computes the eigenvalues of the n-by-n symmetric matrix a in
+	// ascending order into w. If wantv is true, a is overwritten with the
+	// orthonormal eigenvectors in its columns. Dsyev returns false if the
+	// algorithm failed to converge.
+	Dsyev(wantv bool, n int, a []float64, lda int, w []float64) (ok bool)
+
+	// Dgeev computes the eigenvalues wr[j] + i*wi[j] of the n-by-n matrix a,
+	// overwriting a. Complex conjugate pairs are consecutive with the
+	// eigenvalue having positive imaginary part first. If wantv is true,
+	// the right eigenvectors are stored in the columns of vr, with the
+	// real and imaginary parts of the eigenvector of the first eigenvalue
+	// of a pair held in consecutive columns. Dgeev returns false if the
+	// algorithm failed to converge.
+	Dgeev(wantv bool, n int, a []float64, lda int, wr, wi []float64, vr []float64, ldvr int) (ok bool)
+}
+
+// LapackSVD is implemented by LAPACK backends that provide a singular value
+// decomposition. When the registered backend implements LapackSVD, SVD uses it
+// in place of the pure Go implementation.
+type LapackSVD interface {
+	// Dgesvd computes the thin singular value decomposition a = U.diag(s).VT
+	// of the m-by-n matrix a, overwriting a. The min(m, n) singular values
+	// are stored in s in descending order. If wantu is true the m-by-min(m, n)
+	// U is stored in u, and if wantv is true the min(m, n)-by-n VT is stored
+	// in vt. Dgesvd returns false if the algorithm failed to converge.
+	Dgesvd(wantu, wantv bool, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int) (ok bool)
+}
+
+// ErrNoConvergence is the panic value used when a registered LAPACK backend
+// reports that a routine failed to converge.
+const ErrNoConvergence = Error("mat64: LAPACK routine failed to converge")
+
+var lapackEngine Lapack
+
+// RegisterLapack registers a LAPACK backend to be used by the decompositions in
+// the package. Registering a nil backend restores the pure Go implementations.
+func RegisterLapack(l Lapack) { lapackEngine = l }
+
+// RegisteredLapack returns the registered LAPACK backend, or nil if none is
+// registered.
+func RegisteredLapack() Lapack { return lapackEngine }
+
+// luLapack computes the LU decomposition of a in place using the registered
+// LAPACK backend. A singular U reported by the backend is recorded so that it
+// is reported by IsSingular as for the pure Go decomposition.
+func luLapack(a *Dense) LUFactors {
+	m, n := a.Dims()
+	ipiv := make([]int, min(m, n))
+	ok := lapackEngine.Dgetrf(m, n, a.mat.Data, a.mat.Stride, ipiv)
+
+	// Convert the sequence of interchanges to a permutation.
+	piv := make([]int, m)
+	for i := range piv {
+		piv[i] = i
+	}
+	sign := 1
+	for i, p := range ipiv {
+		if p != i {
+			piv[i], piv[p] = piv[p], piv[i]
+			sign = -sign
+		}
+	}
+	return LUFactors{LU: a, Pivot: piv, Sign: sign, singular: !ok}
+}
+
+// choleskyLapack computes the Cholesky decomposition of the square matrix a
+// using the registered LAPACK backend.
+func choleskyLapack(a *Dense) CholeskyFactor {
+	n, _ := a.Dims()
+	l := NewDense(n, n, nil)
+	spd := true
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			l.Set(i, j, a.At(i, j))
+			spd = spd && a.At(i, j) == a.At(j, i)
+		}
+	}
+	spd = lapackEngine.Dpotrf(n, l.mat.Data, l.mat.Stride) && spd
+	l.zeroUpper()
+	return CholeskyFactor{L: l, SPD: spd}
+}
+
+// eigenLapack computes the eigen decomposition of the square matrix a using l,
+// storing the eigenvalues in d and e, and returns the eigenvectors. The matrix
+// a is overwritten and holds the eigenvectors of a symmetric matrix; otherwise
+// the eigenvectors are stored in v.
+func eigenLapack(l LapackEigen, a *Dense, d, e []float64, v *Dense) *Dense {
+	n, _ := a.Dims()
+	if symmetric(a) {
+		if !l.Dsyev(true, n, a.mat.Data, a.mat.Stride, d) {
+			panic(ErrNoConvergence)
+		}
+		return a
+	}
+	if !l.Dgeev(true, n, a.mat.Data, a.mat.Stride, d, e, v.mat.Data, v.mat.Stride) {
+		panic(ErrNoConvergence)
+	}
+	return v
+}
+
+// svdLapack computes the singular value decomposition of a using l. The matrix
+// a is overwritten.
+func svdLapack(l LapackSVD, a *Dense, wantu, wantv bool) SVDFactors {
+	m, n := a.Dims()
+	k := min(m, n)
+	sigma := make([]float64, k)
+	var u, vt *Dense
+	var uData, vtData []float64
+	ldu, ldvt := 1, 1
+	if wantu {
+		u = NewDense(m, k, nil)
+		uData, ldu = u.mat.Data, max(u.mat.Stride, 1)
+	}
+	if wantv {
+		vt = NewDense(k, n, nil)
+		vtData, ldvt = vt.mat.Data, max(vt.mat.Stride, 1)
+	}
+	if !l.Dgesvd(wantu, wantv, m, n, a.mat.Data, a.mat.Stride, sigma, uData, ldu, vtData, ldvt) {
+		panic(ErrNoConvergence)
+	}
+	f := SVDFactors{U: u, Sigma: sigma, m: max(m, n), n: k}
+	if wantv {
+		f.V = &Dense{}
+		f.V.TCopy(vt)
+	}
+	return f
+}
@@ -0,0 +1,240 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+// goLapack is an unblocked Go implementation of Lapack that counts calls.
+type goLapack struct {
+	getrf, potrf int
+}
+
+func (l *goLapack) Dgetrf(m, n int, a []float64, lda int, ipiv []int) bool {
+	l.getrf++
+	ok := true
+	for j := 0; j < min(m, n); j++ {
+		p := j
+		for i := j + 1; i < m; i++ {
+			if math.Abs(a[i*lda+j]) > math.Abs(a[p*lda+j]) {
+				p = i
+			}
+		}
+		ipiv[j] = p
+		if p != j {
+			for k := 0; k < n; k++ {
+				a[j*lda+k], a[p*lda+k] = a[p*lda+k], a[j*lda+k]
+			}
+		}
+		if a[j*lda+j] == 0 {
+			ok = false
+			continue
+		}
+		for i := j + 1; i < m; i++ {
+			a[i*lda+j] /= a[j*lda+j]
+			for k := j + 1; k < n; k++ {
+				a[i*lda+k] -= a[i*lda+j] * a[j*lda+k]
+			}
+		}
+	}
+	return ok
+}
+
+func (l *goLapack) Dpotrf(n int, a []float64, lda int) bool {
+	l.potrf++
+	for j := 0; j < n; j++ {
+		d := a[j*lda+j]
+		for k := 0; k < j; k++ {
+			d -= a[j*lda+k] * a[j*lda+k]
+		}
+		if d <= 0 {
+			return false
+		}
+		d = math.Sqrt(d)
+		a[j*lda+j] = d
+		for i := j + 1; i < n; i++ {
+			s := a[i*lda+j]
+			for k := 0; k < j; k++ {
+				s -= a[i*lda+k] * a[j*lda+k]
+			}
+			a[i*lda+j] = s / d
+		}
+	}
+	return true
+}
+
+func (s *S) TestRegisterLapack(c *check.C) {
+	c.Check(RegisteredLapack(), check.Equals, nil)
+
+	a := NewDense(4, 4, []float64{
+		2, 1, 0, 3,
+		4, -1, 3, 1,
+		0, 5, 1, 2,
+		-2, 3, 3, 0,
+	})
+	spd := NewDense(3, 3, []float64{
+		4, 2, -2,
+		2, 10, 1,
+		-2, 1, 6,
+	})
+	b := NewDense(4, 2, []float64{1, 2, 3, 4, 5, 6, 7, 8})
+
+	lu := LU(DenseCopyOf(a))
+	x := lu.Solve(DenseCopyOf(b))
+	chol := Cholesky(spd)
+
+	l := &goLapack{}
+	RegisterLapack(l)
+	defer RegisterLapack(nil)
+	c.Check(RegisteredLapack(), check.Equals, Lapack(l))
+
+	luL := LU(DenseCopyOf(a))
+	c.Check(l.getrf, check.Equals, 1)
+	c.Check(luL.LU.EqualsApprox(lu.LU, 1e-12), check.Equals, true)
+	c.Check(luL.Pivot, check.DeepEquals, lu.Pivot)
+	c.Check(luL.Sign, check.Equals, lu.Sign)
+	c.Check(math.Abs(luL.Det()-lu.Det()) < 1e-10, check.Equals, true)
+	c.Check(luL.Solve(DenseCopyOf(b)).EqualsApprox(x, 1e-12), check.Equals, true)
+
+	cholL := Cholesky(spd)
+	c.Check(l.potrf, check.Equals, 1)
+	c.Check(cholL.SPD, check.Equals, true)
+	c.Check(cholL.L.EqualsApprox(chol.L, 1e-12), check.Equals, true)
+
+	notSPD := NewDense(2, 2, []float64{1, 2, 2, 1})
+	c.Check(Cholesky(notSPD).SPD, check.Equals, false)
+	notSym := NewDense(2, 2, []float64{4, 1, 0, 4})
+	c.Check(Cholesky(notSym).SPD, check.Equals, false)
+
+	// Singularity is reported as by the pure Go decomposition.
+	sing := NewDense(3, 3, []float64{
+		1, 2, 3,
+		2, 4, 6,
+		1, 0, 1,
+	})
+	RegisterLapack(nil)
+	luG := LU(DenseCopyOf(sing))
+	RegisterLapack(l)
+	luL = LU(DenseCopyOf(sing))
+	c.Check(luG.IsSingular(), check.Equals, true)
+	c.Check(luL.IsSingular(), check.Equals, true)
+	c.Check(luL.Det(), check.Equals, luG.Det())
+	c.Check(func() { luL.Solve(NewDense(3, 1, nil)) }, check.PanicMatches, string(ErrSingular))
+
+	// A backend may report singularity without an exact zero on the
+	// diagonal of U.
+	RegisterLapack(singularLapack{l})
+	luL = LU(DenseCopyOf(a))
+	c.Check(luL.IsSingular(), check.Equals, true)
+	c.Check(luL.Det(), check.Equals, 0.)
+
+	// The reported singularity survives encoding.
+	bin, err := luL.MarshalBinary()
+	c.Assert(err, check.IsNil)
+	var luD LUFactors
+	c.Assert(luD.UnmarshalBinary(bin), check.IsNil)
+	c.Check(luD.IsSingular(), check.Equals, true)
+	c.Check(luD.Pivot, check.DeepEquals, luL.Pivot)
+	c.Check(func() { luL.Solve(DenseCopyOf(b)) }, check.PanicMatches, string(ErrSingular))
+}
+
+// singularLapack is a Lapack whose Dgetrf always reports a singular matrix.
+type singularLapack struct {
+	*goLapack
+}
+
+func (l singularLapack) Dgetrf(m, n int, a []float64, lda int, ipiv []int) bool {
+	l.goLapack.Dgetrf(m, n, a, lda, ipiv)
+	return false
+}
+
+// goLapackEigen extends goLapack with eigensolvers and an SVD built from the
+// pure Go implementations.
+type goLapackEigen struct {
+	goLapack
+	syev, geev, gesvd int
+}
+
+func (l *goLapackEigen) Dsyev(wantv bool, n int, a []float64, lda int, w []float64) bool {
+	l.syev++
+	m := &Dense{RawMatrix{Rows: n, Cols: n, Stride: lda, Data: a}}
+	e := make([]float64, n)
+	tql2(w, e, tred2(m, w, e), epsilon, nil, nil)
+	return true
+}
+
+func (l *goLapackEigen) Dgeev(wantv bool, n int, a []float64, lda int, wr, wi []float64, vr []float64, ldvr int) bool {
+	l.geev++
+	m := &Dense{RawMatrix{Rows: n, Cols: n, Stride: lda, Data: a}}
+	hess, v := orthes(m, nil, nil)
+	hqr2(wr, wi, hess, v, epsilon, true, nil)
+	(&Dense{RawMatrix{Rows: n, Cols: n, Stride: ldvr, Data: vr}}).Copy(v)
+	return true
+}
+
+func (l *goLapackEigen) Dgesvd(wantu, wantv bool, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int) bool {
+	l.gesvd++
+	RegisterLapack(nil)
+	defer RegisterLapack(l)
+	f := SVD(&Dense{RawMatrix{Rows: m, Cols: n, Stride: lda, Data: a}}, epsilon, small, wantu, wantv)
+	k := copy(s, f.Sigma)
+	if wantu {
+		(&Dense{RawMatrix{Rows: m, Cols: k, Stride: ldu, Data: u}}).Copy(f.U)
+	}
+	if wantv {
+		(&Dense{RawMatrix{Rows: k, Cols: n, Stride: ldvt, Data: vt}}).TCopy(f.V)
+	}
+	return true
+}
+
+func (s *S) TestLapackEigenSVD(c *check.C) {
+	sym := NewDense(3, 3, []float64{
+		4, 1, -2,
+		1, 3, 0,
+		-2, 0, 5,
+	})
+	gen := NewDense(3, 3, []float64{
+		1, -2, 0,
+		3, 1, 1,
+		0, 1, 2,
+	})
+	wide := NewDense(2, 4, []float64{
+		1, 2, 3, 4,
+		-1, 0, 2, 1,
+	})
+
+	symWant := Eigen(DenseCopyOf(sym), epsilon)
+	genWant := Eigen(DenseCopyOf(gen), epsilon)
+	svdWant := SVD(DenseCopyOf(wide), epsilon, small, true, true)
+
+	l := &goLapackEigen{}
+	RegisterLapack(l)
+	defer RegisterLapack(nil)
+
+	symGot := Eigen(DenseCopyOf(sym), epsilon)
+	c.Check(l.syev, check.Equals, 1)
+	c.Check(symGot.V.EqualsApprox(symWant.V, 1e-12), check.Equals, true)
+	c.Check(symGot.D().EqualsApprox(symWant.D(), 1e-12), check.Equals, true)
+
+	genGot := Eigen(DenseCopyOf(gen), epsilon)
+	c.Check(l.geev, check.Equals, 1)
+	c.Check(genGot.Values(), check.DeepEquals, genWant.Values())
+	c.Check(genGot.V.EqualsApprox(genWant.V, 1e-12), check.Equals, true)
+
+	svdGot := SVD(DenseCopyOf(wide), epsilon, small, true, true)
+	c.Check(l.gesvd, check.Equals, 1)
+	c.Check(svdGot.Sigma, check.DeepEquals, svdWant.Sigma)
+	c.Check(svdGot.U.EqualsApprox(svdWant.U, 1e-12), check.Equals, true)
+	c.Check(svdGot.V.EqualsApprox(svdWant.V, 1e-12), check.Equals, true)
+	c.Check(svdGot.Rank(epsilon), check.Equals, svdWant.Rank(epsilon))
+	c.Check(svdGot.Cond(), check.Equals, svdWant.Cond())
+
+	// Only the factorizations the backend provides are routed to it.
+	RegisterLapack(&goLapack{})
+	c.Check(Eigen(DenseCopyOf(sym), epsilon).V.EqualsApprox(symWant.V, 1e-12), check.Equals, true)
+}
@@ -0,0 +1,293 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// LDLFactors holds the symmetric indefinite factorization P.a.P' = L.D.L' of a
+// symmetric matrix a, where L is unit lower triangular, D is symmetric block
+// diagonal with 1-by-1 and 2-by-2 diagonal blocks, and P is a permutation such
+// that row i of P.a is row Pivot[i] of a.
+type LDLFactors struct {
+	L     *Dense
+	D     *Dense
+	Pivot []int
+}
+
+// LDL returns the Bunch-Kaufman factorization of the symmetric matrix a. Unlike
+// Cholesky, the factorization exists for indefinite matrices such as the KKT
+// and saddle point matrices of constrained problems, and unlike LU it preserves
+// the symmetry of a, with half the operations. The matrix a is overwritten
+// during the decomposition.
+//
+// The pivoting strategy of Bunch and Kaufman, "Some stable methods for
+// calculating inertia and solving symmetric linear systems", Math. Comp.
+// 31:163-179, 1977, chooses at each step between a 1-by-1 pivot and a 2-by-2
+// pivot so that the elements of L are bounded.
+//
+// LDL will panic with ErrSquare if a is not square and with ErrNotSymmetric if
+// a is not symmetric.
+func LDL(a *Dense) LDLFactors {
+	m, n := a.Dims()
+	if m != n {
+		panic(ErrSquare)
+	}
+	if !symmetric(a) {
+		panic(ErrNotSymmetric)
+	}
+
+	alpha := (1 + math.Sqrt(17)) / 8
+
+	l := identityDense(n)
+	d := NewDense(n, n, nil)
+	piv := make([]int, n)
+	for i := range piv {
+		piv[i] = i
+	}
+
+	for k := 0; k < n; {
+		// Find the largest off-diagonal element in column k.
+		absakk := math.Abs(a.At(k, k))
+		var colmax float64
+		r := k
+		for i := k + 1; i < n; i++ {
+			if v := math.Abs(a.At(i, k)); v > colmax {
+				colmax, r = v, i
+			}
+		}
+
+		kstep, kp := 1, k
+		switch {
+		case math.Max(absakk, colmax) == 0:
+			// The column is zero, leaving a zero pivot.
+		case absakk >= alpha*colmax:
+			// No interchange.
+		default:
+			var rowmax float64
+			for j := k; j < n; j++ {
+				if j != r {
+					rowmax = math.Max(rowmax, math.Abs(a.At(r, j)))
+				}
+			}
+			switch {
+			case absakk >= alpha*colmax*(colmax/rowmax):
+				// No interchange.
+			case math.Abs(a.At(r, r)) >= alpha*rowmax:
+				kp = r
+			default:
+				kstep, kp = 2, r
+			}
+		}
+
+		// Interchange rows and columns kk and kp of the trailing
+		// matrix and the rows of the computed columns of L.
+		if kk := k + kstep - 1; kp != kk {
+			ldlSwap(a, l, k, kk, kp)
+			piv[kk], piv[kp] = piv[kp], piv[kk]
+		}
+
+		if kstep == 1 {
+			dkk := a.At(k, k)
+			d.Set(k, k, dkk)
+			if dkk != 0 {
+				for i := k + 1; i < n; i++ {
+					l.Set(i, k, a.At(i, k)/dkk)
+				}
+				for i := k + 1; i < n; i++ {
+					li := l.At(i, k)
+					if li == 0 {
+						continue
+					}
+					row := a.rowView(i)
+					for j := k + 1; j < n; j++ {
+						row[j] -= li * a.At(j, k)
+					}
+				}
+			}
+		} else {
+			d11, d21, d22 := a.At(k, k), a.At(k+1, k), a.At(k+1, k+1)
+			d.Set(k, k, d11)
+			d.Set(k+1, k, d21)
+			d.Set(k, k+1, d21)
+			d.Set(k+1, k+1, d22)
+			det := d11*d22 - d21*d21
+			for i := k + 2; i < n; i++ {
+				x, y := a.At(i, k), a.At(i, k+1)
+				l.Set(i, k, (x*d22-y*d21)/det)
+				l.Set(i, k+1, (y*d11-x*d21)/det)
+			}
+			for i := k + 2; i < n; i++ {
+				li0, li1 := l.At(i, k), l.At(i, k+1)
+				row := a.rowView(i)
+				for j := k + 2; j < n; j++ {
+					row[j] -= li0*a.At(j, k) + li1*a.At(j, k+1)
+				}
+			}
+		}
+		k += kstep
+	}
+
+	return LDLFactors{L: l, D: d, Pivot: piv}
+}
+
+// ldlSwap interchanges rows and columns p and q, with p < q, of the trailing
+// matrix of a from k and the rows p and q of the leading k columns of l.
+func ldlSwap(a, l *Dense, k, p, q int) {
+	n, _ := a.Dims()
+	for j := k; j < n; j++ {
+		if j == p || j == q {
+			continue
+		}
+		ap, aq := a.At(p, j), a.At(q, j)
+		a.Set(p, j, aq)
+		a.Set(j, p, aq)
+		a.Set(q, j, ap)
+		a.Set(j, q, ap)
+	}
+	app, aqq := a.At(p, p), a.At(q, q)
+	a.Set(p, p, aqq)
+	a.Set(q, q, app)
+
+	lp, lq := l.rowView(p)[:k], l.rowView(q)[:k]
+	for j := range lp {
+		lp[j], lq[j] = lq[j], lp[j]
+	}
+}
+
+// IsSingular returns whether the factored matrix is singular, which is the case
+// when D has a zero 1-by-1 block.
+func (f LDLFactors) IsSingular() bool {
+	n, _ := f.D.Dims()
+	for k := 0; k < n; k++ {
+		if f.blockSize(k) == 2 {
+			k++
+			continue
+		}
+		if f.D.At(k, k) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// blockSize returns the size of the diagonal block of D starting at k.
+func (f LDLFactors) blockSize(k int) int {
+	n, _ := f.D.Dims()
+	if k+1 < n && f.D.At(k+1, k) != 0 {
+		return 2
+	}
+	return 1
+}
+
+// Inertia returns the numbers of positive, negative and zero eigenvalues of the
+// factored matrix, which by Sylvester's law of inertia are those of D.
+func (f LDLFactors) Inertia() (pos, neg, zeros int) {
+	n, _ := f.D.Dims()
+	for k := 0; k < n; k++ {
+		if f.blockSize(k) == 1 {
+			switch v := f.D.At(k, k); {
+			case v > 0:
+				pos++
+			case v < 0:
+				neg++
+			default:
+				zeros++
+			}
+			continue
+		}
+
+		// A 2-by-2 block chosen by Bunch-Kaufman pivoting has a
+		// negative determinant and so one eigenvalue of each sign,
+		// but the signs are determined from the block in general.
+		d11, d21, d22 := f.D.At(k, k), f.D.At(k+1, k), f.D.At(k+1, k+1)
+		det := d11*d22 - d21*d21
+		switch {
+		case det < 0:
+			pos++
+			neg++
+		case det > 0 && d11+d22 > 0:
+			pos += 2
+		case det > 0:
+			neg += 2
+		case d11+d22 > 0:
+			pos++
+			zeros++
+		case d11+d22 < 0:
+			neg++
+			zeros++
+		default:
+			zeros += 2
+		}
+		k++
+	}
+	return pos, neg, zeros
+}
+
+// Solve returns a matrix x that solves a.x = b where P.a.P' = L.D.L'. The matrix
+// b must have the same number of rows as a. The matrix b is overwritten by the
+// operation. Solve will panic with ErrSingular if the factored matrix is
+// singular.
+func (f LDLFactors) Solve(b *Dense) (x *Dense) {
+	if f.IsSingular() {
+		panic(ErrSingular)
+	}
+	n, _ := f.L.Dims()
+	bm, bn := b.Dims()
+	if bm != n {
+		panic(ErrShape)
+	}
+
+	// Apply the permutation, y = P.b.
+	y := NewDense(n, bn, nil)
+	for i, p := range f.Pivot {
+		copy(y.rowView(i), b.rowView(p))
+	}
+
+	// Solve L.z = y.
+	for i := 0; i < n; i++ {
+		yi := y.rowView(i)
+		for k, lik := range f.L.rowView(i)[:i] {
+			if lik != 0 {
+				axpyUnitary(-lik, y.rowView(k), yi)
+			}
+		}
+	}
+
+	// Solve D.w = z.
+	for k := 0; k < n; k++ {
+		if f.blockSize(k) == 1 {
+			scalUnitary(1/f.D.At(k, k), y.rowView(k))
+			continue
+		}
+		d11, d21, d22 := f.D.At(k, k), f.D.At(k+1, k), f.D.At(k+1, k+1)
+		det := d11*d22 - d21*d21
+		y0, y1 := y.rowView(k), y.rowView(k+1)
+		for j := range y0 {
+			u, v := y0[j], y1[j]
+			y0[j] = (u*d22 - v*d21) / det
+			y1[j] = (v*d11 - u*d21) / det
+		}
+		k++
+	}
+
+	// Solve L'.u = w.
+	for i := n - 1; i >= 0; i-- {
+		yi := y.rowView(i)
+		for k := i + 1; k < n; k++ {
+			if lki := f.L.At(k, i); lki != 0 {
+				axpyUnitary(-lki, y.rowView(k), yi)
+			}
+		}
+	}
+
+	// Undo the permutation, x = P'.u.
+	x = b
+	for i, p := range f.Pivot {
+		copy(x.rowView(p), y.rowView(i))
+	}
+	return x
+}
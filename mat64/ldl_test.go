@@ -0,0 +1,110 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math/rand"
+)
+
+func (s *S) TestLDL(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	randSym := func(n int) *Dense {
+		a := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j <= i; j++ {
+				v := rnd.NormFloat64()
+				a.Set(i, j, v)
+				a.Set(j, i, v)
+			}
+		}
+		return a
+	}
+
+	for i, a := range []*Dense{
+		// Zero diagonal forcing a 2-by-2 pivot.
+		NewDense(2, 2, []float64{
+			0, 1,
+			1, 0,
+		}),
+		// KKT matrix.
+		SaddlePoint(NewDense(4, 2, []float64{
+			1, 2,
+			3, 4,
+			5, 6,
+			7, 9,
+		}), NewDense(1, 2, []float64{1, 1})),
+		// Positive definite.
+		NewDense(3, 3, []float64{
+			4, 1, 0,
+			1, 3, 1,
+			0, 1, 2,
+		}),
+		randSym(8),
+		randSym(25),
+	} {
+		n, _ := a.Dims()
+		f := LDL(DenseCopyOf(a))
+		c.Check(f.IsSingular(), check.Equals, false, check.Commentf("Test %d", i))
+
+		// P.a.P' = L.D.L'
+		pap := NewDense(n, n, nil)
+		for r, p := range f.Pivot {
+			for c, q := range f.Pivot {
+				pap.Set(r, c, a.At(p, q))
+			}
+		}
+		var ld, lt, ldl Dense
+		ld.Mul(f.L, f.D)
+		lt.TCopy(f.L)
+		ldl.Mul(&ld, &lt)
+		c.Check(ldl.EqualsApprox(pap, 1e-12), check.Equals, true, check.Commentf("Test %d", i))
+		for r := 0; r < n; r++ {
+			c.Check(f.L.At(r, r), check.Equals, 1.)
+			for c2 := r + 1; c2 < n; c2++ {
+				c.Check(f.L.At(r, c2), check.Equals, 0.)
+			}
+		}
+
+		// The inertia matches the eigenvalues.
+		ef := Eigen(DenseCopyOf(a), epsilon)
+		var wantPos, wantNeg int
+		for _, v := range ef.d {
+			if v > 0 {
+				wantPos++
+			} else {
+				wantNeg++
+			}
+		}
+		pos, neg, zeros := f.Inertia()
+		c.Check([]int{pos, neg, zeros}, check.DeepEquals, []int{wantPos, wantNeg, 0}, check.Commentf("Test %d", i))
+
+		b := NewDense(n, 2, nil)
+		for r := 0; r < n; r++ {
+			b.Set(r, 0, float64(r+1))
+			b.Set(r, 1, rnd.NormFloat64())
+		}
+		want := DenseCopyOf(b)
+		x := f.Solve(DenseCopyOf(b))
+		var ax Dense
+		ax.Mul(a, x)
+		c.Check(ax.EqualsApprox(want, 1e-10), check.Equals, true, check.Commentf("Test %d", i))
+	}
+}
+
+func (s *S) TestLDLSingular(c *check.C) {
+	f := LDL(NewDense(3, 3, []float64{
+		1, 0, 1,
+		0, 0, 0,
+		1, 0, 2,
+	}))
+	c.Check(f.IsSingular(), check.Equals, true)
+	pos, neg, zeros := f.Inertia()
+	c.Check([]int{pos, neg, zeros}, check.DeepEquals, []int{2, 0, 1})
+	c.Check(func() { f.Solve(NewDense(3, 1, nil)) }, check.PanicMatches, string(ErrSingular))
+
+	c.Check(func() { LDL(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrSquare))
+	c.Check(func() { LDL(NewDense(2, 2, []float64{1, 2, 3, 4})) }, check.PanicMatches, string(ErrNotSymmetric))
+}
@@ -0,0 +1,72 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// The level 1 kernels used by the inner loops of the package. They operate on
+// unit stride slices and are set at init to assembly implementations where
+// these are available for the architecture, and otherwise to the unrolled Go
+// implementations below.
+var (
+	// dotUnitary returns the inner product of x and y[:len(x)].
+	dotUnitary = dotUnitaryGo
+
+	// axpyUnitary sets y[i] += alpha*x[i] for i in [0, len(x)).
+	axpyUnitary = axpyUnitaryGo
+
+	// scalUnitary sets x[i] *= alpha for all i.
+	scalUnitary = scalUnitaryGo
+)
+
+func dotUnitaryGo(x, y []float64) float64 {
+	y = y[:len(x)]
+	var s0, s1, s2, s3 float64
+	i := 0
+	for ; i+4 <= len(x); i += 4 {
+		s0 += x[i] * y[i]
+		s1 += x[i+1] * y[i+1]
+		s2 += x[i+2] * y[i+2]
+		s3 += x[i+3] * y[i+3]
+	}
+	for ; i < len(x); i++ {
+		s0 += x[i] * y[i]
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+func axpyUnitaryGo(alpha float64, x, y []float64) {
+	y = y[:len(x)]
+	i := 0
+	for ; i+4 <= len(x); i += 4 {
+		y[i] += alpha * x[i]
+		y[i+1] += alpha * x[i+1]
+		y[i+2] += alpha * x[i+2]
+		y[i+3] += alpha * x[i+3]
+	}
+	for ; i < len(x); i++ {
+		y[i] += alpha * x[i]
+	}
+}
+
+func scalUnitaryGo(alpha float64, x []float64) {
+	i := 0
+	for ; i+4 <= len(x); i += 4 {
+		x[i] *= alpha
+		x[i+1] *= alpha
+		x[i+2] *= alpha
+		x[i+3] *= alpha
+	}
+	for ; i < len(x); i++ {
+		x[i] *= alpha
+	}
+}
+
+// mulTransVec sets dst to a'.v.
+func mulTransVec(dst []float64, a *Dense, v []float64) {
+	zero(dst)
+	r, _ := a.Dims()
+	for i := 0; i < r; i++ {
+		axpyUnitary(v[i], a.rowView(i), dst)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// SSE2 is part of the amd64 baseline, so the assembly kernels are always used.
+func init() {
+	dotUnitary = dotUnitaryAsm
+	axpyUnitary = axpyUnitaryAsm
+	scalUnitary = scalUnitaryAsm
+}
+
+// dotUnitaryAsm returns the inner product of x and y[:len(x)].
+func dotUnitaryAsm(x, y []float64) float64 {
+	if len(y) < len(x) {
+		panic(ErrShape)
+	}
+	return dotUnitarySSE2(x, y)
+}
+
+// axpyUnitaryAsm sets y[i] += alpha*x[i] for i in [0, len(x)).
+func axpyUnitaryAsm(alpha float64, x, y []float64) {
+	if len(y) < len(x) {
+		panic(ErrShape)
+	}
+	axpyUnitarySSE2(alpha, x, y)
+}
+
+// scalUnitaryAsm sets x[i] *= alpha for all i.
+func scalUnitaryAsm(alpha float64, x []float64) {
+	scalUnitarySSE2(alpha, x)
+}
+
+//go:noescape
+func dotUnitarySSE2(x, y []float64) float64
+
+//go:noescape
+func axpyUnitarySSE2(alpha float64, x, y []float64)
+
+//go:noescape
+func scalUnitarySSE2(alpha float64, x []float64)
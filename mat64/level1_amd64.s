@@ -0,0 +1,132 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// func dotUnitarySSE2(x, y []float64) float64
+TEXT ·dotUnitarySSE2(SB), NOSPLIT, $0-56
+	MOVQ x_base+0(FP), SI
+	MOVQ x_len+8(FP), CX
+	MOVQ y_base+24(FP), DI
+	XORPD X0, X0
+	XORPD X1, X1
+	MOVQ CX, BX
+	SHRQ $2, BX
+	JZ   dot_reduce
+
+dot_loop:
+	MOVUPD (SI), X2
+	MOVUPD 16(SI), X3
+	MOVUPD (DI), X4
+	MOVUPD 16(DI), X5
+	MULPD  X4, X2
+	MULPD  X5, X3
+	ADDPD  X2, X0
+	ADDPD  X3, X1
+	ADDQ   $32, SI
+	ADDQ   $32, DI
+	DECQ   BX
+	JNZ    dot_loop
+
+dot_reduce:
+	ADDPD    X1, X0
+	MOVAPD   X0, X1
+	UNPCKHPD X1, X1
+	ADDSD    X1, X0
+	ANDQ     $3, CX
+	JZ       dot_end
+
+dot_tail:
+	MOVSD (SI), X2
+	MULSD (DI), X2
+	ADDSD X2, X0
+	ADDQ  $8, SI
+	ADDQ  $8, DI
+	DECQ  CX
+	JNZ   dot_tail
+
+dot_end:
+	MOVSD X0, ret+48(FP)
+	RET
+
+// func axpyUnitarySSE2(alpha float64, x, y []float64)
+TEXT ·axpyUnitarySSE2(SB), NOSPLIT, $0-56
+	MOVSD   alpha+0(FP), X0
+	MOVQ    x_base+8(FP), SI
+	MOVQ    x_len+16(FP), CX
+	MOVQ    y_base+32(FP), DI
+	UNPCKLPD X0, X0
+	MOVQ    CX, BX
+	SHRQ    $2, BX
+	JZ      axpy_tail_start
+
+axpy_loop:
+	MOVUPD (SI), X2
+	MOVUPD 16(SI), X3
+	MULPD  X0, X2
+	MULPD  X0, X3
+	MOVUPD (DI), X4
+	MOVUPD 16(DI), X5
+	ADDPD  X2, X4
+	ADDPD  X3, X5
+	MOVUPD X4, (DI)
+	MOVUPD X5, 16(DI)
+	ADDQ   $32, SI
+	ADDQ   $32, DI
+	DECQ   BX
+	JNZ    axpy_loop
+
+axpy_tail_start:
+	ANDQ $3, CX
+	JZ   axpy_end
+
+axpy_tail:
+	MOVSD (SI), X2
+	MULSD X0, X2
+	MOVSD (DI), X3
+	ADDSD X2, X3
+	MOVSD X3, (DI)
+	ADDQ  $8, SI
+	ADDQ  $8, DI
+	DECQ  CX
+	JNZ   axpy_tail
+
+axpy_end:
+	RET
+
+// func scalUnitarySSE2(alpha float64, x []float64)
+TEXT ·scalUnitarySSE2(SB), NOSPLIT, $0-32
+	MOVSD    alpha+0(FP), X0
+	MOVQ     x_base+8(FP), SI
+	MOVQ     x_len+16(FP), CX
+	UNPCKLPD X0, X0
+	MOVQ     CX, BX
+	SHRQ     $2, BX
+	JZ       scal_tail_start
+
+scal_loop:
+	MOVUPD (SI), X2
+	MOVUPD 16(SI), X3
+	MULPD  X0, X2
+	MULPD  X0, X3
+	MOVUPD X2, (SI)
+	MOVUPD X3, 16(SI)
+	ADDQ   $32, SI
+	DECQ   BX
+	JNZ    scal_loop
+
+scal_tail_start:
+	ANDQ $3, CX
+	JZ   scal_end
+
+scal_tail:
+	MOVSD (SI), X2
+	MULSD X0, X2
+	MOVSD X2, (SI)
+	ADDQ  $8, SI
+	DECQ  CX
+	JNZ   scal_tail
+
+scal_end:
+	RET
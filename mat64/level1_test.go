@@ -0,0 +1,57 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestLevel1Kernels(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 7, 8, 9, 16, 31, 100} {
+		x := make([]float64, n)
+		y := make([]float64, n+3)
+		for i := range x {
+			x[i] = rnd.NormFloat64()
+		}
+		for i := range y {
+			y[i] = rnd.NormFloat64()
+		}
+
+		var want float64
+		for i, v := range x {
+			want += v * y[i]
+		}
+		for _, dot := range []func(x, y []float64) float64{dotUnitary, dotUnitaryGo} {
+			c.Check(math.Abs(dot(x, y)-want) <= 1e-14*float64(n+1), check.Equals, true, check.Commentf("dot n=%d", n))
+		}
+
+		for _, axpy := range []func(float64, []float64, []float64){axpyUnitary, axpyUnitaryGo} {
+			got := append([]float64(nil), y...)
+			axpy(-1.5, x, got)
+			for i := range got {
+				want := y[i]
+				if i < n {
+					want += -1.5 * x[i]
+				}
+				c.Check(got[i], check.Equals, want, check.Commentf("axpy n=%d i=%d", n, i))
+			}
+		}
+
+		for _, scal := range []func(float64, []float64){scalUnitary, scalUnitaryGo} {
+			got := append([]float64(nil), x...)
+			scal(0.25, got)
+			for i := range got {
+				c.Check(got[i], check.Equals, 0.25*x[i], check.Commentf("scal n=%d i=%d", n, i))
+			}
+		}
+	}
+
+	// Non-finite values propagate as for the scalar loops.
+	c.Check(math.IsNaN(dotUnitary([]float64{1, math.Inf(1), 2, 3, 4}, []float64{0, 0, 1, 1, 1})), check.Equals, true)
+}
@@ -0,0 +1,100 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// Durbin solves the Yule-Walker equations for the autoregressive model of order
+// p = len(r)-1 with the autocorrelation sequence r[0], ..., r[p] using the
+// Levinson-Durbin recursion in O(p^2) time. It returns the model coefficients ar,
+// such that the process satisfies x[t] = sum_k ar[k-1] x[t-k] + e[t], the
+// reflection (partial autocorrelation) coefficients of orders 1 to p, and the
+// variance of the innovation e.
+//
+// Durbin will panic with ErrSingular if the p-by-p autocorrelation matrix of the
+// Yule-Walker equations is not positive definite.
+func Durbin(r Vec) (ar, reflect Vec, variance float64) {
+	if len(r) == 0 {
+		panic(ErrZeroLength)
+	}
+	p := len(r) - 1
+	ar = make(Vec, p)
+	reflect = make(Vec, p)
+	prev := make(Vec, p)
+
+	variance = r[0]
+	for m := 0; m < p; m++ {
+		if variance <= 0 {
+			panic(ErrSingular)
+		}
+		acc := r[m+1]
+		for k := 0; k < m; k++ {
+			acc -= ar[k] * r[m-k]
+		}
+		km := acc / variance
+		copy(prev, ar[:m])
+		for k := 0; k < m; k++ {
+			ar[k] = prev[k] - km*prev[m-1-k]
+		}
+		ar[m] = km
+		reflect[m] = km
+		variance *= 1 - km*km
+	}
+	return ar, reflect, variance
+}
+
+// Levinson solves the linear system T.x = b, where T is the symmetric Toeplitz
+// matrix with first row t, using the Levinson recursion in O(n^2) time. The
+// leading principal submatrices of T must be nonsingular, as is the case when T
+// is positive definite. Levinson will panic with ErrShape if t and b differ in
+// length and with ErrSingular if a leading principal submatrix is singular.
+func Levinson(t, b Vec) Vec {
+	n := len(t)
+	if len(b) != n {
+		panic(ErrShape)
+	}
+	if n == 0 {
+		return Vec{}
+	}
+	if t[0] == 0 {
+		panic(ErrSingular)
+	}
+
+	// f holds the solution of T_m.f = e_1 for the leading m-by-m
+	// submatrix T_m. By symmetry the reverse of f solves T_m.g = e_m.
+	f := make(Vec, 1, n)
+	f[0] = 1 / t[0]
+	x := make(Vec, 1, n)
+	x[0] = b[0] / t[0]
+	prev := make(Vec, n)
+
+	for m := 1; m < n; m++ {
+		var ef, ex float64
+		for i := 0; i < m; i++ {
+			ef += t[m-i] * f[i]
+			ex += t[m-i] * x[i]
+		}
+		d := 1 - ef*ef
+		if d == 0 {
+			panic(ErrSingular)
+		}
+		copy(prev, f)
+		f = f[:m+1]
+		f[m] = 0
+		for i := 0; i <= m; i++ {
+			var g float64
+			if i > 0 {
+				g = prev[m-i]
+			}
+			f[i] = (f[i] - ef*g) / d
+		}
+
+		x = x[:m+1]
+		x[m] = 0
+		s := b[m] - ex
+		for i := 0; i <= m; i++ {
+			x[i] += s * f[m-i]
+		}
+	}
+	return x
+}
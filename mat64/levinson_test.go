@@ -0,0 +1,74 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func symToeplitz(t Vec) *Dense {
+	n := len(t)
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			k := i - j
+			if k < 0 {
+				k = -k
+			}
+			m.Set(i, j, t[k])
+		}
+	}
+	return m
+}
+
+func (s *S) TestDurbin(c *check.C) {
+	// The autocorrelation of an AR(1) process with coefficient 0.6.
+	phi := 0.6
+	r := Vec{1, phi, phi * phi, phi * phi * phi}
+	ar, reflect, variance := Durbin(r)
+	for i, want := range []float64{phi, 0, 0} {
+		c.Check(math.Abs(ar[i]-want) < 1e-14, check.Equals, true, check.Commentf("ar[%d] = %v", i, ar[i]))
+		c.Check(math.Abs(reflect[i]-want) < 1e-14, check.Equals, true, check.Commentf("reflect[%d] = %v", i, reflect[i]))
+	}
+	c.Check(math.Abs(variance-(1-phi*phi)) < 1e-14, check.Equals, true)
+
+	// A general sequence agrees with solving the Yule-Walker system directly.
+	r = Vec{4, 2, 1.5, -0.5, 0.25}
+	ar, _, variance = Durbin(r)
+	want := Solve(symToeplitz(r[:4]), NewDense(4, 1, []float64(r[1:])))
+	c.Check(NewDense(4, 1, ar).EqualsApprox(want, 1e-12), check.Equals, true)
+	v := r[0]
+	for k, a := range ar {
+		v -= a * r[k+1]
+	}
+	c.Check(math.Abs(variance-v) < 1e-12, check.Equals, true)
+
+	ar, reflect, variance = Durbin(Vec{2})
+	c.Check(len(ar), check.Equals, 0)
+	c.Check(len(reflect), check.Equals, 0)
+	c.Check(variance, check.Equals, 2.0)
+
+	c.Check(func() { Durbin(Vec{1, 1, 1}) }, check.PanicMatches, string(ErrSingular))
+}
+
+func (s *S) TestLevinson(c *check.C) {
+	for i, test := range []struct {
+		t, b Vec
+	}{
+		{Vec{3}, Vec{6}},
+		{Vec{4, 1}, Vec{1, 2}},
+		{Vec{5, 2, -1, 0.5}, Vec{1, -2, 3, 4}},
+		{Vec{1, 2, 3}, Vec{1, 1, 1}}, // Indefinite with nonsingular leading submatrices.
+	} {
+		x := Levinson(test.t, test.b)
+		want := Solve(symToeplitz(test.t), NewDense(len(test.b), 1, []float64(test.b)))
+		c.Check(NewDense(len(x), 1, x).EqualsApprox(want, 1e-12), check.Equals, true, check.Commentf("Test %d", i))
+	}
+	c.Check(len(Levinson(nil, nil)), check.Equals, 0)
+	c.Check(func() { Levinson(Vec{1, 2}, Vec{1}) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { Levinson(Vec{1, 1}, Vec{1, 1}) }, check.PanicMatches, string(ErrSingular))
+}
@@ -0,0 +1,79 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// A LinearOperator is a linear map from vectors of length c to vectors of length
+// r, represented only by its action on a vector. Routines that need only
+// products with vectors, such as PowerIteration and SpectralRadius, accept a
+// LinearOperator so that implicit operators, such as convolutions or
+// Jacobian-vector products, can be used without forming a matrix.
+//
+// Sparse, SymSparse, Toeplitz and Circulant are linear operators, and a Dense or
+// any other Matrix can be used through Operator.
+type LinearOperator interface {
+	// Dims returns the dimensions r and c of the operator.
+	Dims() (r, c int)
+
+	// Apply sets dst, of length r, to the product of the operator with x,
+	// of length c. Apply must panic with ErrShape if the lengths of dst
+	// or x do not match the dimensions of the operator.
+	Apply(dst, x []float64)
+}
+
+var (
+	_ LinearOperator = (*Sparse)(nil)
+	_ LinearOperator = (*SymSparse)(nil)
+	_ LinearOperator = (*Toeplitz)(nil)
+	_ LinearOperator = (*Circulant)(nil)
+)
+
+// Operator returns a LinearOperator whose action is the product with a. If a is
+// already a LinearOperator it is returned unchanged.
+func Operator(a Matrix) LinearOperator {
+	if op, ok := a.(LinearOperator); ok {
+		return op
+	}
+	return matrixOperator{a}
+}
+
+// matrixOperator is a LinearOperator applying a Matrix.
+type matrixOperator struct {
+	Matrix
+}
+
+func (m matrixOperator) Apply(dst, x []float64) {
+	checkApply(m, dst, x)
+	matVec(dst, m.Matrix, x)
+}
+
+// OperatorFunc returns a LinearOperator with dimensions r and c whose action is
+// given by fn, which sets its first argument, of length r, to the product of
+// the operator with its second, of length c. The lengths are checked before fn
+// is called.
+func OperatorFunc(r, c int, fn func(dst, x []float64)) LinearOperator {
+	return funcOperator{r: r, c: c, fn: fn}
+}
+
+// funcOperator is a LinearOperator with its action given by a function.
+type funcOperator struct {
+	r, c int
+	fn   func(dst, x []float64)
+}
+
+func (f funcOperator) Dims() (r, c int) { return f.r, f.c }
+
+func (f funcOperator) Apply(dst, x []float64) {
+	checkApply(f, dst, x)
+	f.fn(dst, x)
+}
+
+// checkApply panics with ErrShape if dst and x do not have the lengths required
+// by the dimensions of op.
+func checkApply(op LinearOperator, dst, x []float64) {
+	r, c := op.Dims()
+	if len(dst) != r || len(x) != c {
+		panic(ErrShape)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestLinearOperator(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	a := NewNormal(4, 3, 0, 1, rnd)
+	x := []float64{1, -2, 0.5}
+	want := make([]float64, 4)
+	for i := range want {
+		want[i] = dotUnitary(a.rowView(i), x)
+	}
+
+	sp := NewRandomSparse(4, 3, 0.5, nil, rnd)
+	spWant := sp.MulVec(nil, x)
+	circ := NewCirculant([]float64{1, 2, 3})
+	toe := NewToeplitz([]float64{1, 2, 3, 4}, []float64{1, 5, 6})
+	for _, test := range []struct {
+		op   LinearOperator
+		want []float64
+	}{
+		{op: Operator(a), want: want},
+		{op: Operator((*basicMatrix)(a)), want: want},
+		{op: Operator((*basicVectorer)(a)), want: want},
+		{op: OperatorFunc(4, 3, Operator(a).Apply), want: want},
+		{op: sp, want: spWant},
+		{op: Operator(sp.Dense()), want: spWant},
+		{op: circ, want: circ.MulVec(nil, x)},
+		{op: toe, want: toe.MulVec(nil, x)},
+	} {
+		r, cols := test.op.Dims()
+		dst := make([]float64, r)
+		test.op.Apply(dst, x[:cols])
+		for i := range dst {
+			c.Check(math.Abs(dst[i]-test.want[i]) < 1e-12, check.Equals, true)
+		}
+		c.Check(func() { test.op.Apply(make([]float64, r+1), x[:cols]) }, check.PanicMatches, string(ErrShape))
+		c.Check(func() { test.op.Apply(dst, make([]float64, cols+1)) }, check.PanicMatches, string(ErrShape))
+	}
+
+	sym := NewCooccurrenceBuilder(3, nil)
+	sym.Add(0, 1, 2, 1)
+	m := sym.Matrix()
+	dst := make([]float64, 3)
+	m.Apply(dst, x)
+	c.Check(dst, check.DeepEquals, m.MulVec(nil, x))
+	c.Check(func() { m.Apply(nil, x) }, check.PanicMatches, string(ErrShape))
+
+	// The power method on an implicit operator, the second difference
+	// operator with Dirichlet boundaries, whose largest eigenvalue is
+	// 2 - 2cos(nπ/(n+1)). Its eigenvector is not orthogonal to the
+	// starting vector of ones for odd n.
+	const n = 21
+	lap := OperatorFunc(n, n, func(dst, x []float64) {
+		for i := range dst {
+			dst[i] = 2 * x[i]
+			if i > 0 {
+				dst[i] -= x[i-1]
+			}
+			if i < n-1 {
+				dst[i] -= x[i+1]
+			}
+		}
+	})
+	lambda := 2 - 2*math.Cos(n*math.Pi/(n+1))
+	got := SpectralRadius(lap, 1e-12, 20000)
+	c.Check(math.Abs(got-lambda) < 1e-3, check.Equals, true, check.Commentf("got %v want %v", got, lambda))
+}
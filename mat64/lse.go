@@ -0,0 +1,102 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// LSE returns the matrix x that minimizes the Frobenius norm of a.x - b subject
+// to the equality constraints c.x = d, where a is m-by-n, c is p-by-n with
+// p <= n, and each column of b and d is a separate problem. The matrices a, b,
+// c and d are not modified.
+//
+// LSE uses the nullspace method. With the QR factorization c' = [Q1 Q2].[R; 0],
+// every x satisfying the constraints is x = Q1.R'^-1.d + Q2.y, and y is the
+// least squares solution of a.Q2.y = b - a.Q1.R'^-1.d found by LeastSquares.
+// Unlike ConstrainedLeastSquares, which uses the same reduction but requires a
+// unique solution, LSE requires only that c has full row rank: if a.Q2 is rank
+// deficient the minimum norm y is used, giving the minimum norm solution among
+// those that satisfy the constraints and minimize the residual.
+//
+// LSE will panic with ErrShape if the dimensions of the arguments do not agree
+// or p > n, and with ErrRankDeficient if c does not have full row rank.
+func LSE(a, b, c, d Matrix) *Dense {
+	m, n := a.Dims()
+	p, cn := c.Dims()
+	bm, bk := b.Dims()
+	dp, dk := d.Dims()
+	if cn != n || bm != m || dp != p || dk != bk || p > n {
+		panic(ErrShape)
+	}
+
+	x, q2, ok := constraintNullspace(c, d)
+	if !ok {
+		panic(ErrRankDeficient)
+	}
+	if p == n {
+		return x
+	}
+
+	// Minimize over the nullspace of c.
+	var aq2, ax0 Dense
+	aq2.Mul(a, q2)
+	ax0.Mul(a, x)
+	rhs := DenseCopyOf(b)
+	rhs.Sub(rhs, &ax0)
+	y, _ := LeastSquares(&aq2, rhs)
+
+	var q2y Dense
+	q2y.Mul(q2, y)
+	x.Add(x, &q2y)
+	return x
+}
+
+// constraintNullspace returns the particular solution x0 = Q1.R'^-1.d of the
+// constraints c.x = d and the basis Q2 of the nullspace of c, from the full QR
+// factorization c' = [Q1 Q2].[R; 0] of the p-by-n matrix c with p <= n. ok is
+// false if c does not have full row rank.
+func constraintNullspace(c, d Matrix) (x0, q2 *Dense, ok bool) {
+	p, n := c.Dims()
+	_, k := d.Dims()
+
+	// Full QR factorization of c'.
+	ct := NewDense(n, p, nil)
+	ct.TCopy(c)
+	q := householderQ(ct)
+	var q1 Dense
+	q1.View(q, 0, 0, n, p)
+	var q1t, r Dense
+	q1t.TCopy(&q1)
+	r.Mul(&q1t, ct)
+
+	var maxDiag float64
+	for k := 0; k < p; k++ {
+		maxDiag = math.Max(maxDiag, math.Abs(r.At(k, k)))
+	}
+	for k := 0; k < p; k++ {
+		if math.Abs(r.At(k, k)) <= float64(n)*epsilon*maxDiag || maxDiag == 0 {
+			return nil, nil, false
+		}
+	}
+
+	// Solve R'.w = d by forward substitution, giving the particular
+	// solution x0 = Q1.w.
+	w := DenseCopyOf(d)
+	for k := 0; k < p; k++ {
+		row := w.rowView(k)
+		for i := 0; i < k; i++ {
+			axpyUnitary(-r.At(i, k), w.rowView(i), row)
+		}
+		scalUnitary(1/r.At(k, k), row)
+	}
+	x0 = NewDense(n, k, nil)
+	if p > 0 {
+		x0.Mul(&q1, w)
+	}
+	q2 = &Dense{}
+	q2.View(q, 0, p, n, n-p)
+	return x0, q2, true
+}
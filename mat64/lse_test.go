@@ -0,0 +1,66 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math/rand"
+)
+
+func (s *S) TestLSE(c *check.C) {
+	// The constrained fit of the identity agrees with the saddle point
+	// solution.
+	a := eye()
+	b := NewDense(3, 2, []float64{
+		1, 4,
+		2, 5,
+		3, 6,
+	})
+	cm := NewDense(1, 3, []float64{1, 1, 1})
+	d := NewDense(1, 2, []float64{3, 3})
+	x := LSE(a, b, cm, d)
+	c.Check(x.EqualsApprox(NewDense(3, 2, []float64{
+		0, 0,
+		1, 1,
+		2, 2,
+	}), 1e-12), check.Equals, true, check.Commentf("got %v", x))
+
+	rnd := rand.New(rand.NewSource(1))
+	randDense := func(r, c int) *Dense {
+		m := NewDense(r, c, nil)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				m.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		return m
+	}
+	for trial := 0; trial < 5; trial++ {
+		a, b := randDense(6, 4), randDense(6, 2)
+		cm, d := randDense(2, 4), randDense(2, 2)
+		x := LSE(a, b, cm, d)
+		want := ConstrainedLeastSquares(a, b, cm, d)
+		c.Check(x.EqualsApprox(want, 1e-10), check.Equals, true, check.Commentf("trial %d", trial))
+	}
+
+	// A rank deficient a for which the saddle point system is singular
+	// still has a minimum norm solution: x0 + x1 = 2 and x2 is unobserved.
+	a = NewDense(2, 3, []float64{
+		1, 1, 0,
+		1, 1, 0,
+	})
+	b = NewDense(2, 1, []float64{2, 2})
+	cm = NewDense(1, 3, []float64{1, -1, 0})
+	d = NewDense(1, 1, []float64{0})
+	x = LSE(a, b, cm, d)
+	c.Check(x.EqualsApprox(NewDense(3, 1, []float64{1, 1, 0}), 1e-12), check.Equals, true, check.Commentf("got %v", x))
+
+	// As many constraints as unknowns determine x.
+	x = LSE(a, b, eye(), NewDense(3, 1, []float64{1, 2, 3}))
+	c.Check(x.EqualsApprox(NewDense(3, 1, []float64{1, 2, 3}), 1e-12), check.Equals, true)
+
+	c.Check(func() { LSE(a, b, NewDense(2, 3, []float64{1, 1, 1, 2, 2, 2}), NewDense(2, 1, nil)) }, check.PanicMatches, string(ErrRankDeficient))
+	c.Check(func() { LSE(a, b, NewDense(1, 2, nil), NewDense(1, 1, nil)) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,79 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"github.com/gonum/blas"
+)
+
+// Simulate computes the response of the discrete-time linear time-invariant
+// system
+//
+//  x[k+1] = a.x[k] + b.u[k]
+//  y[k]   = c.x[k] + d.u[k]
+//
+// to the input sequence held in the rows of u, starting from the initial state
+// x0. A nil x0 is treated as the zero state and a nil d as no direct feedthrough.
+// The outputs y[k] and states x[k] for each of the time steps are returned in
+// the rows of y and x.
+//
+// The input and output terms for all time steps are each computed by a single
+// matrix multiplication, leaving only the state recursion to be computed step
+// by step. Simulate will panic with ErrShape if the dimensions of the system,
+// input and initial state are not consistent.
+func Simulate(a, b, c, d *Dense, u *Dense, x0 Vec) (y, x *Dense) {
+	n, ac := a.Dims()
+	if n != ac {
+		panic(ErrSquare)
+	}
+	br, m := b.Dims()
+	p, cc := c.Dims()
+	steps, um := u.Dims()
+	if br != n || cc != n || um != m || (x0 != nil && len(x0) != n) {
+		panic(ErrShape)
+	}
+	if d != nil {
+		if dr, dc := d.Dims(); dr != p || dc != m {
+			panic(ErrShape)
+		}
+	}
+	if steps == 0 {
+		panic(ErrZeroLength)
+	}
+
+	// bu holds the input contributions b.u[k] in its rows.
+	var bt, bu Dense
+	bt.TCopy(b)
+	bu.Mul(u, &bt)
+
+	x = NewDense(steps, n, nil)
+	copy(x.rowView(0), x0)
+	amat := a.RawMatrix()
+	for k := 0; k < steps-1; k++ {
+		next := x.rowView(k + 1)
+		copy(next, bu.rowView(k))
+		blasEngine.Dgemv(
+			blas.NoTrans,
+			n, n,
+			1.,
+			amat.Data, amat.Stride,
+			x.rowView(k), 1,
+			1.,
+			next, 1,
+		)
+	}
+
+	var ct Dense
+	ct.TCopy(c)
+	y = &Dense{}
+	y.Mul(x, &ct)
+	if d != nil {
+		var dt, du Dense
+		dt.TCopy(d)
+		du.Mul(u, &dt)
+		y.Add(y, &du)
+	}
+	return y, x
+}
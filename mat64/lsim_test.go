@@ -0,0 +1,43 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSimulate(c *check.C) {
+	a := NewDense(2, 2, []float64{
+		0.5, 1,
+		0, 0.25,
+	})
+	b := NewDense(2, 1, []float64{0, 1})
+	cm := NewDense(1, 2, []float64{1, 0})
+	d := NewDense(1, 1, []float64{2})
+	u := NewDense(4, 1, []float64{1, 0, 0, 1})
+	x0 := Vec{1, 0}
+
+	y, x := Simulate(a, b, cm, d, u, x0)
+
+	// Step the system directly.
+	state := []float64{1, 0}
+	for k := 0; k < 4; k++ {
+		c.Check(x.At(k, 0), check.Equals, state[0], check.Commentf("step %d", k))
+		c.Check(x.At(k, 1), check.Equals, state[1], check.Commentf("step %d", k))
+		c.Check(y.At(k, 0), check.Equals, state[0]+2*u.At(k, 0), check.Commentf("step %d", k))
+		state = []float64{
+			0.5*state[0] + state[1],
+			0.25*state[1] + u.At(k, 0),
+		}
+	}
+
+	// Zero initial state and no feedthrough.
+	y, _ = Simulate(a, b, cm, nil, u, nil)
+	c.Check(y.Equals(NewDense(4, 1, []float64{0, 0, 1, 0.75})), check.Equals, true)
+
+	c.Check(func() { Simulate(a, b, cm, d, u, Vec{1}) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { Simulate(a, b, cm, NewDense(2, 1, nil), u, nil) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { Simulate(a, b, cm, d, NewDense(4, 2, nil), nil) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,176 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// LeastSquares returns the matrix x that minimizes the Frobenius norm of a.x - b,
+// treating each column of b as a separate right hand side, and the two norms of
+// the columns of the residual a.x - b. The matrices a and b are not modified.
+//
+// If a has at least as many rows as columns and its QR factorization shows it to
+// be of numerically full column rank, x is found from the QR factorization.
+// Otherwise, for a rank deficient or underdetermined a, x is the minimum norm
+// solution found from the singular value decomposition of a, ignoring singular
+// values that are negligible as determined by SVDFactors.Rank.
+//
+// LeastSquares will panic with ErrShape if a and b do not have the same number
+// of rows.
+func LeastSquares(a, b *Dense) (x *Dense, resid []float64) {
+	m, n := a.Dims()
+	bm, bn := b.Dims()
+	if bm != m {
+		panic(ErrShape)
+	}
+
+	if m >= n && n > 0 {
+		qr := QR(DenseCopyOf(a))
+		var maxDiag, minDiag float64
+		minDiag = math.Inf(1)
+		for _, v := range qr.rDiag {
+			maxDiag = math.Max(maxDiag, math.Abs(v))
+			minDiag = math.Min(minDiag, math.Abs(v))
+		}
+		if minDiag > float64(m)*epsilon*maxDiag {
+			x = NewDense(n, bn, nil)
+			x.Copy(qr.Solve(DenseCopyOf(b)))
+		}
+	}
+	if x == nil {
+		x = minNormSolve(a, b)
+	}
+
+	var r Dense
+	r.Mul(a, x)
+	r.Sub(&r, b)
+	resid = make([]float64, bn)
+	for i := 0; i < m; i++ {
+		for j, v := range r.rowView(i) {
+			resid[j] = math.Hypot(resid[j], v)
+		}
+	}
+	return x, resid
+}
+
+// minNormSolve returns the minimum norm least squares solution of a.x = b from
+// the singular value decomposition of a.
+func minNormSolve(a, b *Dense) *Dense {
+	m, n := a.Dims()
+	_, bn := b.Dims()
+	x := NewDense(n, bn, nil)
+	if m == 0 || n == 0 || bn == 0 {
+		return x
+	}
+
+	// Work with a tall matrix, exchanging the roles of u and v for a
+	// wide one.
+	var f SVDFactors
+	var u, v *Dense
+	if m >= n {
+		f = SVD(DenseCopyOf(a), epsilon, small, true, true)
+		u, v = f.U, f.V
+	} else {
+		var at Dense
+		at.TCopy(a)
+		f = SVD(&at, epsilon, small, true, true)
+		u, v = f.V, f.U
+	}
+
+	// x = sum over i < rank of v_i.u_i'.b/sigma_i.
+	ub := make([]float64, bn)
+	for i := 0; i < f.Rank(epsilon); i++ {
+		zero(ub)
+		for k := 0; k < m; k++ {
+			axpyUnitary(u.At(k, i), b.rowView(k), ub)
+		}
+		scalUnitary(1/f.Sigma[i], ub)
+		for k := 0; k < n; k++ {
+			axpyUnitary(v.At(k, i), ub, x.rowView(k))
+		}
+	}
+	return x
+}
+
+// ErrNegativeWeight is the panic value used by WeightedLeastSquares when a
+// weight or the regularization parameter is negative.
+const ErrNegativeWeight = Error("mat64: negative weight")
+
+// WeightedLeastSquares returns the matrix x that minimizes, for each column of b,
+//
+//  sum_i w[i].(a.x - b)_i² + lambda.|x|²
+//
+// where the observation weights w scale the squared residuals of the rows of a
+// and lambda is the Tikhonov or ridge regularization parameter. If w is nil all
+// weights are one. It also returns the weighted residual norms, the square roots
+// of the first sum for each column, excluding the regularization term. The
+// matrices a and b are not modified.
+//
+// The problem is solved as the ordinary least squares problem with the
+// augmented matrix [W^½.a; sqrt(lambda).I] and right hand side [W^½.b; 0] by
+// LeastSquares, avoiding the loss of accuracy of forming the normal equations.
+// With lambda positive the augmented matrix has full column rank, so the
+// solution is unique even when a is rank deficient or has fewer rows than
+// columns.
+//
+// WeightedLeastSquares will panic with ErrShape if a and b do not have the same
+// number of rows or w is not nil and does not have one weight per row, and with
+// ErrNegativeWeight if a weight or lambda is negative.
+func WeightedLeastSquares(a, b *Dense, w []float64, lambda float64) (x *Dense, resid []float64) {
+	m, n := a.Dims()
+	bm, bn := b.Dims()
+	if bm != m || (w != nil && len(w) != m) {
+		panic(ErrShape)
+	}
+	if lambda < 0 {
+		panic(ErrNegativeWeight)
+	}
+	for _, v := range w {
+		if v < 0 {
+			panic(ErrNegativeWeight)
+		}
+	}
+
+	rows := m
+	if lambda > 0 {
+		rows += n
+	}
+	aug := NewDense(rows, n, nil)
+	rhs := NewDense(rows, bn, nil)
+	for i := 0; i < m; i++ {
+		ar, br := aug.rowView(i), rhs.rowView(i)
+		copy(ar, a.rowView(i))
+		copy(br, b.rowView(i))
+		if w != nil {
+			sw := math.Sqrt(w[i])
+			scalUnitary(sw, ar)
+			scalUnitary(sw, br)
+		}
+	}
+	if lambda > 0 {
+		sl := math.Sqrt(lambda)
+		for j := 0; j < n; j++ {
+			aug.Set(m+j, j, sl)
+		}
+	}
+
+	x, _ = LeastSquares(aug, rhs)
+
+	var r Dense
+	r.Mul(a, x)
+	r.Sub(&r, b)
+	resid = make([]float64, bn)
+	for i := 0; i < m; i++ {
+		sw := 1.0
+		if w != nil {
+			sw = math.Sqrt(w[i])
+		}
+		for j, v := range r.rowView(i) {
+			resid[j] = math.Hypot(resid[j], sw*v)
+		}
+	}
+	return x, resid
+}
@@ -0,0 +1,135 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math"
+)
+
+func (s *S) TestLeastSquares(c *check.C) {
+	for i, t := range []struct {
+		a, b  *Dense
+		x     *Dense
+		resid []float64
+	}{
+		{
+			// Exact fit of y = 1 + 2t and a noisy column.
+			a: NewDense(4, 2, []float64{
+				1, 0,
+				1, 1,
+				1, 2,
+				1, 3,
+			}),
+			b: NewDense(4, 2, []float64{
+				1, 1,
+				3, 2,
+				5, 2,
+				7, 3,
+			}),
+			x: NewDense(2, 2, []float64{
+				1, 1.1,
+				2, 0.6,
+			}),
+			resid: []float64{0, math.Sqrt(0.2)},
+		},
+		{
+			// Rank deficient: the second column duplicates the first,
+			// so the minimum norm solution splits the coefficient.
+			a: NewDense(3, 2, []float64{
+				1, 1,
+				2, 2,
+				3, 3,
+			}),
+			b: NewDense(3, 1, []float64{
+				2,
+				4,
+				6,
+			}),
+			x: NewDense(2, 1, []float64{
+				1,
+				1,
+			}),
+			resid: []float64{0},
+		},
+		{
+			// Underdetermined: the minimum norm solution of x+y+z = 3.
+			a: NewDense(1, 3, []float64{1, 1, 1}),
+			b: NewDense(1, 1, []float64{3}),
+			x: NewDense(3, 1, []float64{
+				1,
+				1,
+				1,
+			}),
+			resid: []float64{0},
+		},
+	} {
+		a, b := DenseCopyOf(t.a), DenseCopyOf(t.b)
+		x, resid := LeastSquares(t.a, t.b)
+		c.Check(x.EqualsApprox(t.x, 1e-12), check.Equals, true, check.Commentf("Test %d: got %v", i, x))
+		c.Check(len(resid), check.Equals, len(t.resid))
+		for j, r := range resid {
+			c.Check(math.Abs(r-t.resid[j]) < 1e-12, check.Equals, true, check.Commentf("Test %d: column %d", i, j))
+		}
+		c.Check(t.a.Equals(a), check.Equals, true)
+		c.Check(t.b.Equals(b), check.Equals, true)
+	}
+
+	c.Check(func() { LeastSquares(NewDense(3, 2, nil), NewDense(2, 1, nil)) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestWeightedLeastSquares(c *check.C) {
+	a := NewDense(4, 2, []float64{
+		1, 0,
+		1, 1,
+		1, 2,
+		1, 3,
+	})
+	b := NewDense(4, 1, []float64{
+		1,
+		2,
+		2,
+		3,
+	})
+
+	// Unit weights and no regularization agree with LeastSquares.
+	want, wantResid := LeastSquares(a, b)
+	x, resid := WeightedLeastSquares(a, b, nil, 0)
+	c.Check(x.EqualsApprox(want, 1e-12), check.Equals, true)
+	c.Check(math.Abs(resid[0]-wantResid[0]) < 1e-12, check.Equals, true)
+
+	// A zero weight removes an observation, and integer weights
+	// replicate observations.
+	x, _ = WeightedLeastSquares(a, b, []float64{1, 0, 1, 1}, 0)
+	want, _ = LeastSquares(NewDense(3, 2, []float64{1, 0, 1, 2, 1, 3}), NewDense(3, 1, []float64{1, 2, 3}))
+	c.Check(x.EqualsApprox(want, 1e-12), check.Equals, true)
+	x, _ = WeightedLeastSquares(a, b, []float64{2, 1, 1, 1}, 0)
+	want, _ = LeastSquares(
+		NewDense(5, 2, []float64{1, 0, 1, 0, 1, 1, 1, 2, 1, 3}),
+		NewDense(5, 1, []float64{1, 1, 2, 2, 3}),
+	)
+	c.Check(x.EqualsApprox(want, 1e-12), check.Equals, true)
+
+	// Ridge regression agrees with the regularized normal equations
+	// (a'.a + lambda.I).x = a'.b.
+	const lambda = 0.5
+	x, _ = WeightedLeastSquares(a, b, nil, lambda)
+	var at, ata, atb Dense
+	at.TCopy(a)
+	ata.Mul(&at, a)
+	for i := 0; i < 2; i++ {
+		ata.Set(i, i, ata.At(i, i)+lambda)
+	}
+	atb.Mul(&at, b)
+	c.Check(x.EqualsApprox(Solve(&ata, &atb), 1e-12), check.Equals, true)
+
+	// Regularization makes a rank deficient problem well posed.
+	x, _ = WeightedLeastSquares(NewDense(2, 2, []float64{1, 1, 1, 1}), NewDense(2, 1, []float64{2, 2}), nil, 1e-3)
+	c.Check(math.Abs(x.At(0, 0)-x.At(1, 0)) < 1e-12, check.Equals, true)
+
+	c.Check(func() { WeightedLeastSquares(a, b, []float64{1}, 0) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { WeightedLeastSquares(a, b, []float64{1, -1, 1, 1}, 0) }, check.PanicMatches, string(ErrNegativeWeight))
+	c.Check(func() { WeightedLeastSquares(a, b, nil, -1) }, check.PanicMatches, string(ErrNegativeWeight))
+}
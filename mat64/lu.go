@@ -7,12 +7,18 @@ package mat64
 
 import (
 	"math"
+
+	"github.com/gonum/blas"
 )
 
 type LUFactors struct {
 	LU    *Dense
 	Pivot []int
 	Sign  int
+
+	// singular records that a LAPACK backend reported U to be
+	// singular.
+	singular bool
 }
 
 // LUD performs an LU Decomposition for an m-by-n matrix a.
@@ -27,7 +33,17 @@ type LUFactors struct {
 // singular, so the LUD will never fail. The primary use of the LU decomposition
 // is in the solution of square systems of simultaneous linear equations.  This
 // will fail if IsSingular() returns true.
+//
+// If a LAPACK backend is registered, the decomposition is computed by its Dgetrf.
 func LU(a *Dense) LUFactors {
+	if p := startProfile(); p != nil {
+		m, n := a.Dims()
+		defer p.stop("LU", factorFlops(m, n))
+	}
+	if lapackEngine != nil {
+		return luLapack(a)
+	}
+
 	// Use a "left-looking", dot-product, Crout/Doolittle algorithm.
 	m, n := a.Dims()
 	lu := a
@@ -53,10 +69,7 @@ func LU(a *Dense) LUFactors {
 
 			// Most of the time is spent in the following dot product.
 			kmax := min(i, j)
-			var s float64
-			for k, v := range luRowi[:kmax] {
-				s += v * luColj[k]
-			}
+			s := dotUnitary(luRowi[:kmax], luColj)
 
 			luColj[i] -= s
 			luRowi[j] = luColj[i]
@@ -87,7 +100,7 @@ func LU(a *Dense) LUFactors {
 		}
 	}
 
-	return LUFactors{lu, piv, sign}
+	return LUFactors{LU: lu, Pivot: piv, Sign: sign}
 }
 
 // LUGaussian performs an LU Decomposition for an m-by-n matrix a using Gaussian elimination.
@@ -147,12 +160,16 @@ func LUGaussian(a *Dense) LUFactors {
 		}
 	}
 
-	return LUFactors{lu, piv, sign}
+	return LUFactors{LU: lu, Pivot: piv, Sign: sign}
 }
 
 // IsSingular returns whether the the upper triangular factor and hence a is
-// singular.
+// singular, either because it has a zero on its diagonal or because the LAPACK
+// backend that computed the decomposition reported it singular.
 func (f LUFactors) IsSingular() bool {
+	if f.singular {
+		return true
+	}
 	lu := f.LU
 	_, n := lu.Dims()
 	for j := 0; j < n; j++ {
@@ -195,14 +212,24 @@ func (f LUFactors) U() *Dense {
 	return u
 }
 
+// P returns the row permutation of the LU decomposition such that P.a = L.U.
+// The permutation is a copy of Pivot, so it may be modified without affecting
+// the factorization.
+func (f LUFactors) P() Permutation {
+	return append(Permutation(nil), f.Pivot...)
+}
+
 // Det returns the determinant of matrix a decomposed into lu. The matrix
-// a must have been square.
+// a must have been square. The determinant of a singular matrix is zero.
 func (f LUFactors) Det() float64 {
 	lu, sign := f.LU, f.Sign
 	m, n := lu.Dims()
 	if m != n {
 		panic(ErrSquare)
 	}
+	if f.singular {
+		return 0
+	}
 	d := float64(sign)
 	for j := 0; j < n; j++ {
 		d *= lu.At(j, j)
@@ -228,6 +255,22 @@ func (f LUFactors) Solve(b *Dense) (x *Dense) {
 	nx := bn
 	x = pivotRows(b, piv)
 
+	if blasEngine != nil && m == n && n > 0 && nx > 0 {
+		blasEngine.Dtrsm(
+			blas.Left, blas.Lower, blas.NoTrans, blas.Unit,
+			n, nx,
+			1, lu.mat.Data, lu.mat.Stride,
+			x.mat.Data, x.mat.Stride,
+		)
+		blasEngine.Dtrsm(
+			blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit,
+			n, nx,
+			1, lu.mat.Data, lu.mat.Stride,
+			x.mat.Data, x.mat.Stride,
+		)
+		return x
+	}
+
 	// Solve L*Y = B(piv,:)
 	for k := 0; k < n; k++ {
 		for i := k + 1; i < n; i++ {
@@ -0,0 +1,253 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+func init() {
+	gob.Register(&Dense{})
+	gob.Register(LUFactors{})
+	gob.Register(QRFactor{})
+	gob.Register(LQFactor{})
+	gob.Register(CholeskyFactor{})
+	gob.Register(EigenFactors{})
+	gob.Register(SVDFactors{})
+	gob.Register(SchurFactors{})
+	gob.Register(BlockDiagFactors{})
+	gob.Register(PencilFactors{})
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*Dense)(nil)
+	_ encoding.BinaryUnmarshaler = (*Dense)(nil)
+	_ json.Marshaler             = (*Dense)(nil)
+	_ json.Unmarshaler           = (*Dense)(nil)
+)
+
+// MarshalBinary encodes the receiver in the native format described for WriteTo.
+// It implements the encoding.BinaryMarshaler interface.
+func (m *Dense) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(nativeHeaderSize + 8*m.mat.Rows*m.mat.Cols)
+	_, err := m.WriteTo(&buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes the native format encoded matrix in b into the receiver,
+// replacing its previous value and allocating new storage. It implements the
+// encoding.BinaryUnmarshaler interface.
+func (m *Dense) UnmarshalBinary(b []byte) error {
+	_, err := m.ReadFrom(bytes.NewReader(b))
+	return err
+}
+
+// denseJSON is the JSON representation of a Dense, holding the elements in
+// row-major order.
+type denseJSON struct {
+	Rows int         `json:"rows"`
+	Cols int         `json:"cols"`
+	Data []jsonFloat `json:"data"`
+}
+
+// jsonFloat is a float64 that represents the non-finite values, which have no
+// JSON number representation, as the strings "NaN", "+Inf" and "-Inf".
+type jsonFloat float64
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return strconv.AppendFloat(nil, v, 'g', -1, 64), nil
+}
+
+func (f *jsonFloat) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case `"NaN"`:
+		*f = jsonFloat(math.NaN())
+		return nil
+	case `"+Inf"`, `"Inf"`:
+		*f = jsonFloat(math.Inf(1))
+		return nil
+	case `"-Inf"`:
+		*f = jsonFloat(math.Inf(-1))
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*f = jsonFloat(v)
+	return nil
+}
+
+// MarshalJSON encodes the receiver as a JSON object holding the number of rows
+// and columns and the elements in row-major order, for example
+//
+//  {"rows":2,"cols":2,"data":[1,2,3,4]}
+//
+// Non-finite elements are encoded as the strings "NaN", "+Inf" and "-Inf". It
+// implements the json.Marshaler interface.
+func (m *Dense) MarshalJSON() ([]byte, error) {
+	v := denseJSON{
+		Rows: m.mat.Rows,
+		Cols: m.mat.Cols,
+		Data: make([]jsonFloat, 0, m.mat.Rows*m.mat.Cols),
+	}
+	for i := 0; i < m.mat.Rows; i++ {
+		for _, e := range m.rowView(i) {
+			v.Data = append(v.Data, jsonFloat(e))
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a matrix in the representation described for MarshalJSON
+// into the receiver, replacing its previous value and allocating new storage. It
+// returns ErrShape if the number of elements does not match the dimensions. It
+// implements the json.Unmarshaler interface.
+func (m *Dense) UnmarshalJSON(b []byte) error {
+	var v denseJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.Rows < 0 || v.Cols < 0 || (v.Cols != 0 && v.Rows > maxInt/v.Cols) ||
+		len(v.Data) != v.Rows*v.Cols {
+		return ErrShape
+	}
+	data := make([]float64, len(v.Data))
+	for i, e := range v.Data {
+		data[i] = float64(e)
+	}
+	m.mat = RawMatrix{Rows: v.Rows, Cols: v.Cols, Stride: v.Cols, Data: data}
+	return nil
+}
+
+// The factor types with unexported fields are encoded through these exported
+// mirrors. The factor types with only exported fields are handled directly by
+// the encoding/gob package.
+type (
+	luFactorsGob struct {
+		LU       *Dense
+		Pivot    []int
+		Sign     int
+		Singular bool
+	}
+	qrFactorGob struct {
+		QR    *Dense
+		RDiag []float64
+	}
+	lqFactorGob struct {
+		LQ    *Dense
+		LDiag []float64
+	}
+	eigenFactorsGob struct {
+		V    *Dense
+		D, E []float64
+	}
+	svdFactorsGob struct {
+		U     *Dense
+		Sigma []float64
+		V     *Dense
+		M, N  int
+	}
+)
+
+func gobMarshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+func gobUnmarshal(b []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(b)).Decode(v)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f LUFactors) MarshalBinary() ([]byte, error) {
+	return gobMarshal(luFactorsGob{LU: f.LU, Pivot: f.Pivot, Sign: f.Sign, Singular: f.singular})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (f *LUFactors) UnmarshalBinary(b []byte) error {
+	var g luFactorsGob
+	if err := gobUnmarshal(b, &g); err != nil {
+		return err
+	}
+	*f = LUFactors{LU: g.LU, Pivot: g.Pivot, Sign: g.Sign, singular: g.Singular}
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f QRFactor) MarshalBinary() ([]byte, error) {
+	return gobMarshal(qrFactorGob{QR: f.QR, RDiag: f.rDiag})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (f *QRFactor) UnmarshalBinary(b []byte) error {
+	var g qrFactorGob
+	if err := gobUnmarshal(b, &g); err != nil {
+		return err
+	}
+	*f = QRFactor{QR: g.QR, rDiag: g.RDiag}
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f LQFactor) MarshalBinary() ([]byte, error) {
+	return gobMarshal(lqFactorGob{LQ: f.LQ, LDiag: f.lDiag})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (f *LQFactor) UnmarshalBinary(b []byte) error {
+	var g lqFactorGob
+	if err := gobUnmarshal(b, &g); err != nil {
+		return err
+	}
+	*f = LQFactor{LQ: g.LQ, lDiag: g.LDiag}
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f EigenFactors) MarshalBinary() ([]byte, error) {
+	return gobMarshal(eigenFactorsGob{V: f.V, D: f.d, E: f.e})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (f *EigenFactors) UnmarshalBinary(b []byte) error {
+	var g eigenFactorsGob
+	if err := gobUnmarshal(b, &g); err != nil {
+		return err
+	}
+	*f = EigenFactors{V: g.V, d: g.D, e: g.E}
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f SVDFactors) MarshalBinary() ([]byte, error) {
+	return gobMarshal(svdFactorsGob{U: f.U, Sigma: f.Sigma, V: f.V, M: f.m, N: f.n})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (f *SVDFactors) UnmarshalBinary(b []byte) error {
+	var g svdFactorsGob
+	if err := gobUnmarshal(b, &g); err != nil {
+		return err
+	}
+	*f = SVDFactors{U: g.U, Sigma: g.Sigma, V: g.V, m: g.M, n: g.N}
+	return nil
+}
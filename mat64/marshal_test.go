@@ -0,0 +1,95 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"reflect"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestDenseMarshalBinary(c *check.C) {
+	for i, m := range []*Dense{
+		NewDense(2, 3, []float64{1, 2, 3, 4, 5, math.Inf(-1)}),
+		NewDense(1, 1, []float64{-0.5}),
+		{},
+	} {
+		b, err := m.MarshalBinary()
+		c.Assert(err, check.IsNil, check.Commentf("Test %d", i))
+		var got Dense
+		c.Assert(got.UnmarshalBinary(b), check.IsNil, check.Commentf("Test %d", i))
+		c.Check(got.Equals(m), check.Equals, true, check.Commentf("Test %d", i))
+	}
+
+	var m Dense
+	c.Check(m.UnmarshalBinary([]byte("GONUMMAT")), check.NotNil)
+
+	// A matrix view is encoded as a contiguous matrix.
+	var v Dense
+	v.View(NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}), 1, 1, 2, 2)
+	b, err := v.MarshalBinary()
+	c.Assert(err, check.IsNil)
+	c.Assert(m.UnmarshalBinary(b), check.IsNil)
+	c.Check(m.Equals(NewDense(2, 2, []float64{5, 6, 8, 9})), check.Equals, true)
+}
+
+func (s *S) TestGobFactors(c *check.C) {
+	a := NewDense(3, 3, []float64{
+		4, 1, 2,
+		1, 5, 3,
+		2, 3, 6,
+	})
+	for i, v := range []interface{}{
+		a,
+		LU(DenseCopyOf(a)),
+		QR(DenseCopyOf(a)),
+		LQ(DenseCopyOf(a)),
+		Cholesky(DenseCopyOf(a)),
+		Eigen(DenseCopyOf(a), epsilon),
+		SVD(DenseCopyOf(a), epsilon, small, true, true),
+		SVD(DenseCopyOf(a), epsilon, small, false, false),
+		Schur(DenseCopyOf(a), epsilon),
+	} {
+		// Encode through an interface value to exercise registration.
+		var buf bytes.Buffer
+		err := gob.NewEncoder(&buf).Encode(&v)
+		c.Assert(err, check.IsNil, check.Commentf("Test %d", i))
+		var got interface{}
+		err = gob.NewDecoder(&buf).Decode(&got)
+		c.Assert(err, check.IsNil, check.Commentf("Test %d", i))
+		c.Check(reflect.DeepEqual(got, v), check.Equals, true, check.Commentf("Test %d: %T", i, v))
+	}
+}
+
+func (s *S) TestDenseJSON(c *check.C) {
+	var v Dense
+	v.View(NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}), 1, 0, 2, 2)
+	b, err := json.Marshal(&v)
+	c.Assert(err, check.IsNil)
+	c.Check(string(b), check.Equals, `{"rows":2,"cols":2,"data":[4,5,7,8]}`)
+
+	m := NewDense(1, 4, []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e-300})
+	b, err = json.Marshal(struct{ M *Dense }{m})
+	c.Assert(err, check.IsNil)
+	c.Check(string(b), check.Equals, `{"M":{"rows":1,"cols":4,"data":["NaN","+Inf","-Inf",1e-300]}}`)
+
+	var got struct{ M *Dense }
+	c.Assert(json.Unmarshal(b, &got), check.IsNil)
+	c.Check(math.IsNaN(got.M.At(0, 0)), check.Equals, true)
+	c.Check(got.M.At(0, 1), check.Equals, math.Inf(1))
+	c.Check(got.M.At(0, 2), check.Equals, math.Inf(-1))
+	c.Check(got.M.At(0, 3), check.Equals, 1e-300)
+
+	var d Dense
+	c.Check(d.UnmarshalJSON([]byte(`{"rows":2,"cols":2,"data":[1,2,3]}`)), check.Equals, ErrShape)
+	c.Check(d.UnmarshalJSON([]byte(`{"rows":4294967296,"cols":4294967296,"data":[]}`)), check.Equals, ErrShape)
+	c.Check(d.UnmarshalJSON([]byte(`{"rows":1,"cols":1,"data":["x"]}`)), check.NotNil)
+	c.Assert(d.UnmarshalJSON([]byte(`{"rows": 2, "cols": 1, "data": [0.5, -2]}`)), check.IsNil)
+	c.Check(d.Equals(NewDense(2, 1, []float64{0.5, -2})), check.Equals, true)
+}
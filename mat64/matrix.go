@@ -8,6 +8,18 @@
 // be either the correct dimensions for the result or the zero value for the concrete type
 // of the matrix. In the latter case, matrix data is allocated and stored in the receiver.
 // If the matrix dimensions do not match the result, the method must panic.
+//
+// Concurrency
+//
+// A Dense, and any other matrix type in the package, may be read by any number
+// of goroutines at once, including as an operand of an operation whose receiver
+// is a different matrix. A goroutine that writes to a matrix, by setting an
+// element or using it as the receiver of an operation, must have exclusive
+// access to it and to every view that shares its storage; no other goroutine may
+// read or write any of them until the write is complete. Matrices that are
+// shared between goroutines with concurrent writers may be held in a SyncDense.
+// The package level settings such as SetMaxProcs and Register must not be
+// changed concurrently with operations on matrices.
 package mat64
 
 // Matrix is the basic matrix interface type.
@@ -218,6 +230,24 @@ type Augmenter interface {
 // An ApplyFunc takes a row/column index and element value and returns some function of that tuple.
 type ApplyFunc func(r, c int, v float64) float64
 
+// FuseApply returns an ApplyFunc that applies each of fs in order to an element,
+// passing the result of each function to the next. Applying the returned function
+// makes a single pass over the matrix rather than one pass for each function.
+func FuseApply(fs ...ApplyFunc) ApplyFunc {
+	switch len(fs) {
+	case 0:
+		return func(_, _ int, v float64) float64 { return v }
+	case 1:
+		return fs[0]
+	}
+	return func(r, c int, v float64) float64 {
+		for _, f := range fs {
+			v = f(r, c, v)
+		}
+		return v
+	}
+}
+
 // An Applyer can apply an Applyfunc f to each of the elements of the matrix represented by a,
 // placing the resulting matrix in the receiver.
 type Applyer interface {
@@ -248,6 +278,12 @@ type BandWidther interface {
 	BandWidth() (k1, k2 int)
 }
 
+// A Solver represents a factorization of a square matrix a and can return the solution x of a.x = b.
+// Solve may overwrite b. LUFactors, CholeskyFactor and LDLFactors are Solvers.
+type Solver interface {
+	Solve(b *Dense) (x *Dense)
+}
+
 // RawMatrix represents a cblas native representation of a matrix.
 type RawMatrix struct {
 	Rows, Cols int
@@ -295,6 +331,9 @@ func Det(a Matrix) float64 {
 // Inverse returns the inverse or pseudoinverse of the matrix a.
 func Inverse(a Matrix) *Dense {
 	m, _ := a.Dims()
+	if p := startProfile(); p != nil {
+		defer p.stop("Inverse", 8*m*m*m/3)
+	}
 	d := make([]float64, m*m)
 	for i := 0; i < m*m; i += m + 1 {
 		d[i] = 1
@@ -305,6 +344,11 @@ func Inverse(a Matrix) *Dense {
 
 // Solve returns a matrix x that satisfies ax = b.
 func Solve(a, b Matrix) (x *Dense) {
+	if p := startProfile(); p != nil {
+		m, n := a.Dims()
+		_, k := b.Dims()
+		defer p.stop("Solve", solveFlops(m, n, k))
+	}
 	switch m, n := a.Dims(); {
 	case m == n:
 		return LU(DenseCopyOf(a)).Solve(DenseCopyOf(b))
@@ -357,6 +401,24 @@ func MaybeFloat(fn FloatPanicker) (f float64, err error) {
 	return fn(), nil
 }
 
+// A DensePanicker is a function that returns a *Dense and may panic.
+type DensePanicker func() *Dense
+
+// MaybeDense will recover a panic with a type matrix.Error from fn, and return this error.
+// Any other error is re-panicked.
+func MaybeDense(fn DensePanicker) (m *Dense, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(Error); ok {
+				err = e
+				return
+			}
+			panic(r)
+		}
+	}()
+	return fn(), nil
+}
+
 // Must can be used to wrap a function returning an error.
 // If the returned error is not nil, Must will panic.
 func Must(err error) {
@@ -382,6 +444,11 @@ const (
 	ErrIllegalStride   = Error("mat64: illegal stride")
 	ErrPivot           = Error("mat64: malformed pivot list")
 	ErrNoEngine        = Error("mat64: no blas engine registered: call Register()")
+
+	ErrRowAccess           = Error("index error: row access out of bounds")
+	ErrColAccess           = Error("index error: column access out of bounds")
+	ErrRankDeficient       = Error("mat64: matrix is rank deficient")
+	ErrNotPositiveDefinite = Error("mat64: matrix not symmetric positive definite")
 )
 
 func min(a, b int) int {
@@ -404,5 +471,5 @@ func use(f []float64, l int) []float64 {
 	if l <= cap(f) {
 		return f[:l]
 	}
-	return make([]float64, l)
+	return newFloats(l)
 }
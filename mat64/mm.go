@@ -0,0 +1,314 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MMFormat specifies the storage format of a MatrixMarket file.
+type MMFormat int
+
+const (
+	// MMArray stores every element of the matrix in column-major order.
+	MMArray MMFormat = iota
+	// MMCoordinate stores the non-zero elements of the matrix as
+	// one-based row, column and value triplets.
+	MMCoordinate
+)
+
+// MMSymmetry specifies the symmetry structure of a MatrixMarket file.
+type MMSymmetry int
+
+const (
+	// MMGeneral stores all the elements of the matrix.
+	MMGeneral MMSymmetry = iota
+	// MMSymmetric stores only the lower triangle of a symmetric matrix.
+	MMSymmetric
+	// MMSkewSymmetric stores only the strictly lower triangle of a
+	// skew-symmetric matrix.
+	MMSkewSymmetric
+)
+
+const (
+	ErrMMFormat      = Error("mat64: invalid MatrixMarket data")
+	ErrMMUnsupported = Error("mat64: unsupported MatrixMarket type")
+	ErrNotSymmetric  = Error("mat64: matrix is not symmetric")
+)
+
+const mmBanner = "%%MatrixMarket"
+
+// ReadMatrixMarket reads a matrix in the MatrixMarket exchange format from r.
+// Array and coordinate formats with real, integer or pattern fields and general,
+// symmetric or skew-symmetric structure are supported. Elements of a pattern
+// matrix are read as one. Matrices with no rows or columns are accepted. The
+// elements are read before the matrix is allocated, so truncated data is
+// reported as an error, but a coordinate file describes a dense matrix of the
+// size given in its header however few entries it lists.
+func ReadMatrixMarket(r io.Reader) (*Dense, error) {
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrMMFormat
+	}
+	header := strings.Fields(strings.ToLower(sc.Text()))
+	if len(header) != 5 || header[0] != strings.ToLower(mmBanner) || header[1] != "matrix" {
+		return nil, ErrMMFormat
+	}
+
+	var format MMFormat
+	switch header[2] {
+	case "array":
+		format = MMArray
+	case "coordinate":
+		format = MMCoordinate
+	default:
+		return nil, ErrMMUnsupported
+	}
+	pattern := false
+	switch header[3] {
+	case "real", "integer":
+	case "pattern":
+		if format == MMArray {
+			return nil, ErrMMFormat
+		}
+		pattern = true
+	default:
+		return nil, ErrMMUnsupported
+	}
+	var sym MMSymmetry
+	switch header[4] {
+	case "general":
+		sym = MMGeneral
+	case "symmetric":
+		sym = MMSymmetric
+	case "skew-symmetric":
+		sym = MMSkewSymmetric
+	default:
+		return nil, ErrMMUnsupported
+	}
+
+	// next returns the fields of the next line that is not a comment.
+	next := func() ([]string, error) {
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			if line == "" || line[0] == '%' {
+				continue
+			}
+			return strings.Fields(line), nil
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrMMFormat
+	}
+
+	size, err := next()
+	if err != nil {
+		return nil, err
+	}
+	want := 2
+	if format == MMCoordinate {
+		want = 3
+	}
+	dims, err := parseInts(size, want)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := dims[0], dims[1]
+	if rows < 0 || cols < 0 || (cols != 0 && rows > maxInt/8/cols) {
+		return nil, ErrMMFormat
+	}
+	if sym != MMGeneral && rows != cols {
+		return nil, ErrMMFormat
+	}
+
+	// The entries are read before the matrix is allocated, so that a
+	// size line claiming more elements than the data holds is reported
+	// as an error rather than allocating storage for them.
+	var entries []mmEntry
+	if format == MMArray {
+		for j := 0; j < cols; j++ {
+			start := 0
+			switch sym {
+			case MMSymmetric:
+				start = j
+			case MMSkewSymmetric:
+				start = j + 1
+			}
+			for i := start; i < rows; i++ {
+				f, err := next()
+				if err != nil {
+					return nil, err
+				}
+				if len(f) != 1 {
+					return nil, ErrMMFormat
+				}
+				v, err := strconv.ParseFloat(f[0], 64)
+				if err != nil {
+					return nil, ErrMMFormat
+				}
+				entries = append(entries, mmEntry{i, j, v})
+			}
+		}
+	} else {
+		nnz := dims[2]
+		if nnz < 0 {
+			return nil, ErrMMFormat
+		}
+		for k := 0; k < nnz; k++ {
+			f, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if pattern {
+				if len(f) != 2 {
+					return nil, ErrMMFormat
+				}
+				f = append(f, "1")
+			} else if len(f) != 3 {
+				return nil, ErrMMFormat
+			}
+			idx, err := parseInts(f[:2], 2)
+			if err != nil {
+				return nil, err
+			}
+			i, j := idx[0]-1, idx[1]-1
+			if i < 0 || i >= rows || j < 0 || j >= cols {
+				return nil, ErrMMFormat
+			}
+			if (sym == MMSymmetric && i < j) || (sym == MMSkewSymmetric && i <= j) {
+				return nil, ErrMMFormat
+			}
+			v, err := strconv.ParseFloat(f[2], 64)
+			if err != nil {
+				return nil, ErrMMFormat
+			}
+			entries = append(entries, mmEntry{i, j, v})
+		}
+	}
+
+	m := NewDense(rows, cols, nil)
+	for _, e := range entries {
+		m.Set(e.i, e.j, e.v)
+		switch sym {
+		case MMSymmetric:
+			m.Set(e.j, e.i, e.v)
+		case MMSkewSymmetric:
+			m.Set(e.j, e.i, -e.v)
+		}
+	}
+	return m, nil
+}
+
+// mmEntry is an element read from a MatrixMarket file.
+type mmEntry struct {
+	i, j int
+	v    float64
+}
+
+func parseInts(f []string, n int) ([]int, error) {
+	if len(f) != n {
+		return nil, ErrMMFormat
+	}
+	v := make([]int, n)
+	for i, s := range f {
+		var err error
+		v[i], err = strconv.Atoi(s)
+		if err != nil {
+			return nil, ErrMMFormat
+		}
+	}
+	return v, nil
+}
+
+// WriteMatrixMarket writes the matrix a to w in the MatrixMarket exchange format
+// with a real field using the given storage format and symmetry structure. For
+// MMSymmetric and MMSkewSymmetric, WriteMatrixMarket returns ErrNotSymmetric if
+// a does not have the requested structure.
+func WriteMatrixMarket(w io.Writer, a Matrix, format MMFormat, sym MMSymmetry) error {
+	rows, cols := a.Dims()
+
+	var formatName, symName string
+	switch format {
+	case MMArray:
+		formatName = "array"
+	case MMCoordinate:
+		formatName = "coordinate"
+	default:
+		return ErrMMUnsupported
+	}
+	switch sym {
+	case MMGeneral:
+		symName = "general"
+	case MMSymmetric, MMSkewSymmetric:
+		if rows != cols {
+			return ErrSquare
+		}
+		sign := 1.0
+		symName = "symmetric"
+		if sym == MMSkewSymmetric {
+			sign = -1
+			symName = "skew-symmetric"
+		}
+		for i := 0; i < rows; i++ {
+			for j := 0; j <= i; j++ {
+				if a.At(i, j) != sign*a.At(j, i) {
+					return ErrNotSymmetric
+				}
+			}
+		}
+	default:
+		return ErrMMUnsupported
+	}
+
+	// start returns the first stored row of column j.
+	start := func(j int) int {
+		switch sym {
+		case MMSymmetric:
+			return j
+		case MMSkewSymmetric:
+			return j + 1
+		}
+		return 0
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix %s real %s\n", mmBanner, formatName, symName)
+	if format == MMArray {
+		fmt.Fprintf(bw, "%d %d\n", rows, cols)
+		for j := 0; j < cols; j++ {
+			for i := start(j); i < rows; i++ {
+				bw.WriteString(strconv.FormatFloat(a.At(i, j), 'g', -1, 64))
+				bw.WriteByte('\n')
+			}
+		}
+		return bw.Flush()
+	}
+
+	var nnz int
+	for j := 0; j < cols; j++ {
+		for i := start(j); i < rows; i++ {
+			if a.At(i, j) != 0 {
+				nnz++
+			}
+		}
+	}
+	fmt.Fprintf(bw, "%d %d %d\n", rows, cols, nnz)
+	for j := 0; j < cols; j++ {
+		for i := start(j); i < rows; i++ {
+			if v := a.At(i, j); v != 0 {
+				fmt.Fprintf(bw, "%d %d %s\n", i+1, j+1, strconv.FormatFloat(v, 'g', -1, 64))
+			}
+		}
+	}
+	return bw.Flush()
+}
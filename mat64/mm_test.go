@@ -0,0 +1,125 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bytes"
+	"strings"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestMatrixMarketRoundTrip(c *check.C) {
+	general := NewDense(3, 2, []float64{
+		1, 0,
+		-2.5, 3,
+		0, 1e-300,
+	})
+	sym := NewDense(3, 3, []float64{
+		4, 1, 0,
+		1, 5, -2,
+		0, -2, 6,
+	})
+	skew := NewDense(3, 3, []float64{
+		0, 1, -3,
+		-1, 0, 2,
+		3, -2, 0,
+	})
+	for i, test := range []struct {
+		a   *Dense
+		sym MMSymmetry
+	}{
+		{general, MMGeneral},
+		{sym, MMGeneral},
+		{sym, MMSymmetric},
+		{skew, MMSkewSymmetric},
+	} {
+		for _, format := range []MMFormat{MMArray, MMCoordinate} {
+			var buf bytes.Buffer
+			err := WriteMatrixMarket(&buf, test.a, format, test.sym)
+			c.Assert(err, check.IsNil, check.Commentf("Test %d format %d", i, format))
+			got, err := ReadMatrixMarket(&buf)
+			c.Assert(err, check.IsNil, check.Commentf("Test %d format %d", i, format))
+			c.Check(got.Equals(test.a), check.Equals, true, check.Commentf("Test %d format %d", i, format))
+		}
+	}
+
+	var buf bytes.Buffer
+	c.Check(WriteMatrixMarket(&buf, general, MMArray, MMSymmetric), check.Equals, ErrSquare)
+	c.Check(WriteMatrixMarket(&buf, skew, MMArray, MMSymmetric), check.Equals, ErrNotSymmetric)
+}
+
+func (s *S) TestReadMatrixMarket(c *check.C) {
+	for i, test := range []struct {
+		in   string
+		want *Dense
+		err  error
+	}{
+		{
+			in: `%%MatrixMarket matrix coordinate pattern symmetric
+% a comment
+
+2 2 2
+1 1
+2 1
+`,
+			want: NewDense(2, 2, []float64{1, 1, 1, 0}),
+		},
+		{
+			in: `%%MatrixMarket matrix array integer general
+2 2
+1
+2
+3
+4
+`,
+			want: NewDense(2, 2, []float64{1, 3, 2, 4}),
+		},
+		{
+			in:  "%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+			err: ErrMMUnsupported,
+		},
+		{
+			in:  "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+			err: ErrMMFormat,
+		},
+		{
+			in:  "%%MatrixMarket matrix array real general\n2 2\n1\n2\n3\n",
+			err: ErrMMFormat,
+		},
+		{
+			in:  "not a matrix\n",
+			err: ErrMMFormat,
+		},
+		{
+			in:   "%%MatrixMarket matrix coordinate real general\n0 3 0\n",
+			want: NewDense(0, 3, nil),
+		},
+		{
+			in:   "%%MatrixMarket matrix array real general\n0 0\n",
+			want: NewDense(0, 0, nil),
+		},
+		{
+			in:  "%%MatrixMarket matrix coordinate real general\n4000000000 4000000000 0\n",
+			err: ErrMMFormat,
+		},
+		{
+			in:  "%%MatrixMarket matrix array real general\n100000 100000\n1\n",
+			err: ErrMMFormat,
+		},
+		{
+			in:  "%%MatrixMarket matrix array real general\n-1 2\n",
+			err: ErrMMFormat,
+		},
+	} {
+		got, err := ReadMatrixMarket(strings.NewReader(test.in))
+		if test.err != nil {
+			c.Check(err, check.Equals, test.err, check.Commentf("Test %d", i))
+			continue
+		}
+		c.Assert(err, check.IsNil, check.Commentf("Test %d", i))
+		c.Check(got.Equals(test.want), check.Equals, true, check.Commentf("Test %d", i))
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"os"
+)
+
+// MappedDense is a Dense matrix whose elements are held in a file in the native
+// format described for WriteTo. Where the platform supports it the file is
+// memory mapped, so that matrices larger than the available memory can be used
+// and only the parts of the file that are accessed are read.
+//
+// Close releases the mapping. Neither the matrix nor any matrix sharing its
+// storage, such as a view of it, may be used after Close has been called, since
+// the storage is then no longer mapped and accessing it faults.
+type MappedDense struct {
+	*Dense
+
+	file     *os.File
+	data     []byte
+	writable bool
+}
+
+// OpenMapped opens the native format matrix file at path. If writable is true,
+// changes to the matrix elements are written to the file, otherwise they are
+// private to the matrix and discarded when it is closed. Column-major files and platforms without memory mapping
+// support are handled by reading the elements into memory.
+func OpenMapped(path string, writable bool) (*MappedDense, error) {
+	flag := os.O_RDONLY
+	if writable {
+		flag = os.O_RDWR
+	}
+	f, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fi.Size() < nativeHeaderSize {
+		f.Close()
+		return nil, ErrNativeFormat
+	}
+	return mapFile(f, int(fi.Size()), writable)
+}
+
+// CreateMapped creates a writable native format matrix file at path holding an
+// r-by-c matrix of zeros, and returns the mapped matrix. CreateMapped returns
+// ErrShape if r or c is negative or the file size would overflow an int.
+func CreateMapped(path string, r, c int) (*MappedDense, error) {
+	if r < 0 || c < 0 || (c != 0 && r > (maxInt-nativeHeaderSize)/8/c) {
+		return nil, ErrShape
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return nil, err
+	}
+	h := nativeHeader{
+		version: nativeVersion,
+		dtype:   nativeFloat64,
+		order:   nativeRowMajor,
+		rows:    r,
+		cols:    c,
+	}
+	size := nativeHeaderSize + 8*r*c
+	_, err = f.Write(h.marshal())
+	if err == nil {
+		err = f.Truncate(int64(size))
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return mapFile(f, size, true)
+}
+
+// Close releases the mapping and closes the file. If the matrix is writable,
+// Close also commits the changes to stable storage before returning.
+func (m *MappedDense) Close() error {
+	err := m.unmap()
+	if m.writable && err == nil {
+		// Unmapping leaves the changes in the page cache; they are
+		// only known to have reached the disk once the file is synced.
+		err = m.file.Sync()
+	}
+	if cerr := m.file.Close(); err == nil {
+		err = cerr
+	}
+	m.Dense = nil
+	m.data = nil
+	return err
+}
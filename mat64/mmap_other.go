@@ -0,0 +1,35 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package mat64
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the matrix into memory on platforms without memory mapping.
+func mapFile(f *os.File, size int, writable bool) (*MappedDense, error) {
+	d := &Dense{}
+	if _, err := d.ReadFrom(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &MappedDense{Dense: d, file: f, writable: writable}, nil
+}
+
+// unmap writes the matrix back to the file if it is writable.
+func (m *MappedDense) unmap() error {
+	if !m.writable {
+		return nil
+	}
+	if _, err := m.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := m.Dense.WriteTo(m.file)
+	return err
+}
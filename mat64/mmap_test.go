@@ -0,0 +1,81 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestMappedDense(c *check.C) {
+	dir, err := ioutil.TempDir("", "mat64")
+	c.Assert(err, check.IsNil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "m.mat")
+
+	want := NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	f, err := os.Create(path)
+	c.Assert(err, check.IsNil)
+	_, err = want.WriteTo(f)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Close(), check.IsNil)
+
+	m, err := OpenMapped(path, false)
+	c.Assert(err, check.IsNil)
+	c.Check(m.Equals(want), check.Equals, true)
+	var sum Dense
+	sum.Add(m, want)
+	c.Check(sum.At(2, 1), check.Equals, 12.0)
+	c.Assert(m.Close(), check.IsNil)
+
+	// Changes to a writable mapping are persisted.
+	m, err = OpenMapped(path, true)
+	c.Assert(err, check.IsNil)
+	m.Set(1, 0, -3)
+	c.Assert(m.Close(), check.IsNil)
+	m, err = OpenMapped(path, false)
+	c.Assert(err, check.IsNil)
+	c.Check(m.At(1, 0), check.Equals, -3.0)
+
+	// Changes to a read-only mapping are private and are not persisted.
+	m.Set(1, 0, 8)
+	m.Copy(NewDense(1, 1, []float64{9}))
+	c.Check(m.At(0, 0), check.Equals, 9.0)
+	c.Check(m.At(1, 0), check.Equals, 8.0)
+	c.Assert(m.Close(), check.IsNil)
+	m, err = OpenMapped(path, false)
+	c.Assert(err, check.IsNil)
+	c.Check(m.At(0, 0), check.Equals, 1.0)
+	c.Check(m.At(1, 0), check.Equals, -3.0)
+	c.Assert(m.Close(), check.IsNil)
+
+	m, err = CreateMapped(filepath.Join(dir, "z.mat"), 4, 5)
+	c.Assert(err, check.IsNil)
+	r, cols := m.Dims()
+	c.Check(r, check.Equals, 4)
+	c.Check(cols, check.Equals, 5)
+	m.Set(3, 4, 7)
+	c.Assert(m.Close(), check.IsNil)
+	b, err := ioutil.ReadFile(filepath.Join(dir, "z.mat"))
+	c.Assert(err, check.IsNil)
+	var got Dense
+	c.Assert(got.UnmarshalBinary(b), check.IsNil)
+	c.Check(got.At(3, 4), check.Equals, 7.0)
+	c.Check(got.At(0, 0), check.Equals, 0.0)
+
+	_, err = CreateMapped(filepath.Join(dir, "huge.mat"), maxInt/4, 4)
+	c.Check(err, check.Equals, ErrShape)
+	_, err = os.Stat(filepath.Join(dir, "huge.mat"))
+	c.Check(os.IsNotExist(err), check.Equals, true)
+
+	c.Assert(ioutil.WriteFile(path, []byte("short"), 0666), check.IsNil)
+	_, err = OpenMapped(path, false)
+	c.Check(err, check.Equals, ErrNativeFormat)
+	_, err = OpenMapped(filepath.Join(dir, "missing"), false)
+	c.Check(err, check.NotNil)
+}
@@ -0,0 +1,50 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package mat64
+
+import (
+	"os"
+	"syscall"
+)
+
+func mapFile(f *os.File, size int, writable bool) (*MappedDense, error) {
+	// A read-only file is mapped copy-on-write so that the matrix can
+	// be modified like any other without the changes reaching the file.
+	flags := syscall.MAP_PRIVATE
+	if writable {
+		flags = syscall.MAP_SHARED
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, flags)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	d, err := NativeView(data)
+	if err != nil {
+		syscall.Munmap(data)
+		f.Close()
+		return nil, err
+	}
+	if writable {
+		if h, _ := unmarshalNativeHeader(data); h.order != nativeRowMajor || !littleEndian {
+			// The elements were copied, so changes could not be
+			// reflected in the file.
+			syscall.Munmap(data)
+			f.Close()
+			return nil, ErrNativeFormat
+		}
+	}
+	return &MappedDense{Dense: d, file: f, data: data, writable: writable}, nil
+}
+
+func (m *MappedDense) unmap() error {
+	if m.data == nil {
+		return nil
+	}
+	return syscall.Munmap(m.data)
+}
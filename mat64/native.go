@@ -0,0 +1,212 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"unsafe"
+)
+
+// The native format is a simple binary representation of a matrix. It consists of
+// a 64 byte header followed by the matrix elements as little-endian IEEE-754
+// float64 values stored contiguously in the order given by the header. The header
+// layout is:
+//
+//  offset  size  field
+//       0     8  magic "GONUMMAT"
+//       8     4  version, currently 1
+//      12     4  element type, 1 for float64
+//      16     4  storage order, 0 for row-major and 1 for column-major
+//      20     4  reserved, zero
+//      24     8  number of rows
+//      32     8  number of columns
+//      40    24  reserved, zero
+//
+// All integers are little-endian. Since the header is 64 bytes long, the element
+// data is 64 byte aligned when the encoded matrix is placed at an aligned offset,
+// so that it may be used directly from a memory mapping.
+const (
+	nativeMagic      = "GONUMMAT"
+	nativeVersion    = 1
+	nativeHeaderSize = 64
+
+	nativeFloat64 = 1
+
+	nativeRowMajor = 0
+	nativeColMajor = 1
+
+	// nativeChunk is the number of elements decoded by each read of
+	// ReadFrom, bounding the storage allocated ahead of the data that
+	// has actually been read.
+	nativeChunk = 1 << 13
+
+	maxInt = int(^uint(0) >> 1)
+)
+
+const (
+	ErrNativeFormat  = Error("mat64: invalid native format")
+	ErrNativeVersion = Error("mat64: unsupported native format version")
+)
+
+type nativeHeader struct {
+	version uint32
+	dtype   uint32
+	order   uint32
+	rows    int
+	cols    int
+}
+
+func (h nativeHeader) marshal() []byte {
+	b := make([]byte, nativeHeaderSize)
+	copy(b, nativeMagic)
+	binary.LittleEndian.PutUint32(b[8:], h.version)
+	binary.LittleEndian.PutUint32(b[12:], h.dtype)
+	binary.LittleEndian.PutUint32(b[16:], h.order)
+	binary.LittleEndian.PutUint64(b[24:], uint64(h.rows))
+	binary.LittleEndian.PutUint64(b[32:], uint64(h.cols))
+	return b
+}
+
+func unmarshalNativeHeader(b []byte) (nativeHeader, error) {
+	if len(b) < nativeHeaderSize || string(b[:len(nativeMagic)]) != nativeMagic {
+		return nativeHeader{}, ErrNativeFormat
+	}
+	h := nativeHeader{
+		version: binary.LittleEndian.Uint32(b[8:]),
+		dtype:   binary.LittleEndian.Uint32(b[12:]),
+		order:   binary.LittleEndian.Uint32(b[16:]),
+	}
+	if h.version == 0 || h.version > nativeVersion {
+		return nativeHeader{}, ErrNativeVersion
+	}
+	rows := binary.LittleEndian.Uint64(b[24:])
+	cols := binary.LittleEndian.Uint64(b[32:])
+	if h.dtype != nativeFloat64 || h.order > nativeColMajor ||
+		rows > math.MaxInt32 || cols > math.MaxInt32 {
+		return nativeHeader{}, ErrNativeFormat
+	}
+	// The size of the element data in bytes must be representable.
+	if cols != 0 && rows > uint64(maxInt/8)/cols {
+		return nativeHeader{}, ErrNativeFormat
+	}
+	h.rows, h.cols = int(rows), int(cols)
+	return h, nil
+}
+
+// WriteTo writes the receiver to w in the native format, in row-major order.
+// It implements the io.WriterTo interface.
+func (m *Dense) WriteTo(w io.Writer) (int64, error) {
+	h := nativeHeader{
+		version: nativeVersion,
+		dtype:   nativeFloat64,
+		order:   nativeRowMajor,
+		rows:    m.mat.Rows,
+		cols:    m.mat.Cols,
+	}
+	n, err := w.Write(h.marshal())
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	buf := make([]byte, 8*m.mat.Cols)
+	for i := 0; i < m.mat.Rows; i++ {
+		for j, v := range m.rowView(i) {
+			binary.LittleEndian.PutUint64(buf[8*j:], math.Float64bits(v))
+		}
+		n, err = w.Write(buf)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// ReadFrom reads a matrix in the native format from r into the receiver,
+// replacing its previous value and allocating new storage. The elements are read
+// incrementally, so a header claiming more elements than r holds results in
+// io.ErrUnexpectedEOF rather than a large allocation. It implements the
+// io.ReaderFrom interface.
+func (m *Dense) ReadFrom(r io.Reader) (int64, error) {
+	hb := make([]byte, nativeHeaderSize)
+	n, err := io.ReadFull(r, hb)
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+	h, err := unmarshalNativeHeader(hb)
+	if err != nil {
+		return read, err
+	}
+
+	size := h.rows * h.cols
+	data := make([]float64, 0, min(size, nativeChunk))
+	buf := make([]byte, 8*min(size, nativeChunk))
+	for len(data) < size {
+		k := min(size-len(data), nativeChunk)
+		n, err = io.ReadFull(r, buf[:8*k])
+		read += int64(n)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return read, err
+		}
+		for i := 0; i < k; i++ {
+			data = append(data, math.Float64frombits(binary.LittleEndian.Uint64(buf[8*i:])))
+		}
+	}
+	m.mat = nativeRawMatrix(h, data)
+	return read, nil
+}
+
+// NativeView returns a matrix holding the native format encoded matrix in b.
+// When the host is little-endian and the element data in b is suitably aligned,
+// the returned matrix shares its storage with b, so no copy is made and changes
+// to the matrix elements are reflected in b; this allows a memory mapped file to
+// be used as matrix storage. Otherwise, and always for column-major encodings,
+// the elements are copied.
+func NativeView(b []byte) (*Dense, error) {
+	h, err := unmarshalNativeHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	b = b[nativeHeaderSize:]
+	n := h.rows * h.cols
+	if len(b)/8 < n {
+		return nil, ErrNativeFormat
+	}
+
+	var data []float64
+	if n != 0 && h.order == nativeRowMajor && littleEndian && uintptr(unsafe.Pointer(&b[0]))%unsafe.Alignof(float64(0)) == 0 {
+		data = unsafe.Slice((*float64)(unsafe.Pointer(&b[0])), n)
+	} else {
+		data = make([]float64, n)
+		for i := range data {
+			data[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[8*i:]))
+		}
+	}
+	return &Dense{nativeRawMatrix(h, data)}, nil
+}
+
+// nativeRawMatrix returns a RawMatrix for the native format data, transposing
+// column-major data.
+func nativeRawMatrix(h nativeHeader, data []float64) RawMatrix {
+	mat := RawMatrix{Rows: h.rows, Cols: h.cols, Stride: h.cols, Data: data}
+	if h.order == nativeColMajor {
+		var t Dense
+		t.TCopy(&Dense{RawMatrix{Rows: h.cols, Cols: h.rows, Stride: h.rows, Data: data}})
+		mat = t.mat
+	}
+	return mat
+}
+
+var littleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
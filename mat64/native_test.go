@@ -0,0 +1,123 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestNativeRoundTrip(c *check.C) {
+	for i, test := range []*Dense{
+		NewDense(1, 1, []float64{1}),
+		NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+		NewDense(3, 2, []float64{1, -2, 3, inf, 5, -6}),
+	} {
+		var buf bytes.Buffer
+		n, err := test.WriteTo(&buf)
+		c.Check(err, check.Equals, nil)
+		c.Check(n, check.Equals, int64(buf.Len()), check.Commentf("Test %d", i))
+
+		b := append([]byte(nil), buf.Bytes()...)
+
+		var got Dense
+		m, err := got.ReadFrom(&buf)
+		c.Check(err, check.Equals, nil)
+		c.Check(m, check.Equals, n, check.Commentf("Test %d", i))
+		c.Check(got.Equals(test), check.Equals, true, check.Commentf("Test %d", i))
+
+		view, err := NativeView(b)
+		c.Check(err, check.Equals, nil)
+		c.Check(view.Equals(test), check.Equals, true, check.Commentf("Test %d", i))
+		if littleEndian {
+			view.Set(0, 0, 42)
+			got.Reset()
+			got.ReadFrom(bytes.NewReader(b))
+			c.Check(got.At(0, 0), check.Equals, 42.0, check.Commentf("Test %d: view does not share storage", i))
+		}
+	}
+
+	// A strided view is written without its padding.
+	a := NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	var sub, got Dense
+	sub.View(a, 1, 1, 2, 2)
+	var buf bytes.Buffer
+	sub.WriteTo(&buf)
+	got.ReadFrom(&buf)
+	c.Check(got.Equals(&sub), check.Equals, true)
+}
+
+func (s *S) TestNativeColMajor(c *check.C) {
+	h := nativeHeader{
+		version: nativeVersion,
+		dtype:   nativeFloat64,
+		order:   nativeColMajor,
+		rows:    2,
+		cols:    3,
+	}
+	b := h.marshal()
+	for _, v := range []float64{1, 4, 2, 5, 3, 6} {
+		b = append(b, make([]byte, 8)...)
+		binary.LittleEndian.PutUint64(b[len(b)-8:], math.Float64bits(v))
+	}
+	want := NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	view, err := NativeView(b)
+	c.Check(err, check.Equals, nil)
+	c.Check(view.Equals(want), check.Equals, true)
+
+	var got Dense
+	_, err = got.ReadFrom(bytes.NewReader(b))
+	c.Check(err, check.Equals, nil)
+	c.Check(got.Equals(want), check.Equals, true)
+}
+
+func (s *S) TestNativeErrors(c *check.C) {
+	var buf bytes.Buffer
+	NewDense(2, 2, []float64{1, 2, 3, 4}).WriteTo(&buf)
+	b := buf.Bytes()
+
+	var m Dense
+	_, err := m.ReadFrom(bytes.NewReader(b[:len(b)-1]))
+	c.Check(err, check.Equals, io.ErrUnexpectedEOF)
+	_, err = NativeView(b[:len(b)-1])
+	c.Check(err, check.Equals, ErrNativeFormat)
+
+	bad := append([]byte(nil), b...)
+	bad[0] = 'X'
+	_, err = NativeView(bad)
+	c.Check(err, check.Equals, ErrNativeFormat)
+
+	bad = append([]byte(nil), b...)
+	bad[8] = nativeVersion + 1
+	_, err = m.ReadFrom(bytes.NewReader(bad))
+	c.Check(err, check.Equals, ErrNativeVersion)
+
+	// A corrupt header whose element data size overflows is rejected.
+	bad = nativeHeader{version: nativeVersion, dtype: nativeFloat64, rows: 1 << 30, cols: 1 << 30}.marshal()
+	if maxInt>>32 == 0 {
+		// The size already overflows a 32-bit int at 2^15 by 2^15.
+		bad = nativeHeader{version: nativeVersion, dtype: nativeFloat64, rows: 1 << 15, cols: 1 << 15}.marshal()
+	}
+	_, err = NativeView(bad)
+	c.Check(err, check.Equals, ErrNativeFormat)
+	_, err = m.ReadFrom(bytes.NewReader(bad))
+	c.Check(err, check.Equals, ErrNativeFormat)
+	c.Check(m.UnmarshalBinary(bad), check.Equals, ErrNativeFormat)
+
+	// A header claiming more elements than are present is detected without
+	// allocating storage for all of them.
+	bad = nativeHeader{version: nativeVersion, dtype: nativeFloat64, rows: 1 << 12, cols: 1 << 12}.marshal()
+	bad = append(bad, make([]byte, 16)...)
+	_, err = NativeView(bad)
+	c.Check(err, check.Equals, ErrNativeFormat)
+	_, err = m.ReadFrom(bytes.NewReader(bad))
+	c.Check(err, check.Equals, io.ErrUnexpectedEOF)
+	c.Check(m.UnmarshalBinary(bad), check.Equals, io.ErrUnexpectedEOF)
+}
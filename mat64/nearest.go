@@ -0,0 +1,119 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// NearestPSD returns the symmetric matrix nearest to the square matrix a in the
+// Frobenius norm whose eigenvalues are all at least minEig. With minEig zero the
+// result is the nearest symmetric positive semi-definite matrix, as given by
+// Higham, "Computing a nearest symmetric positive semidefinite matrix", Linear
+// Algebra Appl. 103:103-118, 1988: the eigenvalues of the symmetric part
+// (a+a')/2 that are below minEig are raised to it and the eigenvectors kept. A
+// small positive minEig gives a matrix that Cholesky accepts, allowing a
+// slightly indefinite covariance estimate to be repaired before factorization.
+// NearestPSD will panic with ErrSquare if a is not square. The matrix a is not
+// modified.
+func NearestPSD(a *Dense, minEig float64) *Dense {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	b := NewDense(r, r, nil)
+	b.TCopy(a)
+	b.Add(b, a)
+	b.Scale(0.5, b)
+	return psdProject(b, minEig)
+}
+
+// psdProject returns V.max(Λ, minEig).V' for the symmetric a = V.Λ.V'. The
+// matrix a is overwritten.
+func psdProject(a *Dense, minEig float64) *Dense {
+	n, _ := a.Dims()
+	if n == 0 {
+		return a
+	}
+	Symmetrize(a)
+	eig := Eigen(a, epsilon)
+	vals := eig.Values()
+	vd := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		row, vr := vd.rowView(i), eig.V.rowView(i)
+		for j, v := range vals {
+			row[j] = vr[j] * math.Max(real(v), minEig)
+		}
+	}
+	var vt Dense
+	vt.TCopy(eig.V)
+	x := NewDense(n, n, nil)
+	x.Mul(vd, &vt)
+	Symmetrize(x)
+	return x
+}
+
+// NearestCorrelation returns the correlation matrix, the symmetric positive
+// semi-definite matrix with unit diagonal, nearest to the symmetric part of the
+// square matrix a in the Frobenius norm, using the alternating projections
+// method with Dykstra's correction of Higham, "Computing the nearest
+// correlation matrix - a problem from finance", IMA J. Numer. Anal. 22:329-343,
+// 2002. This repairs an approximate correlation matrix, such as one estimated
+// from incomplete data, that is not positive semi-definite.
+//
+// The iteration stops when the relative changes in the iterates and the relative
+// distance between the two projections are below tol, or after maxIter
+// iterations. The returned matrix has exactly unit diagonal and may have
+// eigenvalues that are negative to within the tolerance. NearestCorrelation will
+// panic with ErrSquare if a is not square. The matrix a is not modified.
+func NearestCorrelation(a *Dense, tol float64, maxIter int) *Dense {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	y := NewDense(n, n, nil)
+	y.TCopy(a)
+	y.Add(y, a)
+	y.Scale(0.5, y)
+	for i := 0; i < n; i++ {
+		y.Set(i, i, 1)
+	}
+	if n == 0 {
+		return y
+	}
+
+	ds := NewDense(n, n, nil)
+	var x *Dense
+	var r, diff Dense
+	for iter := 1; iter <= maxIter; iter++ {
+		// Project onto the positive semi-definite matrices with
+		// Dykstra's correction, then onto the unit diagonal matrices.
+		r.Sub(y, ds)
+		xNew := psdProject(DenseCopyOf(&r), 0)
+		ds.Sub(xNew, &r)
+		yNew := DenseCopyOf(xNew)
+		for i := 0; i < n; i++ {
+			yNew.Set(i, i, 1)
+		}
+
+		change := math.Inf(1)
+		if x != nil {
+			diff.Sub(xNew, x)
+			change = diff.Norm(0) / xNew.Norm(0)
+		}
+		diff.Sub(yNew, y)
+		change = math.Max(change, diff.Norm(0)/yNew.Norm(0))
+		diff.Sub(yNew, xNew)
+		change = math.Max(change, diff.Norm(0)/yNew.Norm(0))
+		x, y = xNew, yNew
+		if progress != nil {
+			progress("NearestCorrelation", iter, change)
+		}
+		if change <= tol {
+			break
+		}
+	}
+	return y
+}
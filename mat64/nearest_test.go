@@ -0,0 +1,99 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestNearestPSD(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// A positive definite matrix is its own nearest.
+	a := NewSPD([]float64{1, 2, 3, 4}, rnd)
+	c.Check(NearestPSD(a, 0).EqualsApprox(a, 1e-12), check.Equals, true)
+
+	for _, n := range []int{1, 3, 8} {
+		// A symmetric indefinite matrix with known eigenvalues.
+		eigs := make([]float64, n)
+		for i := range eigs {
+			eigs[i] = rnd.NormFloat64()
+		}
+		q := NewOrthogonal(n, n, rnd)
+		a := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				var v float64
+				for k, e := range eigs {
+					v += q.At(i, k) * e * q.At(j, k)
+				}
+				a.Set(i, j, v)
+			}
+		}
+		Symmetrize(a)
+		// Adding a skew-symmetric part does not change the result.
+		skew := NewNormal(n, n, 0, 1, rnd)
+		var skewT Dense
+		skewT.TCopy(skew)
+		skew.Sub(skew, &skewT)
+		nonsym := DenseCopyOf(a)
+		nonsym.Add(nonsym, skew)
+		orig := DenseCopyOf(nonsym)
+
+		for _, minEig := range []float64{0, 1e-6, 0.5} {
+			x := NearestPSD(nonsym, minEig)
+			c.Check(nonsym.Equals(orig), check.Equals, true)
+			c.Check(symmetric(x), check.Equals, true)
+			got := symEigenvalues(x)
+			c.Check(got[0] >= minEig-1e-12, check.Equals, true)
+			if minEig > 0 {
+				c.Check(Cholesky(DenseCopyOf(x)).SPD, check.Equals, true)
+			}
+
+			// The distance from the symmetric part is that of the
+			// eigenvalues raised to minEig.
+			var want float64
+			for _, e := range eigs {
+				want = math.Hypot(want, math.Max(e, minEig)-e)
+			}
+			var diff Dense
+			diff.Sub(x, a)
+			c.Check(math.Abs(diff.Norm(0)-want) < 1e-10, check.Equals, true)
+		}
+	}
+	c.Check(func() { NearestPSD(NewDense(2, 3, nil), 0) }, check.PanicMatches, string(ErrSquare))
+}
+
+func (s *S) TestNearestCorrelation(c *check.C) {
+	// The example of Higham (2002).
+	a := NewDense(3, 3, []float64{
+		1, 1, 0,
+		1, 1, 1,
+		0, 1, 1,
+	})
+	orig := DenseCopyOf(a)
+	x := NearestCorrelation(a, 1e-12, 1000)
+	c.Check(a.Equals(orig), check.Equals, true)
+	want := NewDense(3, 3, []float64{
+		1, 0.7607, 0.1573,
+		0.7607, 1, 0.7607,
+		0.1573, 0.7607, 1,
+	})
+	c.Check(x.EqualsApprox(want, 1e-4), check.Equals, true, check.Commentf("got %v", x))
+	c.Check(symmetric(x), check.Equals, true)
+	for i := 0; i < 3; i++ {
+		c.Check(x.At(i, i), check.Equals, 1.0)
+	}
+	c.Check(symEigenvalues(x)[0] > -1e-10, check.Equals, true)
+
+	// A correlation matrix is its own nearest.
+	corr := NewCorrelation(5, 1, rand.New(rand.NewSource(1)))
+	c.Check(NearestCorrelation(corr, 1e-12, 100).EqualsApprox(corr, 1e-10), check.Equals, true)
+
+	c.Check(func() { NearestCorrelation(NewDense(2, 3, nil), 1e-8, 10) }, check.PanicMatches, string(ErrSquare))
+}
@@ -0,0 +1,167 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// NIPALSFactors holds the leading principal components of a data matrix x
+// computed by NIPALS, such that the centered x is approximated by
+// Scores.Loadings'.
+type NIPALSFactors struct {
+	// Scores holds the projections of the observations onto the
+	// principal components in its columns.
+	Scores *Dense
+
+	// Loadings holds the principal component directions, with unit
+	// norm, in its columns.
+	Loadings *Dense
+
+	// Mean holds the column means of x that were subtracted.
+	Mean []float64
+}
+
+// NIPALS computes the leading k principal components of the n-by-p data matrix x,
+// with observations in its rows, using the nonlinear iterative partial least
+// squares algorithm. Components are computed one at a time by alternating
+// regressions, and x is deflated by each component before the next is found,
+// so the p-by-p covariance matrix is never formed. This makes NIPALS suited to
+// wide data with p much larger than n when few components are required.
+//
+// Missing values in x, represented by NaN, are ignored in the column means and
+// in the regressions. Iteration for a component stops when the relative change
+// in its scores is less than tol or after maxIter iterations. NIPALS will panic
+// with ErrShape if k is not in [1, min(n, p)]. The matrix x is not modified.
+func NIPALS(x *Dense, k int, tol float64, maxIter int) NIPALSFactors {
+	n, p := x.Dims()
+	if k < 1 || k > min(n, p) {
+		panic(ErrShape)
+	}
+
+	// Center the columns ignoring missing values.
+	e := DenseCopyOf(x)
+	mean := make([]float64, p)
+	count := make([]float64, p)
+	for i := 0; i < n; i++ {
+		for j, v := range e.rowView(i) {
+			if !math.IsNaN(v) {
+				mean[j] += v
+				count[j]++
+			}
+		}
+	}
+	for j := range mean {
+		if count[j] > 0 {
+			mean[j] /= count[j]
+		}
+	}
+	for i := 0; i < n; i++ {
+		row := e.rowView(i)
+		for j := range row {
+			row[j] -= mean[j]
+		}
+	}
+
+	f := NIPALSFactors{
+		Scores:   NewDense(n, k, nil),
+		Loadings: NewDense(p, k, nil),
+		Mean:     mean,
+	}
+	t := make([]float64, n)
+	tNew := make([]float64, n)
+	load := make([]float64, p)
+	var sweeps int
+	for comp := 0; comp < k; comp++ {
+		// Start from the column with the largest sum of squares.
+		var best int
+		var bestSS float64
+		for j := 0; j < p; j++ {
+			var ss float64
+			for i := 0; i < n; i++ {
+				if v := e.At(i, j); !math.IsNaN(v) {
+					ss += v * v
+				}
+			}
+			if ss > bestSS {
+				best, bestSS = j, ss
+			}
+		}
+		for i := range t {
+			t[i] = e.At(i, best)
+			if math.IsNaN(t[i]) {
+				t[i] = 0
+			}
+		}
+
+		for iter := 0; iter < maxIter; iter++ {
+			// Regress the columns on the scores to find the loadings.
+			for j := range load {
+				var num, den float64
+				for i := 0; i < n; i++ {
+					if v := e.At(i, j); !math.IsNaN(v) {
+						num += v * t[i]
+						den += t[i] * t[i]
+					}
+				}
+				if den != 0 {
+					load[j] = num / den
+				} else {
+					load[j] = 0
+				}
+			}
+			norm := Vec(load).Norm(2)
+			if norm == 0 {
+				break
+			}
+			for j := range load {
+				load[j] /= norm
+			}
+
+			// Regress the rows on the loadings to find the scores.
+			for i := 0; i < n; i++ {
+				var num, den float64
+				for j, v := range e.rowView(i) {
+					if !math.IsNaN(v) {
+						num += v * load[j]
+						den += load[j] * load[j]
+					}
+				}
+				if den != 0 {
+					tNew[i] = num / den
+				} else {
+					tNew[i] = 0
+				}
+			}
+
+			var diff float64
+			for i := range t {
+				d := tNew[i] - t[i]
+				diff += d * d
+			}
+			t, tNew = tNew, t
+			sweeps++
+			if progress != nil {
+				progress("NIPALS", sweeps, math.Sqrt(diff)/Vec(t).Norm(2))
+			}
+			if math.Sqrt(diff) <= tol*Vec(t).Norm(2) {
+				break
+			}
+		}
+
+		// Deflate the data by the component.
+		for i := 0; i < n; i++ {
+			row := e.rowView(i)
+			for j := range row {
+				row[j] -= t[i] * load[j]
+			}
+			f.Scores.Set(i, comp, t[i])
+		}
+		for j, v := range load {
+			f.Loadings.Set(j, comp, v)
+		}
+	}
+	return f
+}
@@ -0,0 +1,79 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestNIPALS(c *check.C) {
+	// A wide rank two data set with column offsets.
+	rnd := rand.New(rand.NewSource(1))
+	n, p := 6, 20
+	x := NewDense(n, p, nil)
+	u := []float64{3, -1, 2, 0.5, -2, 1}
+	w := []float64{1, 2, -1, 0.5, 0, -2}
+	for j := 0; j < p; j++ {
+		a, b, off := rnd.NormFloat64(), rnd.NormFloat64(), float64(j)
+		for i := 0; i < n; i++ {
+			x.Set(i, j, off+2*u[i]*a+w[i]*b)
+		}
+	}
+	orig := DenseCopyOf(x)
+
+	f := NIPALS(x, 2, 1e-14, 1000)
+	c.Check(x.Equals(orig), check.Equals, true)
+	c.Check(isOrthonormal(f.Loadings, 1e-8), check.Equals, true)
+	for j := 0; j < p; j++ {
+		var sum float64
+		for i := 0; i < n; i++ {
+			sum += x.At(i, j)
+		}
+		c.Check(math.Abs(f.Mean[j]-sum/float64(n)) < 1e-12, check.Equals, true)
+	}
+
+	// The two components reproduce the centered data.
+	var rec Dense
+	var lt Dense
+	lt.TCopy(f.Loadings)
+	rec.Mul(f.Scores, &lt)
+	for i := 0; i < n; i++ {
+		for j := 0; j < p; j++ {
+			c.Check(math.Abs(rec.At(i, j)+f.Mean[j]-x.At(i, j)) < 1e-8, check.Equals, true)
+		}
+	}
+
+	// The leading component agrees with the SVD of the centered data.
+	centered := DenseCopyOf(x)
+	for i := 0; i < n; i++ {
+		row := centered.rowView(i)
+		for j := range row {
+			row[j] -= f.Mean[j]
+		}
+	}
+	svd := SVD(centered, epsilon, small, false, true)
+	var dot float64
+	for j := 0; j < p; j++ {
+		dot += svd.V.At(j, 0) * f.Loadings.At(j, 0)
+	}
+	c.Check(math.Abs(math.Abs(dot)-1) < 1e-8, check.Equals, true)
+	c.Check(math.Abs(Vec(f.Scores.Col(nil, 0)).Norm(2)-svd.Sigma[0]) < 1e-8, check.Equals, true)
+
+	// Missing values are tolerated and the leading component stays close
+	// to that of the complete data.
+	full := f
+	x.Set(2, 5, math.NaN())
+	f = NIPALS(x, 2, 1e-14, 1000)
+	dot = 0
+	for j := 0; j < p; j++ {
+		dot += full.Loadings.At(j, 0) * f.Loadings.At(j, 0)
+	}
+	c.Check(math.Abs(math.Abs(dot)-1) < 1e-3, check.Equals, true, check.Commentf("dot %v", dot))
+
+	c.Check(func() { NIPALS(x, 7, 1e-10, 10) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,316 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// ErrNegative is the panic value used by NMF when the matrix to be factorized
+// has a negative element.
+const ErrNegative = Error("mat64: matrix has negative elements")
+
+// NMFAlgorithm specifies the update rule used by NMF.
+type NMFAlgorithm int
+
+const (
+	// Multiplicative uses the multiplicative updates of Lee and Seung,
+	// "Algorithms for non-negative matrix factorization", NIPS 13, 2001.
+	// Each iteration is cheap but convergence can be slow.
+	Multiplicative NMFAlgorithm = iota
+
+	// HALS uses hierarchical alternating least squares, updating one
+	// row of H and one column of W at a time, as described by Cichocki
+	// and Phan, "Fast local algorithms for large scale nonnegative matrix
+	// and tensor factorizations", IEICE Trans. Fundamentals E92-A:708-721,
+	// 2009. It usually converges in far fewer iterations.
+	HALS
+)
+
+// NMFInit specifies the initialization of the factors used by NMF.
+type NMFInit int
+
+const (
+	// RandomInit fills the factors with uniform random values scaled to
+	// the mean of the matrix.
+	RandomInit NMFInit = iota
+
+	// NNDSVDInit uses the non-negative double singular value
+	// decomposition of Boutsidis and Gallopoulos, "SVD based
+	// initialization: a head start for nonnegative matrix
+	// factorization", Pattern Recognition 41:1350-1362, 2008, with zeros
+	// replaced by the mean of the matrix. It is deterministic and
+	// requires k <= min(m, n).
+	NNDSVDInit
+)
+
+// NMFOptions holds the parameters for NMF.
+type NMFOptions struct {
+	// Algorithm is the update rule.
+	Algorithm NMFAlgorithm
+
+	// Init is the initialization of the factors.
+	Init NMFInit
+
+	// Tol is the convergence tolerance on the relative change in the
+	// Frobenius norm of the residual between iterations. If Tol is zero,
+	// 1e-6 is used.
+	Tol float64
+
+	// MaxIter is the maximum number of iterations. If MaxIter is zero,
+	// 200 is used.
+	MaxIter int
+
+	// Src is the source of the random initial factors. If Src is nil,
+	// the top-level math/rand functions are used.
+	Src Source
+}
+
+// NMFFactors holds a non-negative matrix factorization a ≈ W.H.
+type NMFFactors struct {
+	// W is the m-by-k non-negative basis matrix.
+	W *Dense
+
+	// H is the k-by-n non-negative coefficient matrix.
+	H *Dense
+
+	// Residual is the Frobenius norm of a - W.H.
+	Residual float64
+
+	// Iterations is the number of iterations performed.
+	Iterations int
+
+	// Converged reports whether the iteration met the tolerance.
+	Converged bool
+}
+
+// NMF returns the rank k non-negative matrix factorization of the m-by-n
+// non-negative matrix a, the factors W and H with non-negative elements that
+// locally minimize the Frobenius norm of a - W.H. In topic modeling with a
+// term-document matrix, the columns of W are the topics and the columns of H
+// the topic weights of the documents. A nil opts uses the default options. The
+// matrix a is not modified.
+//
+// NMF will panic with ErrShape if k is less than one, or greater than
+// min(m, n) for NNDSVDInit, and with ErrNegative if a has a negative element.
+func NMF(a *Dense, k int, opts *NMFOptions) NMFFactors {
+	m, n := a.Dims()
+	if k < 1 {
+		panic(ErrShape)
+	}
+	if opts == nil {
+		opts = &NMFOptions{}
+	}
+	tol := opts.Tol
+	if tol == 0 {
+		tol = 1e-6
+	}
+	maxIter := opts.MaxIter
+	if maxIter == 0 {
+		maxIter = 200
+	}
+
+	var mean float64
+	for i := 0; i < m; i++ {
+		for _, v := range a.rowView(i) {
+			if v < 0 {
+				panic(ErrNegative)
+			}
+			mean += v
+		}
+	}
+	mean /= float64(m * n)
+
+	var f NMFFactors
+	switch opts.Init {
+	case NNDSVDInit:
+		if k > min(m, n) {
+			panic(ErrShape)
+		}
+		f.W, f.H = nndsvd(a, k, mean)
+	default:
+		src := source(opts.Src)
+		scale := math.Sqrt(mean / float64(k))
+		f.W = NewUniform(m, k, 0, scale, src)
+		f.H = NewUniform(k, n, 0, scale, src)
+	}
+
+	var wt, ht, wta, wtw, aht, hht, tmp Dense
+	prev := nmfResidual(a, f.W, f.H)
+	if prev == 0 {
+		f.Converged = true
+		return f
+	}
+	for f.Iterations < maxIter {
+		f.Iterations++
+
+		// Update H with W fixed.
+		wt.TCopy(f.W)
+		wta.Mul(&wt, a)
+		wtw.Mul(&wt, f.W)
+		if opts.Algorithm == HALS {
+			halsRows(f.H, &wta, &wtw)
+		} else {
+			tmp.Mul(&wtw, f.H)
+			multiplicativeUpdate(f.H, &wta, &tmp)
+		}
+
+		// Update W with H fixed.
+		ht.TCopy(f.H)
+		aht.Mul(a, &ht)
+		hht.Mul(f.H, &ht)
+		if opts.Algorithm == HALS {
+			// The columns of W are the rows of W'.
+			var wtNew, ahtT Dense
+			wtNew.TCopy(f.W)
+			ahtT.TCopy(&aht)
+			halsRows(&wtNew, &ahtT, &hht)
+			f.W.TCopy(&wtNew)
+		} else {
+			tmp.Reset()
+			tmp.Mul(f.W, &hht)
+			multiplicativeUpdate(f.W, &aht, &tmp)
+		}
+		tmp.Reset()
+
+		f.Residual = nmfResidual(a, f.W, f.H)
+		change := math.Abs(prev-f.Residual) / prev
+		if progress != nil {
+			progress("NMF", f.Iterations, change)
+		}
+		if change <= tol || f.Residual == 0 {
+			f.Converged = true
+			break
+		}
+		prev = f.Residual
+	}
+	if f.Iterations == 0 {
+		f.Residual = prev
+	}
+	return f
+}
+
+// multiplicativeUpdate sets x = x∘num/den elementwise, guarding against
+// division by zero.
+func multiplicativeUpdate(x, num, den *Dense) {
+	r, _ := x.Dims()
+	for i := 0; i < r; i++ {
+		nr, dr := num.rowView(i), den.rowView(i)
+		for j, v := range x.rowView(i) {
+			x.rowView(i)[j] = v * nr[j] / (dr[j] + small)
+		}
+	}
+}
+
+// halsRows updates each row j of the non-negative x to minimize the objective
+// with the other rows fixed, given the cross products b = w'.a and g = w'.w,
+// where the objective is |a - w.x|².
+func halsRows(x, b, g *Dense) {
+	k, _ := x.Dims()
+	for j := 0; j < k; j++ {
+		gjj := g.At(j, j)
+		if gjj == 0 {
+			continue
+		}
+		xj := x.rowView(j)
+		step := make([]float64, len(xj))
+		copy(step, b.rowView(j))
+		for l, glj := range g.rowView(j) {
+			if glj != 0 {
+				axpyUnitary(-glj, x.rowView(l), step)
+			}
+		}
+		for i, s := range step {
+			// Keep elements strictly positive so a row cannot
+			// become identically zero.
+			xj[i] = math.Max(xj[i]+s/gjj, 1e-16)
+		}
+	}
+}
+
+// nmfResidual returns the Frobenius norm of a - w.h.
+func nmfResidual(a, w, h *Dense) float64 {
+	var wh Dense
+	wh.Mul(w, h)
+	wh.Sub(a, &wh)
+	return wh.Norm(0)
+}
+
+// nndsvd returns the NNDSVD initial factors of a, replacing zeros with fill.
+func nndsvd(a *Dense, k int, fill float64) (w, h *Dense) {
+	m, n := a.Dims()
+	svd := SVD(DenseCopyOf(a), epsilon, small, true, true)
+	w, h = NewDense(m, k, nil), NewDense(k, n, nil)
+
+	u := make([]float64, m)
+	v := make([]float64, n)
+	for j := 0; j < k; j++ {
+		for i := range u {
+			u[i] = svd.U.At(i, j)
+		}
+		for i := range v {
+			v[i] = svd.V.At(i, j)
+		}
+
+		// Choose the sign of the singular vector pair whose
+		// positive parts carry more weight.
+		up, un := posNegNorms(u)
+		vp, vn := posNegNorms(v)
+		sign, scale := 1.0, up*vp
+		if un*vn > scale {
+			sign, scale = -1, un*vn
+		}
+		if j == 0 {
+			// The leading pair can be taken non-negative.
+			sign, scale = 1, 1
+			for i := range u {
+				u[i] = math.Abs(u[i])
+			}
+			for i := range v {
+				v[i] = math.Abs(v[i])
+			}
+			up, vp = 1, 1
+		}
+		if scale == 0 {
+			continue
+		}
+		uNorm, vNorm := up, vp
+		if sign < 0 {
+			uNorm, vNorm = un, vn
+		}
+		coef := math.Sqrt(svd.Sigma[j] * scale)
+		for i, x := range u {
+			if x = sign * x; x > 0 {
+				w.Set(i, j, coef*x/uNorm)
+			}
+		}
+		for i, x := range v {
+			if x = sign * x; x > 0 {
+				h.Set(j, i, coef*x/vNorm)
+			}
+		}
+	}
+
+	for _, d := range [][]float64{w.mat.Data, h.mat.Data} {
+		for i, x := range d {
+			if x == 0 {
+				d[i] = fill
+			}
+		}
+	}
+	return w, h
+}
+
+// posNegNorms returns the two norms of the positive and negative parts of x.
+func posNegNorms(x []float64) (pos, neg float64) {
+	for _, v := range x {
+		if v > 0 {
+			pos = math.Hypot(pos, v)
+		} else {
+			neg = math.Hypot(neg, v)
+		}
+	}
+	return pos, neg
+}
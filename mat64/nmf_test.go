@@ -0,0 +1,108 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestNMF(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+	}{
+		{m: 10, n: 8, k: 2},
+		{m: 6, n: 15, k: 3},
+		{m: 20, n: 20, k: 4},
+	} {
+		// An exactly rank k non-negative matrix.
+		w := NewDense(test.m, test.k, nil)
+		h := NewDense(test.k, test.n, nil)
+		for _, d := range [][]float64{w.mat.Data, h.mat.Data} {
+			for i := range d {
+				d[i] = rnd.Float64()
+			}
+		}
+		a := NewDense(test.m, test.n, nil)
+		a.Mul(w, h)
+		orig := DenseCopyOf(a)
+		norm := a.Norm(0)
+
+		for _, alg := range []NMFAlgorithm{Multiplicative, HALS} {
+			for _, init := range []NMFInit{RandomInit, NNDSVDInit} {
+				f := NMF(a, test.k, &NMFOptions{
+					Algorithm: alg,
+					Init:      init,
+					Tol:       1e-10,
+					MaxIter:   2000,
+					Src:       rand.New(rand.NewSource(2)),
+				})
+				c.Check(a.Equals(orig), check.Equals, true)
+				r, cols := f.W.Dims()
+				c.Check(r == test.m && cols == test.k, check.Equals, true)
+				r, cols = f.H.Dims()
+				c.Check(r == test.k && cols == test.n, check.Equals, true)
+				for _, d := range [][]float64{f.W.mat.Data, f.H.mat.Data} {
+					for _, v := range d {
+						c.Check(v >= 0, check.Equals, true)
+					}
+				}
+				c.Check(nmfResidual(a, f.W, f.H), check.Equals, f.Residual)
+				c.Check(f.Residual < 1e-2*norm, check.Equals, true,
+					check.Commentf("alg=%d init=%d residual=%v", alg, init, f.Residual/norm))
+			}
+		}
+	}
+}
+
+func (s *S) TestNMFMonotone(c *check.C) {
+	rnd := rand.New(rand.NewSource(3))
+	a := NewDense(12, 9, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = rnd.Float64()
+	}
+	for _, alg := range []NMFAlgorithm{Multiplicative, HALS} {
+		var calls int
+		prev := SetProgress(func(op string, _ int, _ float64) {
+			if op == "NMF" {
+				calls++
+			}
+		})
+		prevRes := -1.0
+		for iter := 1; iter <= 30; iter++ {
+			f := NMF(a, 3, &NMFOptions{
+				Algorithm: alg,
+				MaxIter:   iter,
+				Tol:       1e-300,
+				Src:       rand.New(rand.NewSource(4)),
+			})
+			c.Check(f.Iterations, check.Equals, iter)
+			if prevRes >= 0 {
+				c.Check(f.Residual <= prevRes*(1+1e-12), check.Equals, true)
+			}
+			prevRes = f.Residual
+		}
+		SetProgress(prev)
+		c.Check(calls, check.Equals, 30*31/2)
+	}
+}
+
+func (s *S) TestNMFPanics(c *check.C) {
+	a := NewDense(3, 4, []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+	c.Check(func() { NMF(a, 0, nil) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { NMF(a, 4, &NMFOptions{Init: NNDSVDInit}) }, check.PanicMatches, string(ErrShape))
+	a.Set(1, 2, -1)
+	c.Check(func() { NMF(a, 2, nil) }, check.PanicMatches, string(ErrNegative))
+
+	f := NMF(NewDense(3, 4, nil), 2, nil)
+	c.Check(f.Converged, check.Equals, true)
+	c.Check(f.Residual, check.Equals, 0.0)
+}
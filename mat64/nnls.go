@@ -0,0 +1,154 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// NNLS returns the matrix x that minimizes the Frobenius norm of a.x - b subject
+// to every element of x being non-negative, treating each column of b as a
+// separate right hand side, and the two norms of the columns of the residual
+// a.x - b. The matrices a and b are not modified.
+//
+// Each column is solved by the active set method of Lawson and Hanson, "Solving
+// Least Squares Problems", chapter 23, which moves variables between the set
+// held at zero and the set solved for by unconstrained least squares until the
+// Karush-Kuhn-Tucker conditions hold. The method terminates in a finite number
+// of steps; as a safeguard the number of variables freed is limited to 3n for
+// an n column a, after which the current feasible solution is returned.
+//
+// NNLS will panic with ErrShape if a and b do not have the same number of rows.
+func NNLS(a, b *Dense) (x *Dense, resid []float64) {
+	m, n := a.Dims()
+	bm, bn := b.Dims()
+	if bm != m {
+		panic(ErrShape)
+	}
+
+	// The tolerance for the dual variables and for variables leaving the
+	// passive set.
+	var norm float64
+	for j := 0; j < n; j++ {
+		var s float64
+		for i := 0; i < m; i++ {
+			s += math.Abs(a.At(i, j))
+		}
+		norm = math.Max(norm, s)
+	}
+	tol := 10 * epsilon * norm * float64(max(m, n))
+
+	x = NewDense(n, bn, nil)
+	resid = make([]float64, bn)
+	col := make([]float64, m)
+	for j := 0; j < bn; j++ {
+		for i := range col {
+			col[i] = b.At(i, j)
+		}
+		xj, r := nnls(a, col, tol)
+		for i, v := range xj {
+			x.Set(i, j, v)
+		}
+		resid[j] = r
+	}
+	return x, resid
+}
+
+// nnls solves the non-negative least squares problem for a single right hand
+// side b, returning the solution and the two norm of its residual.
+func nnls(a *Dense, b []float64, tol float64) (x []float64, resid float64) {
+	m, n := a.Dims()
+	x = make([]float64, n)
+	passive := make([]bool, n)
+	w := make([]float64, n)
+	r := make([]float64, m)
+
+	// gradient sets r = b - a.x and w = a'.r.
+	gradient := func() {
+		for i := range r {
+			r[i] = b[i] - dotUnitary(a.rowView(i), x)
+		}
+		mulTransVec(w, a, r)
+	}
+
+	gradient()
+	for iter := 0; iter < 3*n; iter++ {
+		// Free the held variable with the largest positive gradient.
+		t := -1
+		for j, v := range w {
+			if !passive[j] && v > tol && (t < 0 || v > w[t]) {
+				t = j
+			}
+		}
+		if t < 0 {
+			break
+		}
+		passive[t] = true
+
+		for {
+			z := nnlsPassiveSolve(a, b, passive)
+			feasible := true
+			alpha := math.Inf(1)
+			for j, p := range passive {
+				if p && z[j] <= 0 {
+					feasible = false
+					if d := x[j] - z[j]; d > 0 {
+						alpha = math.Min(alpha, x[j]/d)
+					} else {
+						alpha = 0
+					}
+				}
+			}
+			if feasible {
+				copy(x, z)
+				break
+			}
+
+			// Step towards z until the first variable reaches zero,
+			// and hold it and any others at zero.
+			for j, p := range passive {
+				if p {
+					x[j] += alpha * (z[j] - x[j])
+					if x[j] <= tol {
+						x[j] = 0
+						passive[j] = false
+					}
+				}
+			}
+		}
+		gradient()
+	}
+
+	for _, v := range r {
+		resid = math.Hypot(resid, v)
+	}
+	return x, resid
+}
+
+// nnlsPassiveSolve returns the unconstrained least squares solution of a.z = b
+// using only the columns of a marked in passive, with the other elements of z
+// zero.
+func nnlsPassiveSolve(a *Dense, b []float64, passive []bool) []float64 {
+	m, n := a.Dims()
+	var idx []int
+	for j, p := range passive {
+		if p {
+			idx = append(idx, j)
+		}
+	}
+	sub := NewDense(m, len(idx), nil)
+	for i := 0; i < m; i++ {
+		row, sr := a.rowView(i), sub.rowView(i)
+		for k, j := range idx {
+			sr[k] = row[j]
+		}
+	}
+	zs, _ := LeastSquares(sub, NewDense(m, 1, append([]float64(nil), b...)))
+	z := make([]float64, n)
+	for k, j := range idx {
+		z[j] = zs.At(k, 0)
+	}
+	return z
+}
@@ -0,0 +1,79 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math"
+	"math/rand"
+)
+
+func (s *S) TestNNLS(c *check.C) {
+	// The unconstrained solution of this problem is (1, -1), so the
+	// constrained solution holds the second variable at zero.
+	a := NewDense(3, 2, []float64{
+		1, 0,
+		0, 1,
+		1, 1,
+	})
+	b := NewDense(3, 1, []float64{1, -1, 0})
+	x, resid := NNLS(a, b)
+	c.Check(x.EqualsApprox(NewDense(2, 1, []float64{0.5, 0}), 1e-12), check.Equals, true, check.Commentf("got %v", x))
+	c.Check(math.Abs(resid[0]-math.Sqrt(1.5)) < 1e-12, check.Equals, true)
+
+	// A problem with a non-negative unconstrained solution is unchanged.
+	b = NewDense(3, 1, []float64{1, 2, 3})
+	x, resid = NNLS(a, b)
+	c.Check(x.EqualsApprox(NewDense(2, 1, []float64{1, 2}), 1e-12), check.Equals, true)
+	c.Check(resid[0] < 1e-12, check.Equals, true)
+
+	// Random problems agree with exhaustive search over the active sets.
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		const m, n = 8, 4
+		a := NewDense(m, n, nil)
+		b := NewDense(m, 2, nil)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a.Set(i, j, rnd.NormFloat64())
+			}
+			b.Set(i, 0, rnd.NormFloat64())
+			b.Set(i, 1, rnd.NormFloat64())
+		}
+		x, resid := NNLS(a, b)
+		for j := 0; j < 2; j++ {
+			col := make([]float64, m)
+			for i := range col {
+				col[i] = b.At(i, j)
+			}
+			best := math.Inf(1)
+			for set := 0; set < 1<<n; set++ {
+				passive := make([]bool, n)
+				for k := range passive {
+					passive[k] = set&(1<<uint(k)) != 0
+				}
+				z := nnlsPassiveSolve(a, col, passive)
+				feasible := true
+				for _, v := range z {
+					feasible = feasible && v >= 0
+				}
+				if !feasible {
+					continue
+				}
+				var r float64
+				for i := 0; i < m; i++ {
+					r = math.Hypot(r, col[i]-dotUnitary(a.rowView(i), z))
+				}
+				best = math.Min(best, r)
+			}
+			for i := 0; i < n; i++ {
+				c.Check(x.At(i, j) >= 0, check.Equals, true)
+			}
+			c.Check(math.Abs(resid[j]-best) < 1e-10, check.Equals, true, check.Commentf("trial %d column %d: %v != %v", trial, j, resid[j], best))
+		}
+	}
+
+	c.Check(func() { NNLS(a, NewDense(2, 1, nil)) }, check.PanicMatches, string(ErrShape))
+}
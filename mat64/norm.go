@@ -0,0 +1,88 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math"
+
+// Norm returns the specified matrix norm of a. The valid orders are those
+// described by the Normer interface, with the addition of
+//
+//     2 - largest singular value
+//    -2 - smallest singular value
+//
+// If a is a Normer its Norm method is used, otherwise the norm is computed
+// from a copy of a. Norm will panic with ErrNormOrder if an illegal norm order
+// is specified.
+func Norm(a Matrix, ord float64) float64 {
+	if a, ok := a.(Normer); ok {
+		return a.Norm(ord)
+	}
+	return DenseCopyOf(a).Norm(ord)
+}
+
+// Norm returns the p-norm of the vector v. Valid values of p are:
+//
+//   p > 0 - (sum |v_i|^p)^(1/p)
+//     Inf - max |v_i|
+//    -Inf - min |v_i|
+//
+// To agree with the matrix norms of a single column described by Normer, an
+// order of 0, -2 or 2 returns the Euclidean norm and an order of -1 returns the
+// 1-norm. The norms are computed with scaling to avoid overflow and underflow.
+// Norm will panic with ErrNormOrder if p is NaN or a negative value not listed
+// above.
+func (v Vec) Norm(p float64) float64 {
+	switch {
+	case p == 1, p == -1:
+		var n float64
+		for _, e := range v {
+			n += math.Abs(e)
+		}
+		return n
+	case math.IsInf(p, 1):
+		var n float64
+		for _, e := range v {
+			n = math.Max(n, math.Abs(e))
+		}
+		return n
+	case math.IsInf(p, -1):
+		n := math.Inf(1)
+		for _, e := range v {
+			n = math.Min(n, math.Abs(e))
+		}
+		return n
+	case p == 0, p == 2, p == -2:
+		return scaledNorm(v, 2)
+	case p > 0:
+		return scaledNorm(v, p)
+	default:
+		panic(ErrNormOrder)
+	}
+}
+
+// scaledNorm returns the p-norm of v for finite p > 0, scaling the elements by
+// their largest magnitude to avoid overflow and underflow.
+func scaledNorm(v []float64, p float64) float64 {
+	var scale float64
+	for _, e := range v {
+		scale = math.Max(scale, math.Abs(e))
+	}
+	if scale == 0 || math.IsInf(scale, 1) {
+		return scale
+	}
+	var sum float64
+	for _, e := range v {
+		if p == 2 {
+			r := e / scale
+			sum += r * r
+		} else {
+			sum += math.Pow(math.Abs(e)/scale, p)
+		}
+	}
+	if p == 2 {
+		return scale * math.Sqrt(sum)
+	}
+	return scale * math.Pow(sum, 1/p)
+}
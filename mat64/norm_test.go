@@ -0,0 +1,58 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestNormFunc(c *check.C) {
+	a := NewDense(2, 3, []float64{
+		1, -2, -2,
+		-4, 5, 6,
+	})
+	orig := DenseCopyOf(a)
+	for _, ord := range []float64{0, 1, -1, 2, -2, inf, -inf} {
+		want := DenseCopyOf(a).Norm(ord)
+		c.Check(Norm((*basicMatrix)(a), ord), check.Equals, want, check.Commentf("Order %v", ord))
+		c.Check(Norm(a, ord), check.Equals, want, check.Commentf("Order %v", ord))
+		c.Check(a.Equals(orig), check.Equals, true, check.Commentf("Order %v: matrix altered", ord))
+	}
+	c.Check(func() { Norm((*basicMatrix)(a), 3) }, check.PanicMatches, string(ErrNormOrder))
+}
+
+func (s *S) TestVecNorm(c *check.C) {
+	for i, test := range []struct {
+		v    Vec
+		p    float64
+		norm float64
+	}{
+		{Vec{3, -4}, 2, 5},
+		{Vec{3, -4}, 0, 5},
+		{Vec{3, -4}, 1, 7},
+		{Vec{3, -4}, inf, 4},
+		{Vec{3, -4}, -inf, 3},
+		{Vec{1, 1, 1, 1}, 3, math.Pow(4, 1.0/3)},
+		{Vec{1, 1, 1, 1}, 0.5, 16},
+		{Vec{3e200, -4e200}, 2, 5e200},
+		{Vec{3e-200, -4e-200}, 2, 5e-200},
+		{Vec{0, 0}, 2, 0},
+		{Vec{1, inf}, 2, inf},
+	} {
+		c.Check(math.Abs(test.v.Norm(test.p)-test.norm) <= 1e-14*test.norm || test.v.Norm(test.p) == test.norm,
+			check.Equals, true, check.Commentf("Test %d: got %v want %v", i, test.v.Norm(test.p), test.norm))
+	}
+
+	// A single column vector agrees with the matrix norms.
+	v := Vec{1, -2, 3}
+	for _, ord := range []float64{0, 1, -1, 2, -2, inf} {
+		c.Check(math.Abs(v.Norm(ord)-NewDense(3, 1, []float64(v)).Norm(ord)) < 1e-14, check.Equals, true, check.Commentf("Order %v", ord))
+	}
+
+	c.Check(func() { v.Norm(-3) }, check.PanicMatches, string(ErrNormOrder))
+	c.Check(func() { v.Norm(math.NaN()) }, check.PanicMatches, string(ErrNormOrder))
+}
@@ -0,0 +1,38 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// NewOrthogonal returns a random r-by-c matrix with orthonormal columns if r >= c,
+// or orthonormal rows if r < c, distributed uniformly with respect to the Haar
+// measure and drawn using src. A square result is a uniformly distributed
+// orthogonal matrix.
+//
+// The matrix is the orthogonal factor Q of the QR factorization of a matrix of
+// independent standard normal elements, with the signs of its columns chosen so
+// that the diagonal of R is positive, as described by Mezzadri, "How to generate
+// random matrices from the classical compact groups", Notices of the AMS
+// 54:592-604, 2007. Without the sign correction the distribution of Q depends
+// on the sign convention of the factorization and is not uniform.
+func NewOrthogonal(r, c int, src Source) *Dense {
+	if r < c {
+		q := NewOrthogonal(c, r, src)
+		qt := NewDense(r, c, nil)
+		qt.TCopy(q)
+		return qt
+	}
+	if c == 0 {
+		return NewDense(r, 0, nil)
+	}
+	f := QR(NewNormal(r, c, 0, 1, src))
+	q := f.Q()
+	for j, d := range f.rDiag[:c] {
+		if d < 0 {
+			for i := 0; i < r; i++ {
+				q.Set(i, j, -q.At(i, j))
+			}
+		}
+	}
+	return q
+}
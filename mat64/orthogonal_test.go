@@ -0,0 +1,51 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestNewOrthogonal(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ r, c int }{
+		{1, 1}, {2, 2}, {5, 5}, {8, 3}, {3, 8}, {4, 0}, {0, 0},
+	} {
+		q := NewOrthogonal(test.r, test.c, src)
+		r, cols := q.Dims()
+		c.Check(r == test.r && cols == test.c, check.Equals, true)
+		if test.r >= test.c {
+			c.Check(isOrthonormal(q, 1e-13), check.Equals, true)
+		} else {
+			var qt Dense
+			qt.TCopy(q)
+			c.Check(isOrthonormal(&qt, 1e-13), check.Equals, true)
+		}
+	}
+
+	// For a Haar distributed n-by-n orthogonal matrix with n >= 2 each
+	// element has mean 0 and mean square 1/n, and the trace has mean 0
+	// and mean square 1.
+	const n, samples = 3, 20000
+	var q00, q00sq, tr, trsq float64
+	for k := 0; k < samples; k++ {
+		q := NewOrthogonal(n, n, src)
+		q00 += q.At(0, 0)
+		q00sq += q.At(0, 0) * q.At(0, 0)
+		var t float64
+		for i := 0; i < n; i++ {
+			t += q.At(i, i)
+		}
+		tr += t
+		trsq += t * t
+	}
+	c.Check(math.Abs(q00/samples) < 0.02, check.Equals, true)
+	c.Check(math.Abs(q00sq/samples-1.0/n) < 0.02, check.Equals, true)
+	c.Check(math.Abs(tr/samples) < 0.03, check.Equals, true)
+	c.Check(math.Abs(trsq/samples-1) < 0.05, check.Equals, true)
+}
@@ -0,0 +1,129 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// A PairFunc computes a distance or kernel value between two vectors of equal
+// length.
+type PairFunc func(x, y []float64) float64
+
+// pairBlockSize is the number of rows of the second matrix in each block of a
+// pairwise computation, chosen so that a block stays in cache while it is
+// compared with the rows of the first matrix.
+const pairBlockSize = 64
+
+// Pairwise returns the m-by-n matrix with element (i, j) equal to fn applied to
+// row i of the m-row matrix a and row j of the n-row matrix b. If b is nil, the
+// rows of a are compared with each other and fn is assumed to be symmetric, so
+// that it is evaluated once for each unordered pair. The rows of b are processed
+// in blocks that remain in cache while the rows of a are shared among up to
+// MaxProcs goroutines. Pairwise will panic with ErrShape if a and b do not have
+// the same number of columns.
+//
+// The package provides EuclideanDistance, ManhattanDistance and CosineDistance,
+// and the kernels LinearKernel, RBFKernel and PolynomialKernel for building
+// Gram matrices.
+func Pairwise(a, b *Dense, fn PairFunc) *Dense {
+	sym := b == nil
+	if sym {
+		b = a
+	}
+	m, p := a.Dims()
+	n, bp := b.Dims()
+	if bp != p {
+		panic(ErrShape)
+	}
+	d := NewDense(m, n, nil)
+	parallelRows(m, n*p, func(lo, hi int) {
+		for jb := 0; jb < n; jb += pairBlockSize {
+			jHi := min(jb+pairBlockSize, n)
+			for i := lo; i < hi; i++ {
+				x, row := a.rowView(i), d.rowView(i)
+				jLo := jb
+				if sym {
+					jLo = max(jb, i)
+				}
+				for j := jLo; j < jHi; j++ {
+					row[j] = fn(x, b.rowView(j))
+				}
+			}
+		}
+	})
+	if sym {
+		for i := 0; i < m; i++ {
+			for j := 0; j < i; j++ {
+				d.rowView(i)[j] = d.rowView(j)[i]
+			}
+		}
+	}
+	return d
+}
+
+// EuclideanDistance returns the Euclidean distance between x and y.
+func EuclideanDistance(x, y []float64) float64 {
+	var s float64
+	for i, v := range x {
+		d := v - y[i]
+		s += d * d
+	}
+	return math.Sqrt(s)
+}
+
+// ManhattanDistance returns the sum of the absolute differences of x and y.
+func ManhattanDistance(x, y []float64) float64 {
+	var s float64
+	for i, v := range x {
+		s += math.Abs(v - y[i])
+	}
+	return s
+}
+
+// CosineDistance returns one minus the cosine of the angle between x and y. The
+// cosine is taken to be zero if either vector is zero.
+func CosineDistance(x, y []float64) float64 {
+	var xy, xx, yy float64
+	for i, v := range x {
+		xy += v * y[i]
+		xx += v * v
+		yy += y[i] * y[i]
+	}
+	if xx == 0 || yy == 0 {
+		return 1
+	}
+	return 1 - xy/math.Sqrt(xx*yy)
+}
+
+// LinearKernel returns the dot product of x and y.
+func LinearKernel(x, y []float64) float64 {
+	return dotUnitary(x, y)
+}
+
+// RBFKernel returns the Gaussian radial basis function kernel
+// exp(-gamma.|x-y|²).
+func RBFKernel(gamma float64) PairFunc {
+	return func(x, y []float64) float64 {
+		var s float64
+		for i, v := range x {
+			d := v - y[i]
+			s += d * d
+		}
+		return math.Exp(-gamma * s)
+	}
+}
+
+// PolynomialKernel returns the polynomial kernel (gamma.x'.y + coef0)^degree.
+func PolynomialKernel(gamma, coef0 float64, degree int) PairFunc {
+	return func(x, y []float64) float64 {
+		base := gamma*dotUnitary(x, y) + coef0
+		v := 1.0
+		for i := 0; i < degree; i++ {
+			v *= base
+		}
+		return v
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestPairFuncs(c *check.C) {
+	x := []float64{1, 2, 2}
+	y := []float64{4, 6, 2}
+	c.Check(EuclideanDistance(x, y), check.Equals, 5.0)
+	c.Check(ManhattanDistance(x, y), check.Equals, 7.0)
+	c.Check(math.Abs(CosineDistance(x, y)-(1-(4+12+4)/(3*math.Sqrt(56)))) < 1e-15, check.Equals, true)
+	c.Check(CosineDistance(x, []float64{0, 0, 0}), check.Equals, 1.0)
+	c.Check(LinearKernel(x, y), check.Equals, 20.0)
+	c.Check(RBFKernel(0.5)(x, y), check.Equals, math.Exp(-12.5))
+	c.Check(PolynomialKernel(0.5, 1, 3)(x, y), check.Equals, 1331.0)
+}
+
+func (s *S) TestPairwise(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	defer SetMaxProcs(SetMaxProcs(4))
+	for _, test := range []struct{ m, n, p int }{
+		{m: 1, n: 1, p: 3},
+		{m: 7, n: 5, p: 2},
+		{m: 150, n: 130, p: 4},
+		{m: 0, n: 3, p: 2},
+	} {
+		a := NewNormal(test.m, test.p, 0, 1, rnd)
+		b := NewNormal(test.n, test.p, 0, 1, rnd)
+		for _, fn := range []PairFunc{EuclideanDistance, ManhattanDistance, CosineDistance, LinearKernel, RBFKernel(0.3)} {
+			d := Pairwise(a, b, fn)
+			r, cols := d.Dims()
+			c.Check(r == test.m && cols == test.n, check.Equals, true)
+			for i := 0; i < test.m; i++ {
+				for j := 0; j < test.n; j++ {
+					c.Check(d.At(i, j), check.Equals, fn(a.rowView(i), b.rowView(j)))
+				}
+			}
+
+			g := Pairwise(a, nil, fn)
+			c.Check(symmetric(g), check.Equals, true)
+			for i := 0; i < test.m; i++ {
+				for j := i; j < test.m; j++ {
+					c.Check(g.At(i, j), check.Equals, fn(a.rowView(i), a.rowView(j)))
+				}
+			}
+		}
+	}
+	c.Check(func() { Pairwise(NewDense(2, 3, nil), NewDense(2, 2, nil), LinearKernel) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,83 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"runtime"
+	"sync"
+)
+
+// maxProcs is the maximum number of goroutines used by a parallel operation.
+var maxProcs = runtime.GOMAXPROCS(0)
+
+// minParallelWork is the minimum number of multiply-add operations assigned
+// to each goroutine by a parallel operation. Smaller operations are performed
+// by the calling goroutine.
+const minParallelWork = 1 << 16
+
+// SetMaxProcs sets the maximum number of goroutines that operations such as
+// Dense.Mul may use to share work, and returns the previous setting. A value
+// of 1 performs all work in the calling goroutine. If n is less than 1 the
+// limit is set to the current value of runtime.GOMAXPROCS.
+//
+// SetMaxProcs must not be called concurrently with operations on matrices.
+func SetMaxProcs(n int) int {
+	prev := maxProcs
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	maxProcs = n
+	return prev
+}
+
+// MaxProcs returns the maximum number of goroutines that operations may use
+// to share work.
+func MaxProcs() int { return maxProcs }
+
+// parallelRows calls fn on contiguous blocks [lo, hi) of the rows [0, rows),
+// where each row requires work multiply-add operations. The blocks are processed
+// by up to maxProcs goroutines, and parallelRows returns when all calls to fn
+// have returned. fn must be safe for concurrent use on disjoint blocks.
+func parallelRows(rows, work int, fn func(lo, hi int)) {
+	parallelBlocks(rows, rowProcs(rows, work), fn)
+}
+
+// rowProcs returns the number of goroutines used by parallelRows for rows rows
+// each requiring work multiply-add operations. Callers that must not allocate
+// check for a result of at most 1 and do the work directly, since a closure
+// passed to parallelRows is allocated on the heap.
+func rowProcs(rows, work int) int {
+	procs := maxProcs
+	if rows < procs {
+		procs = rows
+	}
+	if total := rows * work; total/minParallelWork < procs {
+		procs = total / minParallelWork
+	}
+	return procs
+}
+
+// parallelBlocks calls fn on procs contiguous blocks of nearly equal size
+// covering the rows [0, rows), each in its own goroutine, and returns when all
+// calls to fn have returned. If procs is at most 1, fn is called once on all
+// rows by the calling goroutine.
+func parallelBlocks(rows, procs int, fn func(lo, hi int)) {
+	if procs <= 1 {
+		fn(0, rows)
+		return
+	}
+
+	var wg sync.WaitGroup
+	block := (rows + procs - 1) / procs
+	for lo := 0; lo < rows; lo += block {
+		hi := min(lo+block, rows)
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
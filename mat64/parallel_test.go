@@ -0,0 +1,113 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSetMaxProcs(c *check.C) {
+	prev := SetMaxProcs(3)
+	c.Check(MaxProcs(), check.Equals, 3)
+	c.Check(SetMaxProcs(0), check.Equals, 3)
+	c.Check(MaxProcs(), check.Equals, runtime.GOMAXPROCS(0))
+	SetMaxProcs(prev)
+}
+
+func (s *S) TestParallelMul(c *check.C) {
+	defer SetMaxProcs(SetMaxProcs(1))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ ar, ac, bc int }{
+		{97, 83, 101},
+		{200, 64, 64},
+		{7, 300, 90},
+	} {
+		a := NewDense(test.ar, test.ac, nil)
+		b := NewDense(test.ac, test.bc, nil)
+		for _, m := range []*Dense{a, b} {
+			for i := range m.mat.Data {
+				m.mat.Data[i] = rnd.NormFloat64()
+			}
+		}
+
+		SetMaxProcs(1)
+		var want Dense
+		want.Mul(a, b)
+
+		for _, procs := range []int{2, 5, 16} {
+			SetMaxProcs(procs)
+			for _, args := range []struct {
+				name string
+				a, b Matrix
+			}{
+				{"dense", a, b},
+				{"vectorer", (*basicVectorer)(a), (*basicVectorer)(b)},
+				{"basic", (*basicMatrix)(a), (*basicMatrix)(b)},
+			} {
+				var got Dense
+				got.Mul(args.a, args.b)
+				var ok = true
+				for i, v := range got.mat.Data {
+					if math.Abs(v-want.mat.Data[i]) > 1e-12 {
+						ok = false
+						break
+					}
+				}
+				c.Check(ok, check.Equals, true, check.Commentf("%v procs=%d %s", test, procs, args.name))
+			}
+		}
+
+		// The receiver may alias an operand.
+		SetMaxProcs(4)
+		sq := DenseCopyOf(b)
+		if test.ac == test.bc {
+			var w Dense
+			w.Mul(a, sq)
+			a2 := DenseCopyOf(a)
+			a2.Mul(a2, sq)
+			c.Check(a2.EqualsApprox(&w, 1e-12), check.Equals, true)
+		}
+	}
+}
+
+func (s *S) TestApplyParallel(c *check.C) {
+	defer SetMaxProcs(SetMaxProcs(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(103, 17, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = rnd.NormFloat64()
+	}
+	fn := func(r, c int, v float64) float64 { return float64(r*100+c) + 2*v }
+	var want Dense
+	want.Apply(fn, a)
+
+	for _, chunk := range []int{0, 1, 10, 50, 1000} {
+		for _, arg := range []struct {
+			name string
+			a    Matrix
+		}{
+			{"dense", a},
+			{"vectorer", (*basicVectorer)(a)},
+			{"basic", (*basicMatrix)(a)},
+		} {
+			var got Dense
+			got.ApplyParallel(fn, arg.a, chunk)
+			c.Check(got.Equals(&want), check.Equals, true, check.Commentf("chunk=%d %s", chunk, arg.name))
+		}
+	}
+
+	// The receiver may be the operand.
+	b := DenseCopyOf(a)
+	b.ApplyParallel(fn, b, 7)
+	c.Check(b.Equals(&want), check.Equals, true)
+
+	c.Check(func() { NewDense(2, 2, nil).ApplyParallel(fn, a, 1) }, check.PanicMatches, string(ErrShape))
+}
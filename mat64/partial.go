@@ -0,0 +1,236 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math"
+
+// SymEigenFactors holds selected eigenpairs of a symmetric matrix. Values holds
+// the eigenvalues in ascending order and the columns of V the corresponding
+// orthonormal eigenvectors.
+type SymEigenFactors struct {
+	Values []float64
+	V      *Dense
+}
+
+// EigenSymExtreme returns the k largest eigenpairs of the symmetric matrix a if
+// largest is true, and otherwise the k smallest, without computing the rest of
+// the spectrum. The matrix a is overwritten.
+//
+// The matrix is reduced to tridiagonal form, the selected eigenvalues of the
+// tridiagonal matrix are located by bisection using Sturm sequence counts, and
+// their eigenvectors are found by inverse iteration and transformed back. For
+// k much less than n this avoids the accumulation of the QL iteration into all
+// n eigenvectors performed by Eigen. The starting vectors of the inverse
+// iteration are drawn using src.
+//
+// EigenSymExtreme will panic with ErrSquare if a is not square, with
+// ErrNotSymmetric if a is not symmetric and with ErrShape if k is not in [0, n].
+func EigenSymExtreme(a *Dense, k int, largest bool, src Source) SymEigenFactors {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	if k < 0 || k > n {
+		panic(ErrShape)
+	}
+	if !symmetric(a) {
+		panic(ErrNotSymmetric)
+	}
+	lo := 0
+	if largest {
+		lo = n - k
+	}
+	return eigenSymRange(a, lo, lo+k, src)
+}
+
+// eigenSymRange returns the eigenpairs of the symmetric matrix a with indices
+// [lo, hi) in the ascending order of the eigenvalues, overwriting a. The
+// starting vectors of the inverse iteration are drawn using src.
+func eigenSymRange(a *Dense, lo, hi int, src Source) SymEigenFactors {
+	n, _ := a.Dims()
+	k := hi - lo
+	f := SymEigenFactors{Values: make([]float64, k), V: &Dense{}}
+	if k == 0 {
+		return f
+	}
+
+	d := make([]float64, n)
+	e := make([]float64, n)
+	q := tred2(a, d, e)
+
+	var norm float64
+	for i := range d {
+		norm = math.Max(norm, math.Abs(d[i])+math.Abs(e[i])+math.Abs(e[min(i+1, n-1)]))
+	}
+	for j := range f.Values {
+		f.Values[j] = tridiagBisect(d, e, lo+j, norm)
+	}
+
+	// Find the eigenvectors of the tridiagonal matrix by inverse iteration
+	// from random starting vectors. The vectors of a cluster of
+	// close eigenvalues are orthogonalized against each other after each
+	// iteration, and the shifts within a cluster are separated so that the
+	// iterations do not converge to the same vector.
+	y := NewDense(k, n, nil)
+	w := newTridiagWork(n)
+	src = source(src)
+	sep := 10 * epsilon * norm
+	var shift float64
+	for j, lambda := range f.Values {
+		if j > 0 && lambda-shift < sep {
+			shift += sep
+		} else {
+			shift = lambda
+		}
+		first := j
+		for first > 0 && lambda-f.Values[first-1] <= 1e-3*norm {
+			first--
+		}
+
+		tridiagFactor(d, e, shift, norm, w)
+		v := y.rowView(j)
+		for i := range v {
+			v[i] = 2*src.Float64() - 1
+		}
+		for iter := 0; iter < 3; iter++ {
+			tridiagSolve(w, v)
+			for pass := 0; pass < 2; pass++ {
+				for i := first; i < j; i++ {
+					u := y.rowView(i)
+					axpyUnitary(-dotUnitary(u, v), u, v)
+				}
+			}
+			scalUnitary(1/Vec(v).Norm(2), v)
+		}
+	}
+
+	var yt Dense
+	yt.TCopy(y)
+	f.V.Mul(q, &yt)
+	return f
+}
+
+// sturmCount returns the number of eigenvalues of the symmetric tridiagonal
+// matrix with diagonal d and subdiagonal e[1:] that are less than x.
+func sturmCount(d, e []float64, x float64) int {
+	var count int
+	q := 1.0
+	for i := range d {
+		if i == 0 {
+			q = d[0] - x
+		} else {
+			q = d[i] - x - e[i]*e[i]/q
+		}
+		if q == 0 {
+			q = -math.SmallestNonzeroFloat64 / epsilon
+		}
+		if q < 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// tridiagBisect returns the eigenvalue with index idx, counting from zero in
+// ascending order, of the symmetric tridiagonal matrix with diagonal d and
+// subdiagonal e[1:], whose eigenvalues lie in [-norm, norm].
+func tridiagBisect(d, e []float64, idx int, norm float64) float64 {
+	lo, hi := -norm-epsilon, norm+epsilon
+	for hi-lo > 2*epsilon*math.Max(math.Abs(lo), math.Abs(hi))+math.SmallestNonzeroFloat64 {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if sturmCount(d, e, mid) > idx {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return lo + (hi-lo)/2
+}
+
+// tridiagWork holds the LU factors of a shifted tridiagonal matrix.
+type tridiagWork struct {
+	dl, dd, du, du2 []float64
+	piv             []bool
+}
+
+func newTridiagWork(n int) *tridiagWork {
+	return &tridiagWork{
+		dl:  make([]float64, n),
+		dd:  make([]float64, n),
+		du:  make([]float64, n),
+		du2: make([]float64, n),
+		piv: make([]bool, n),
+	}
+}
+
+// tridiagFactor computes in w the LU factorization with partial pivoting of
+// the symmetric tridiagonal matrix with diagonal d and subdiagonal e[1:] shifted
+// by -lambda, for inverse iteration. Zero pivots are replaced by a small
+// multiple of norm.
+func tridiagFactor(d, e []float64, lambda, norm float64, w *tridiagWork) {
+	n := len(d)
+	dl, dd, du, du2, piv := w.dl, w.dd, w.du, w.du2, w.piv
+	for i := 0; i < n; i++ {
+		dd[i] = d[i] - lambda
+		if i < n-1 {
+			dl[i] = e[i+1]
+			du[i] = e[i+1]
+		}
+		du2[i] = 0
+		piv[i] = false
+	}
+	tiny := epsilon * math.Max(norm, math.SmallestNonzeroFloat64)
+	for i := 0; i < n-1; i++ {
+		if math.Abs(dd[i]) >= math.Abs(dl[i]) {
+			if dd[i] == 0 {
+				dd[i] = tiny
+			}
+			fact := dl[i] / dd[i]
+			dl[i] = fact
+			dd[i+1] -= fact * du[i]
+		} else {
+			fact := dd[i] / dl[i]
+			dd[i] = dl[i]
+			dl[i] = fact
+			tmp := du[i]
+			du[i] = dd[i+1]
+			dd[i+1] = tmp - fact*dd[i+1]
+			if i < n-2 {
+				du2[i] = du[i+1]
+				du[i+1] = -fact * du[i+1]
+			}
+			piv[i] = true
+		}
+	}
+	if dd[n-1] == 0 {
+		dd[n-1] = tiny
+	}
+}
+
+// tridiagSolve overwrites v with the solution of the system factorized in w.
+func tridiagSolve(w *tridiagWork, v []float64) {
+	n := len(v)
+	dl, dd, du, du2, piv := w.dl, w.dd, w.du, w.du2, w.piv
+	for i := 0; i < n-1; i++ {
+		if piv[i] {
+			v[i], v[i+1] = v[i+1], v[i]-dl[i]*v[i+1]
+		} else {
+			v[i+1] -= dl[i] * v[i]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := v[i]
+		if i+1 < n {
+			s -= du[i] * v[i+1]
+		}
+		if i+2 < n {
+			s -= du2[i] * v[i+2]
+		}
+		v[i] = s / dd[i]
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestEigenSymExtreme(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 30} {
+		a := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j <= i; j++ {
+				v := rnd.NormFloat64()
+				a.Set(i, j, v)
+				a.Set(j, i, v)
+			}
+		}
+		want := Eigen(DenseCopyOf(a), epsilon)
+
+		for _, k := range []int{0, 1, 3, n} {
+			if k > n {
+				continue
+			}
+			for _, largest := range []bool{false, true} {
+				f := EigenSymExtreme(DenseCopyOf(a), k, largest, rnd)
+				comment := check.Commentf("n=%d k=%d largest=%t", n, k, largest)
+				c.Assert(len(f.Values), check.Equals, k, comment)
+				if k == 0 {
+					continue
+				}
+				off := 0
+				if largest {
+					off = n - k
+				}
+				for j, v := range f.Values {
+					c.Check(math.Abs(v-want.d[off+j]) < 1e-12, check.Equals, true, comment)
+				}
+
+				r, cols := f.V.Dims()
+				c.Check(r, check.Equals, n, comment)
+				c.Check(cols, check.Equals, k, comment)
+				c.Check(isOrthonormal(f.V, 1e-12), check.Equals, true, comment)
+				var av, vl Dense
+				av.Mul(a, f.V)
+				vl.Clone(f.V)
+				for j := 0; j < k; j++ {
+					for i := 0; i < n; i++ {
+						vl.Set(i, j, vl.At(i, j)*f.Values[j])
+					}
+				}
+				c.Check(av.EqualsApprox(&vl, 1e-10), check.Equals, true, comment)
+			}
+		}
+	}
+
+	// Repeated eigenvalues have orthogonal eigenvectors.
+	a := NewDense(4, 4, []float64{
+		2, 0, 0, 0,
+		0, 2, 0, 0,
+		0, 0, 2, 1,
+		0, 0, 1, 2,
+	})
+	f := EigenSymExtreme(DenseCopyOf(a), 3, false, nil)
+	for i, v := range []float64{1, 2, 2} {
+		c.Check(math.Abs(f.Values[i]-v) < 1e-14, check.Equals, true)
+	}
+	c.Check(isOrthonormal(f.V, 1e-12), check.Equals, true)
+
+	// Sources with the same seed give the same eigenvectors.
+	f = EigenSymExtreme(DenseCopyOf(a), 3, false, rand.New(rand.NewSource(7)))
+	g := EigenSymExtreme(DenseCopyOf(a), 3, false, rand.New(rand.NewSource(7)))
+	c.Check(f.V.Equals(g.V), check.Equals, true)
+
+	c.Check(func() { EigenSymExtreme(NewDense(2, 3, nil), 1, true, nil) }, check.PanicMatches, string(ErrSquare))
+	c.Check(func() { EigenSymExtreme(NewDense(2, 2, []float64{1, 2, 3, 4}), 1, true, nil) }, check.PanicMatches, string(ErrNotSymmetric))
+	c.Check(func() { EigenSymExtreme(NewDense(2, 2, nil), 3, true, nil) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,165 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// PCA holds a principal component analysis of a data matrix with observations
+// in its rows, such that the (scaled) centered data are approximated by
+// Transform(data).Components'.
+type PCA struct {
+	// Mean holds the column means of the data.
+	Mean []float64
+
+	// Scale holds the column standard deviations the centered data were
+	// divided by, or is nil if the data were not scaled.
+	Scale []float64
+
+	// Components holds the k principal directions, with unit norm, in
+	// its columns, in order of decreasing variance. The sign of each is
+	// chosen so that its element of largest magnitude is positive.
+	Components *Dense
+
+	// Variances holds the sample variances of the data along the
+	// principal directions.
+	Variances []float64
+
+	// total is the total sample variance of the (scaled) data.
+	total float64
+}
+
+// NewPCA returns the principal component analysis of the n-by-p data with
+// observations in its rows, retaining the k leading components. The columns are
+// centered and, if scale is true, divided by their sample standard deviations so
+// that the analysis is of the correlation rather than the covariance matrix;
+// constant columns are not scaled. The components are computed from the singular
+// value decomposition of the prepared data, which avoids the loss of accuracy
+// of forming the covariance matrix. The data are not modified.
+//
+// NewPCA will panic with ErrShape if n is less than two or k is not in
+// [1, min(n, p)].
+func NewPCA(data *Dense, k int, scale bool) *PCA {
+	n, p := data.Dims()
+	if n < 2 || k < 1 || k > min(n, p) {
+		panic(ErrShape)
+	}
+
+	x := DenseCopyOf(data)
+	pca := &PCA{Mean: Center(x, false).Offset}
+	if scale {
+		pca.Scale = make([]float64, p)
+		for i := 0; i < n; i++ {
+			for j, v := range x.rowView(i) {
+				pca.Scale[j] += v * v
+			}
+		}
+		for j, ss := range pca.Scale {
+			pca.Scale[j] = math.Sqrt(ss / float64(n-1))
+			if pca.Scale[j] == 0 {
+				pca.Scale[j] = 1
+			}
+		}
+		for i := 0; i < n; i++ {
+			row := x.rowView(i)
+			for j := range row {
+				row[j] /= pca.Scale[j]
+			}
+		}
+	}
+
+	svd := SVD(x, epsilon, small, false, true)
+	pca.Components = NewDense(p, k, nil)
+	pca.Variances = make([]float64, k)
+	for i, s := range svd.Sigma[:min(n, p)] {
+		v := s * s / float64(n-1)
+		pca.total += v
+		if i < k {
+			pca.Variances[i] = v
+		}
+	}
+	for j := 0; j < k; j++ {
+		var big float64
+		for i := 0; i < p; i++ {
+			if v := svd.V.At(i, j); math.Abs(v) > math.Abs(big) {
+				big = v
+			}
+		}
+		sign := 1.0
+		if big < 0 {
+			sign = -1
+		}
+		for i := 0; i < p; i++ {
+			pca.Components.Set(i, j, sign*svd.V.At(i, j))
+		}
+	}
+	return pca
+}
+
+// ExplainedVarianceRatio returns the fraction of the total variance of the data
+// accounted for by each retained component.
+func (pca *PCA) ExplainedVarianceRatio() []float64 {
+	r := make([]float64, len(pca.Variances))
+	if pca.total == 0 {
+		return r
+	}
+	for i, v := range pca.Variances {
+		r[i] = v / pca.total
+	}
+	return r
+}
+
+// Transform returns the scores of the observations in the rows of x, their
+// coordinates along the principal directions after centering and scaling as for
+// the data the analysis was computed from. Transform will panic with ErrShape if
+// x does not have the same number of columns as the data. The matrix x is not
+// modified.
+func (pca *PCA) Transform(x *Dense) *Dense {
+	r, c := x.Dims()
+	if c != len(pca.Mean) {
+		panic(ErrShape)
+	}
+	y := DenseCopyOf(x)
+	for i := 0; i < r; i++ {
+		row := y.rowView(i)
+		for j := range row {
+			row[j] -= pca.Mean[j]
+			if pca.Scale != nil {
+				row[j] /= pca.Scale[j]
+			}
+		}
+	}
+	_, k := pca.Components.Dims()
+	scores := NewDense(r, k, nil)
+	scores.Mul(y, pca.Components)
+	return scores
+}
+
+// InverseTransform returns the observations with the given scores, mapping them
+// back to the original coordinates of the data. For data of rank at most k it
+// inverts Transform; otherwise it gives the projection of the observations onto
+// the affine subspace spanned by the components. InverseTransform will panic with
+// ErrShape if scores does not have k columns.
+func (pca *PCA) InverseTransform(scores *Dense) *Dense {
+	r, k := scores.Dims()
+	if _, ck := pca.Components.Dims(); k != ck {
+		panic(ErrShape)
+	}
+	var ct Dense
+	ct.TCopy(pca.Components)
+	x := NewDense(r, len(pca.Mean), nil)
+	x.Mul(scores, &ct)
+	for i := 0; i < r; i++ {
+		row := x.rowView(i)
+		for j := range row {
+			if pca.Scale != nil {
+				row[j] *= pca.Scale[j]
+			}
+			row[j] += pca.Mean[j]
+		}
+	}
+	return x
+}
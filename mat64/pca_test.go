@@ -0,0 +1,118 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestPCA(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ n, p, k int }{
+		{n: 50, p: 4, k: 4},
+		{n: 50, p: 4, k: 2},
+		{n: 6, p: 10, k: 6},
+		{n: 6, p: 10, k: 3},
+	} {
+		// Correlated columns with different scales and offsets.
+		data := NewNormal(test.n, test.p, 0, 1, rnd)
+		mix := NewNormal(test.p, test.p, 0, 1, rnd)
+		data.Mul(data, mix)
+		for i := 0; i < test.n; i++ {
+			row := data.rowView(i)
+			for j := range row {
+				row[j] = float64(j+1)*row[j] + float64(10*j)
+			}
+		}
+		orig := DenseCopyOf(data)
+
+		for _, scale := range []bool{false, true} {
+			pca := NewPCA(data, test.k, scale)
+			c.Check(data.Equals(orig), check.Equals, true)
+			c.Check(isOrthonormal(pca.Components, 1e-12), check.Equals, true)
+
+			// The variances are the leading eigenvalues of the sample
+			// covariance, or correlation, matrix.
+			x := DenseCopyOf(data)
+			Center(x, false)
+			if scale {
+				for i := 0; i < test.n; i++ {
+					row := x.rowView(i)
+					for j := range row {
+						row[j] /= pca.Scale[j]
+					}
+				}
+			}
+			var xt, cov Dense
+			xt.TCopy(x)
+			cov.Mul(&xt, x)
+			cov.Scale(1/float64(test.n-1), &cov)
+			Symmetrize(&cov)
+			eigs := symEigenvalues(&cov)
+			var total float64
+			for _, v := range eigs {
+				total += v
+			}
+			if scale {
+				c.Check(math.Abs(total-float64(test.p)) < 1e-10, check.Equals, true)
+			}
+			ratio := pca.ExplainedVarianceRatio()
+			var sum float64
+			for i, v := range pca.Variances {
+				want := eigs[test.p-1-i]
+				c.Check(math.Abs(v-want) < 1e-9*total, check.Equals, true,
+					check.Commentf("got %v want %v", v, want))
+				c.Check(math.Abs(ratio[i]-want/total) < 1e-12, check.Equals, true)
+				sum += ratio[i]
+			}
+			if test.k == min(test.n, test.p) {
+				c.Check(math.Abs(sum-1) < 1e-12, check.Equals, true)
+			}
+
+			// The scores are uncorrelated with the component variances.
+			scores := pca.Transform(data)
+			var st, scov Dense
+			st.TCopy(scores)
+			scov.Mul(&st, scores)
+			scov.Scale(1/float64(test.n-1), &scov)
+			for i := 0; i < test.k; i++ {
+				for j := 0; j < test.k; j++ {
+					want := 0.0
+					if i == j {
+						want = pca.Variances[i]
+					}
+					c.Check(math.Abs(scov.At(i, j)-want) < 1e-9*total, check.Equals, true)
+				}
+			}
+
+			back := pca.InverseTransform(scores)
+			if test.k == min(test.n-1, test.p) || test.k == test.p {
+				c.Check(back.EqualsApprox(data, 1e-9), check.Equals, true)
+			}
+			// The reconstruction is a projection.
+			again := pca.InverseTransform(pca.Transform(back))
+			c.Check(again.EqualsApprox(back, 1e-9), check.Equals, true)
+		}
+	}
+
+	// A constant column is not scaled.
+	data := NewDense(3, 2, []float64{
+		1, 5,
+		2, 5,
+		4, 5,
+	})
+	pca := NewPCA(data, 1, true)
+	c.Check(pca.Scale[1], check.Equals, 1.0)
+	c.Check(math.Abs(pca.Components.At(0, 0)-1) < 1e-14, check.Equals, true)
+	c.Check(pca.ExplainedVarianceRatio()[0], check.Equals, 1.0)
+
+	c.Check(func() { NewPCA(data, 3, false) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { NewPCA(NewDense(1, 3, nil), 1, false) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { pca.Transform(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { pca.InverseTransform(NewDense(2, 2, nil)) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,198 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+const ErrSingularPencil = Error("mat64: matrix pencil is singular")
+
+// PencilFactors holds the deflation of the infinite eigenvalues of a square
+// matrix pencil a - λb. The orthogonal matrices Q and Z transform the pencil to
+//
+//  Q'.(a - λb).Z = [ A11 - λB11      0 ]
+//                  [ A21 - λB21    A22 ]
+//
+// where A and B hold the transformed pencil, B11 is a nonsingular matrix of
+// order Finite and A22 is nonsingular. The finite eigenvalues of the pencil are
+// those of A11 - λB11 and the remaining eigenvalues are infinite.
+type PencilFactors struct {
+	A, B *Dense
+	Q, Z *Dense
+
+	Finite int
+}
+
+// DeflateInfinite deflates the infinite eigenvalues of the square matrix pencil
+// a - λb, as arise from a singular b, by a sequence of rank revealing orthogonal
+// transformations that reduce the pencil to staircase form. Singular values of b
+// and of the intermediate blocks not greater than tol times the larger of the
+// Frobenius norms of a and b are treated as zero.
+//
+// DeflateInfinite will panic with ErrSingularPencil if a and b have a common
+// null vector at any stage of the reduction, that is, if det(a - λb) vanishes
+// identically and the pencil has no well defined eigenvalues. Neither a nor b
+// is modified.
+func DeflateInfinite(a, b *Dense, tol float64) PencilFactors {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	if br, bc := b.Dims(); br != n || bc != n {
+		panic(ErrShape)
+	}
+
+	thresh := tol * math.Max(a.Norm(0), b.Norm(0))
+
+	f := PencilFactors{
+		A: DenseCopyOf(a),
+		B: DenseCopyOf(b),
+		Q: identityDense(n),
+		Z: identityDense(n),
+	}
+
+	nk := n
+	for nk > 0 {
+		var bk, ak Dense
+		bk.Submatrix(f.B, 0, 0, nk, nk)
+		svd := SVD(&bk, epsilon, small, false, true)
+		r := rankAbove(svd.Sigma, thresh)
+		if r == nk {
+			break
+		}
+
+		// Move the null space of B11 to the trailing columns.
+		zk := svd.V
+		transformCols(f.A, zk)
+		transformCols(f.B, zk)
+		transformCols(f.Z, zk)
+
+		// Compress the columns of A corresponding to the null space of
+		// B11 into the trailing rows.
+		ak.Submatrix(f.A, 0, r, nk, nk-r)
+		u, sigma := fullLeftSingular(&ak)
+		if rankAbove(sigma, thresh) < nk-r {
+			panic(ErrSingularPencil)
+		}
+		qk := NewDense(nk, nk, nil)
+		for i := 0; i < nk; i++ {
+			for j := 0; j < nk; j++ {
+				qk.Set(i, (j+r)%nk, u.At(i, j))
+			}
+		}
+		transformRows(f.A, qk)
+		transformRows(f.B, qk)
+		transformCols(f.Q, qk)
+
+		// Clear the negligible blocks.
+		for i := 0; i < r; i++ {
+			zero(f.A.rowView(i)[r:nk])
+		}
+		for i := 0; i < nk; i++ {
+			zero(f.B.rowView(i)[r:nk])
+		}
+
+		nk = r
+	}
+	f.Finite = nk
+
+	return f
+}
+
+// Infinite returns the number of infinite eigenvalues of the pencil.
+func (f PencilFactors) Infinite() int {
+	n, _ := f.A.Dims()
+	return n - f.Finite
+}
+
+// FiniteEigenvalues returns the finite eigenvalues of the pencil, computed as the
+// eigenvalues of B11^-1.A11. The result may be inaccurate if B11 is badly
+// conditioned.
+func (f PencilFactors) FiniteEigenvalues() []complex128 {
+	if f.Finite == 0 {
+		return nil
+	}
+	var a11, b11 Dense
+	a11.Submatrix(f.A, 0, 0, f.Finite, f.Finite)
+	b11.Submatrix(f.B, 0, 0, f.Finite, f.Finite)
+	return Eigen(LU(&b11).Solve(&a11), epsilon).Values()
+}
+
+// IsSingularPencil returns whether the square matrix pencil a - λb is singular,
+// using tol as described for DeflateInfinite.
+func IsSingularPencil(a, b *Dense, tol float64) (singular bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != ErrSingularPencil {
+				panic(r)
+			}
+			singular = true
+		}
+	}()
+	DeflateInfinite(a, b, tol)
+	return false
+}
+
+func identityDense(n int) *Dense {
+	m := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
+
+// rankAbove returns the number of the decreasingly ordered singular values in
+// sigma that are greater than thresh.
+func rankAbove(sigma []float64, thresh float64) int {
+	var r int
+	for _, s := range sigma {
+		if s <= thresh {
+			break
+		}
+		r++
+	}
+	return r
+}
+
+// fullLeftSingular returns a square orthogonal matrix of left singular vectors of
+// the m-by-k matrix a, so that its leading columns span the range of a, and the
+// singular values of a.
+func fullLeftSingular(a *Dense) (*Dense, []float64) {
+	m, k := a.Dims()
+	if k >= m {
+		svd := SVD(DenseCopyOf(a), epsilon, small, true, false)
+		return svd.U, svd.Sigma
+	}
+	p := NewDense(m, m, nil)
+	for i := 0; i < m; i++ {
+		copy(p.rowView(i), a.rowView(i))
+	}
+	svd := SVD(p, epsilon, small, true, false)
+	return svd.U, svd.Sigma[:k]
+}
+
+// transformCols replaces the leading columns of m with their product with the
+// square matrix q.
+func transformCols(m, q *Dense) {
+	r, _ := m.Dims()
+	k, _ := q.Dims()
+	var w, tmp Dense
+	w.View(m, 0, 0, r, k)
+	tmp.Mul(&w, q)
+	w.Copy(&tmp)
+}
+
+// transformRows replaces the leading rows of m with their product with the
+// transpose of the square matrix q.
+func transformRows(m, q *Dense) {
+	_, c := m.Dims()
+	k, _ := q.Dims()
+	var qt, w, tmp Dense
+	qt.TCopy(q)
+	w.View(m, 0, 0, k, c)
+	tmp.Mul(&qt, &w)
+	w.Copy(&tmp)
+}
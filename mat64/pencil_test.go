@@ -0,0 +1,126 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math/cmplx"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestDeflateInfinite(c *check.C) {
+	for i, test := range []struct {
+		a, b     *Dense
+		finite   int
+		singular bool
+	}{
+		{
+			a: NewDense(3, 3, []float64{
+				1, 2, 0,
+				-1, 3, 1,
+				2, 0, 4,
+			}),
+			b:      NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 0}),
+			finite: 2,
+		},
+		{
+			// A nilpotent b of index 2 gives infinite eigenvalues
+			// that are only revealed at the second stage.
+			a: NewDense(3, 3, []float64{
+				1, 0, 0,
+				0, 1, 0,
+				0, 0, 1,
+			}),
+			b: NewDense(3, 3, []float64{
+				0, 1, 0,
+				0, 0, 0,
+				0, 0, 2,
+			}),
+			finite: 1,
+		},
+		{
+			a:      eye(),
+			b:      eye(),
+			finite: 3,
+		},
+		{
+			a:        NewDense(2, 2, []float64{1, 0, 1, 0}),
+			b:        NewDense(2, 2, []float64{2, 0, 3, 0}),
+			singular: true,
+		},
+	} {
+		orig := DenseCopyOf(test.a)
+		c.Check(IsSingularPencil(test.a, test.b, 1e-12), check.Equals, test.singular, check.Commentf("Test %d", i))
+		if test.singular {
+			c.Check(func() { DeflateInfinite(test.a, test.b, 1e-12) }, check.PanicMatches, string(ErrSingularPencil))
+			continue
+		}
+		f := DeflateInfinite(test.a, test.b, 1e-12)
+		c.Check(test.a.Equals(orig), check.Equals, true, check.Commentf("Test %d", i))
+		c.Check(f.Finite, check.Equals, test.finite, check.Commentf("Test %d", i))
+		n, _ := test.a.Dims()
+		c.Check(f.Infinite(), check.Equals, n-test.finite, check.Commentf("Test %d", i))
+
+		// Q.A.Z' recovers the pencil.
+		for _, pair := range [][2]*Dense{{f.A, test.a}, {f.B, test.b}} {
+			var zt, m Dense
+			zt.TCopy(f.Z)
+			m.Mul(f.Q, pair[0])
+			m.Mul(&m, &zt)
+			c.Check(m.EqualsApprox(pair[1], 1e-12), check.Equals, true, check.Commentf("Test %d", i))
+		}
+		for r := 0; r < f.Finite; r++ {
+			for col := f.Finite; col < n; col++ {
+				c.Check(f.A.At(r, col), check.Equals, 0.0)
+				c.Check(f.B.At(r, col), check.Equals, 0.0)
+			}
+		}
+
+		// The finite eigenvalues are roots of det(a - λb).
+		ev := f.FiniteEigenvalues()
+		c.Check(len(ev), check.Equals, test.finite, check.Commentf("Test %d", i))
+		for _, lambda := range ev {
+			c.Check(cmplx.Abs(complexPencilDet(test.a, test.b, lambda)) < 1e-10, check.Equals, true,
+				check.Commentf("Test %d: eigenvalue %v", i, lambda))
+		}
+	}
+}
+
+// complexPencilDet returns det(a - λb) by Gaussian elimination with partial
+// pivoting in complex arithmetic.
+func complexPencilDet(a, b *Dense, lambda complex128) complex128 {
+	n, _ := a.Dims()
+	m := make([][]complex128, n)
+	for i := range m {
+		m[i] = make([]complex128, n)
+		for j := range m[i] {
+			m[i][j] = complex(a.At(i, j), 0) - lambda*complex(b.At(i, j), 0)
+		}
+	}
+	det := complex(1, 0)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if cmplx.Abs(m[i][k]) > cmplx.Abs(m[p][k]) {
+				p = i
+			}
+		}
+		if m[p][k] == 0 {
+			return 0
+		}
+		if p != k {
+			m[p], m[k] = m[k], m[p]
+			det = -det
+		}
+		det *= m[k][k]
+		for i := k + 1; i < n; i++ {
+			f := m[i][k] / m[k][k]
+			for j := k; j < n; j++ {
+				m[i][j] -= f * m[k][j]
+			}
+		}
+	}
+	return det
+}
@@ -0,0 +1,206 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+var (
+	permutation Permutation
+
+	_ Matrix = permutation
+)
+
+// Permutation is a compact representation of an n-by-n permutation matrix P.
+// Row i of P has its single unit element in column p[i], so the product P.a
+// is the matrix whose i-th row is row p[i] of a. This is the convention used by
+// the Pivot field of LUFactors.
+type Permutation []int
+
+// IdentityPermutation returns the identity permutation of length n.
+func IdentityPermutation(n int) Permutation {
+	p := make(Permutation, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// Dims returns the dimensions of the permutation matrix.
+func (p Permutation) Dims() (r, c int) { return len(p), len(p) }
+
+// At returns the value of the permutation matrix element at (r, c).
+func (p Permutation) At(r, c int) float64 {
+	if r < 0 || r >= len(p) || c < 0 || c >= len(p) {
+		panic(ErrIndexOutOfRange)
+	}
+	if p[r] == c {
+		return 1
+	}
+	return 0
+}
+
+// Valid returns whether p is a permutation of 0 through len(p)-1.
+func (p Permutation) Valid() bool {
+	seen := make([]bool, len(p))
+	for _, v := range p {
+		if v < 0 || v >= len(p) || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// Inverse returns the inverse, and equivalently the transpose, of the
+// permutation p. Inverse will panic with ErrPivot if p is not valid.
+func (p Permutation) Inverse() Permutation {
+	if !p.Valid() {
+		panic(ErrPivot)
+	}
+	inv := make(Permutation, len(p))
+	for i, v := range p {
+		inv[v] = i
+	}
+	return inv
+}
+
+// Compose returns the permutation corresponding to the matrix product P.Q.
+// Compose will panic with ErrShape if p and q have different lengths.
+func (p Permutation) Compose(q Permutation) Permutation {
+	if len(p) != len(q) {
+		panic(ErrShape)
+	}
+	r := make(Permutation, len(p))
+	for i, v := range p {
+		r[i] = q[v]
+	}
+	return r
+}
+
+// Sign returns the sign of the permutation: 1 if it is even and -1 if it is
+// odd. This is the determinant of the permutation matrix. Sign will panic with
+// ErrPivot if p is not valid.
+func (p Permutation) Sign() int {
+	if !p.Valid() {
+		panic(ErrPivot)
+	}
+	visit := make([]bool, len(p))
+	sign := 1
+	for i := range p {
+		if visit[i] {
+			continue
+		}
+		for j := i; !visit[j]; j = p[j] {
+			visit[j] = true
+			if p[j] != i {
+				sign = -sign
+			}
+		}
+	}
+	return sign
+}
+
+// PermuteRows places the product P.a into the receiver, so that row i of the
+// receiver is row p[i] of a. The receiver may share storage with a, in which
+// case a is first copied. PermuteRows will panic with ErrShape if the length of p
+// does not match the number of rows of a and with ErrPivot if p is not valid.
+func (m *Dense) PermuteRows(p Permutation, a Matrix) {
+	ar, ac := a.Dims()
+	if len(p) != ar {
+		panic(ErrShape)
+	}
+	if !p.Valid() {
+		panic(ErrPivot)
+	}
+
+	if m.isZero() {
+		m.mat = RawMatrix{
+			Rows:   ar,
+			Cols:   ac,
+			Stride: ac,
+			Data:   use(m.mat.Data, ar*ac),
+		}
+	} else if ar != m.mat.Rows || ac != m.mat.Cols {
+		panic(ErrShape)
+	}
+
+	src := a
+	if m.overlaps(a) {
+		w := GetDense(ar, ac, false)
+		w.Copy(a)
+		defer PutDense(w)
+		src = w
+	}
+
+	switch src := src.(type) {
+	case RawMatrixer:
+		smat := src.RawMatrix()
+		for i, v := range p {
+			copy(m.rowView(i), smat.Data[v*smat.Stride:v*smat.Stride+ac])
+		}
+	case Vectorer:
+		for i, v := range p {
+			src.Row(m.rowView(i), v)
+		}
+	default:
+		for i, v := range p {
+			row := m.rowView(i)
+			for j := range row {
+				row[j] = src.At(v, j)
+			}
+		}
+	}
+}
+
+// PermuteCols places the product a.P' into the receiver, so that column j of
+// the receiver is column p[j] of a. The product a.P is obtained by passing
+// p.Inverse(). The receiver may share storage with a as for PermuteRows.
+// PermuteCols will panic with ErrShape if the length of p does not match the
+// number of columns of a and with ErrPivot if p is not valid.
+func (m *Dense) PermuteCols(p Permutation, a Matrix) {
+	ar, ac := a.Dims()
+	if len(p) != ac {
+		panic(ErrShape)
+	}
+	if !p.Valid() {
+		panic(ErrPivot)
+	}
+
+	if m.isZero() {
+		m.mat = RawMatrix{
+			Rows:   ar,
+			Cols:   ac,
+			Stride: ac,
+			Data:   use(m.mat.Data, ar*ac),
+		}
+	} else if ar != m.mat.Rows || ac != m.mat.Cols {
+		panic(ErrShape)
+	}
+
+	src := a
+	if m.overlaps(a) {
+		w := GetDense(ar, ac, false)
+		w.Copy(a)
+		defer PutDense(w)
+		src = w
+	}
+
+	if src, ok := src.(RawMatrixer); ok {
+		smat := src.RawMatrix()
+		for i := 0; i < ar; i++ {
+			srow := smat.Data[i*smat.Stride : i*smat.Stride+ac]
+			row := m.rowView(i)
+			for j := range row {
+				row[j] = srow[p[j]]
+			}
+		}
+		return
+	}
+
+	for i := 0; i < ar; i++ {
+		row := m.rowView(i)
+		for j := range row {
+			row[j] = src.At(i, p[j])
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestPermutation(c *check.C) {
+	a := NewDense(3, 4, []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+	for i, test := range []struct {
+		p, q Permutation
+		sign int
+	}{
+		{p: Permutation{0, 1, 2}, q: Permutation{2, 0, 1}, sign: 1},
+		{p: Permutation{1, 0, 2}, q: Permutation{0, 2, 1}, sign: -1},
+		{p: Permutation{2, 0, 1}, q: Permutation{2, 1, 0}, sign: 1},
+		{p: Permutation{2, 1, 0}, q: Permutation{1, 2, 0}, sign: -1},
+	} {
+		pd, qd := DenseCopyOf(test.p), DenseCopyOf(test.q)
+
+		var want, got Dense
+		want.Mul(pd, a)
+		got.PermuteRows(test.p, a)
+		c.Check(got.Equals(&want), check.Equals, true, check.Commentf("Test %d: rows", i))
+
+		inPlace := DenseCopyOf(a)
+		inPlace.PermuteRows(test.p, inPlace)
+		c.Check(inPlace.Equals(&want), check.Equals, true, check.Commentf("Test %d: rows in place", i))
+
+		at := &Dense{}
+		at.TCopy(a)
+		var pt Dense
+		pt.TCopy(pd)
+		want.Reset()
+		want.Mul(at, &pt)
+		got.Reset()
+		got.PermuteCols(test.p, at)
+		c.Check(got.Equals(&want), check.Equals, true, check.Commentf("Test %d: cols", i))
+
+		want.Reset()
+		want.Mul(pd, qd)
+		c.Check(want.Equals(test.p.Compose(test.q)), check.Equals, true, check.Commentf("Test %d: compose", i))
+
+		want.Reset()
+		want.Mul(pd, test.p.Inverse())
+		c.Check(want.Equals(eye()), check.Equals, true, check.Commentf("Test %d: inverse", i))
+
+		c.Check(test.p.Sign(), check.Equals, test.sign, check.Commentf("Test %d: sign", i))
+	}
+
+	c.Check(Permutation{0, 0, 1}.Valid(), check.Equals, false)
+	c.Check(Permutation{0, 3, 1}.Valid(), check.Equals, false)
+	c.Check(func() { a.PermuteRows(Permutation{0, 0, 1}, a) }, check.PanicMatches, string(ErrPivot))
+	c.Check(func() { a.PermuteRows(Permutation{0, 1}, a) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestPermuteOverlap(c *check.C) {
+	big := NewDense(4, 4, []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	})
+	p := Permutation{2, 0, 1}
+
+	// The receiver is the operand shifted down by one row.
+	m := DenseCopyOf(big)
+	var dst, src Dense
+	dst.View(m, 1, 0, 3, 4)
+	src.View(m, 0, 0, 3, 4)
+	dst.PermuteRows(p, &src)
+	c.Check(m.Equals(NewDense(4, 4, []float64{
+		1, 2, 3, 4,
+		9, 10, 11, 12,
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+	})), check.Equals, true, check.Commentf("got %v", m))
+
+	// The receiver is the operand shifted right by one column.
+	m = DenseCopyOf(big)
+	dst.View(m, 0, 1, 4, 3)
+	src.View(m, 0, 0, 4, 3)
+	dst.PermuteCols(p, &src)
+	c.Check(m.Equals(NewDense(4, 4, []float64{
+		1, 3, 1, 2,
+		5, 7, 5, 6,
+		9, 11, 9, 10,
+		13, 15, 13, 14,
+	})), check.Equals, true, check.Commentf("got %v", m))
+
+	// An overlapping receiver of the wrong shape is rejected before any
+	// element is written.
+	m = DenseCopyOf(big)
+	dst.View(m, 0, 0, 2, 2)
+	c.Check(func() { dst.PermuteRows(Permutation{3, 2, 1, 0}, m) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { dst.PermuteCols(Permutation{3, 2, 1, 0}, m) }, check.PanicMatches, string(ErrShape))
+	c.Check(m.Equals(big), check.Equals, true)
+}
+
+func (s *S) TestLUPermutation(c *check.C) {
+	a := NewDense(3, 3, []float64{
+		1, 2, 3,
+		6, -1, 0,
+		-1, -2, -1,
+	})
+	lu := LU(DenseCopyOf(a))
+
+	var pa, l Dense
+	pa.PermuteRows(lu.P(), a)
+	l.Mul(lu.L(), lu.U())
+	c.Check(pa.EqualsApprox(&l, 1e-12), check.Equals, true)
+	c.Check(lu.P().Sign(), check.Equals, lu.Sign)
+
+	// Modifying the permutation does not change the factorization.
+	pivot := append([]int(nil), lu.Pivot...)
+	p := lu.P()
+	p[0], p[1] = p[1], p[0]
+	c.Check(lu.Pivot, check.DeepEquals, pivot)
+}
@@ -0,0 +1,107 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// PivotedCholeskyFactor holds the Cholesky factorization with diagonal pivoting
+// P.a.P' = L.L' of a symmetric positive semi-definite matrix a, where L is the
+// n-by-Rank lower trapezoidal factor and row i of P.a is row Pivot[i] of a.
+type PivotedCholeskyFactor struct {
+	L     *Dense
+	Pivot []int
+	Rank  int
+}
+
+// PivotedCholesky returns the Cholesky factorization of the symmetric positive
+// semi-definite matrix a with complete diagonal pivoting. At each step the
+// largest remaining diagonal element is chosen as the pivot, and the
+// factorization stops when it is not greater than tol, giving the numerical
+// rank of a. If tol is negative, a tolerance of n.ε times the largest diagonal
+// element of a is used. The matrix a is not modified.
+//
+// Unlike Cholesky, the factorization succeeds for rank deficient matrices such
+// as the sample covariance matrix of fewer observations than variables, or of
+// linearly dependent variables, and the rank determined is reliable in practice.
+//
+// PivotedCholesky will panic with ErrSquare if a is not square and with
+// ErrNotSymmetric if a is not symmetric.
+func PivotedCholesky(a *Dense, tol float64) PivotedCholeskyFactor {
+	m, n := a.Dims()
+	if m != n {
+		panic(ErrSquare)
+	}
+	if !symmetric(a) {
+		panic(ErrNotSymmetric)
+	}
+
+	piv := make([]int, n)
+	diag := make([]float64, n)
+	var maxDiag float64
+	for i := range piv {
+		piv[i] = i
+		diag[i] = a.At(i, i)
+		maxDiag = math.Max(maxDiag, diag[i])
+	}
+	if tol < 0 {
+		tol = float64(n) * epsilon * maxDiag
+	}
+
+	// l holds the factor as it is formed, with its rows in the current
+	// pivoted order.
+	l := NewDense(n, n, nil)
+	var rank int
+	for ; rank < n; rank++ {
+		k := rank
+		p := k
+		for i := k + 1; i < n; i++ {
+			if diag[i] > diag[p] {
+				p = i
+			}
+		}
+		if diag[p] <= tol {
+			break
+		}
+		if p != k {
+			piv[k], piv[p] = piv[p], piv[k]
+			diag[k], diag[p] = diag[p], diag[k]
+			lk, lp := l.rowView(k)[:k], l.rowView(p)[:k]
+			for j := range lk {
+				lk[j], lp[j] = lp[j], lk[j]
+			}
+		}
+
+		lkk := math.Sqrt(diag[k])
+		l.Set(k, k, lkk)
+		lk := l.rowView(k)[:k]
+		for i := k + 1; i < n; i++ {
+			li := l.rowView(i)
+			v := (a.At(piv[i], piv[k]) - dotUnitary(li[:k], lk)) / lkk
+			li[k] = v
+			diag[i] -= v * v
+		}
+	}
+
+	f := PivotedCholeskyFactor{L: NewDense(n, rank, nil), Pivot: piv, Rank: rank}
+	for i := 0; i < n; i++ {
+		copy(f.L.rowView(i), l.rowView(i)[:rank])
+	}
+	return f
+}
+
+// Factor returns the n-by-Rank matrix g such that a = g.g' to within the
+// tolerance of the factorization, formed by undoing the pivoting of L. For a
+// covariance matrix, g maps independent standard normal variables to correlated
+// ones.
+func (f PivotedCholeskyFactor) Factor() *Dense {
+	n, r := f.L.Dims()
+	g := NewDense(n, r, nil)
+	for i, p := range f.Pivot {
+		copy(g.rowView(p), f.L.rowView(i))
+	}
+	return g
+}
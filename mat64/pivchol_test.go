@@ -0,0 +1,70 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math/rand"
+)
+
+func (s *S) TestPivotedCholesky(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, t := range []struct{ n, rank int }{
+		{1, 1},
+		{3, 3},
+		{6, 3},
+		{10, 1},
+		{10, 10},
+	} {
+		// a = x.x' has the rank of the n-by-rank x.
+		x := NewDense(t.n, t.rank, nil)
+		for i := 0; i < t.n; i++ {
+			for j := 0; j < t.rank; j++ {
+				x.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		var xt, a Dense
+		xt.TCopy(x)
+		a.Mul(x, &xt)
+		Symmetrize(&a)
+		orig := DenseCopyOf(&a)
+
+		f := PivotedCholesky(&a, -1)
+		c.Check(a.Equals(orig), check.Equals, true)
+		c.Check(f.Rank, check.Equals, t.rank, check.Commentf("n=%d", t.n))
+		r, cols := f.L.Dims()
+		c.Check([]int{r, cols}, check.DeepEquals, []int{t.n, t.rank})
+
+		// The pivot leads with the largest diagonal elements, so the
+		// diagonal of L is non-increasing.
+		for k := 1; k < f.Rank; k++ {
+			c.Check(f.L.At(k, k) <= f.L.At(k-1, k-1), check.Equals, true)
+		}
+
+		g := f.Factor()
+		var gt, ggt Dense
+		gt.TCopy(g)
+		ggt.Mul(g, &gt)
+		c.Check(ggt.EqualsApprox(orig, 1e-10), check.Equals, true, check.Commentf("n=%d rank=%d", t.n, t.rank))
+	}
+
+	// A positive definite matrix has the same factorization as Cholesky
+	// when no pivoting is needed.
+	a := NewDense(3, 3, []float64{
+		4, 2, 1,
+		2, 3, 1,
+		1, 1, 2,
+	})
+	f := PivotedCholesky(a, -1)
+	c.Check(f.Pivot, check.DeepEquals, []int{0, 1, 2})
+	c.Check(f.L.EqualsApprox(Cholesky(a).L, 1e-14), check.Equals, true)
+
+	// A matrix with no positive diagonal has rank zero.
+	f = PivotedCholesky(NewDense(2, 2, []float64{-1, 0, 0, -2}), -1)
+	c.Check(f.Rank, check.Equals, 0)
+
+	c.Check(func() { PivotedCholesky(NewDense(2, 3, nil), -1) }, check.PanicMatches, string(ErrSquare))
+	c.Check(func() { PivotedCholesky(NewDense(2, 2, []float64{1, 2, 3, 4}), -1) }, check.PanicMatches, string(ErrNotSymmetric))
+}
@@ -0,0 +1,195 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// PLSFactors holds a partial least squares regression of the responses y on
+// the predictors x. With k components, the centered predictors and responses
+// are approximated by
+//
+//  x = T.P'
+//  y = T.Q'
+//
+// where the scores T = x.W.(P'.W)^-1 are the projections of the centered
+// predictors onto the weights W.
+type PLSFactors struct {
+	// W, P and Q hold the weights, the predictor loadings and the
+	// response loadings of the components in their columns.
+	W, P, Q *Dense
+
+	// T holds the predictor scores of the observations in its columns.
+	T *Dense
+
+	// Coef holds the regression coefficients of the centered responses
+	// on the centered predictors.
+	Coef *Dense
+
+	// XMean and YMean hold the column means of x and y.
+	XMean, YMean []float64
+}
+
+const (
+	plsTol     = 1e-12
+	plsMaxIter = 500
+)
+
+// PLS computes the partial least squares regression of the n-by-m responses y
+// on the n-by-p predictors x with k components using the NIPALS algorithm.
+// Each component is found by alternating between the predictor and response
+// blocks, and both blocks are deflated by the component's scores before the
+// next is found. PLS will panic with ErrShape if x and y have different numbers
+// of rows or if k is not in [1, min(n, p)], and with ErrSingular if the
+// predictors have rank less than k. Neither x nor y is modified.
+func PLS(x, y *Dense, k int) PLSFactors {
+	n, p := x.Dims()
+	yr, m := y.Dims()
+	if yr != n || k < 1 || k > min(n, p) {
+		panic(ErrShape)
+	}
+
+	e := DenseCopyOf(x)
+	f := DenseCopyOf(y)
+	pf := PLSFactors{
+		W:     NewDense(p, k, nil),
+		P:     NewDense(p, k, nil),
+		Q:     NewDense(m, k, nil),
+		T:     NewDense(n, k, nil),
+		XMean: Center(e, false).Offset,
+		YMean: Center(f, false).Offset,
+	}
+
+	w := make([]float64, p)
+	t := make([]float64, n)
+	u := make([]float64, n)
+	q := make([]float64, m)
+	load := make([]float64, p)
+	var sweeps int
+	for comp := 0; comp < k; comp++ {
+		// Start from the response column with the largest sum of squares.
+		var best int
+		var bestSS float64
+		for j := 0; j < m; j++ {
+			var ss float64
+			for i := 0; i < n; i++ {
+				v := f.at(i, j)
+				ss += v * v
+			}
+			if ss > bestSS {
+				best, bestSS = j, ss
+			}
+		}
+		f.Col(u, best)
+
+		var tt float64
+		for iter := 0; iter < plsMaxIter; iter++ {
+			// w = x'.u / |x'.u|
+			mulTransVec(w, e, u)
+			norm := Vec(w).Norm(2)
+			if norm == 0 {
+				panic(ErrSingular)
+			}
+			for j := range w {
+				w[j] /= norm
+			}
+
+			// t = x.w
+			var diff float64
+			tt = 0
+			for i := 0; i < n; i++ {
+				v := dotUnitary(e.rowView(i), w)
+				d := v - t[i]
+				diff += d * d
+				t[i] = v
+				tt += v * v
+			}
+			if tt == 0 {
+				panic(ErrSingular)
+			}
+
+			// q = y'.t / t'.t and u = y.q / q'.q
+			mulTransVec(q, f, t)
+			for j := range q {
+				q[j] /= tt
+			}
+			qq := dotUnitary(q, q)
+			if qq == 0 || m == 1 {
+				break
+			}
+			for i := 0; i < n; i++ {
+				u[i] = dotUnitary(f.rowView(i), q) / qq
+			}
+			sweeps++
+			if progress != nil {
+				progress("PLS", sweeps, math.Sqrt(diff/tt))
+			}
+			if iter > 0 && math.Sqrt(diff) <= plsTol*math.Sqrt(tt) {
+				break
+			}
+		}
+
+		// Deflate both blocks by the scores.
+		mulTransVec(load, e, t)
+		for j := range load {
+			load[j] /= tt
+		}
+		for i := 0; i < n; i++ {
+			row := e.rowView(i)
+			for j := range row {
+				row[j] -= t[i] * load[j]
+			}
+			row = f.rowView(i)
+			for j := range row {
+				row[j] -= t[i] * q[j]
+			}
+		}
+
+		pf.W.SetCol(comp, w)
+		pf.P.SetCol(comp, load)
+		pf.Q.SetCol(comp, q)
+		pf.T.SetCol(comp, t)
+	}
+
+	// Coef = W.(P'.W)^-1.Q'
+	var pt, pw, qt, r Dense
+	pt.TCopy(pf.P)
+	pw.Mul(&pt, pf.W)
+	lu := LU(&pw)
+	if lu.IsSingular() {
+		panic(ErrSingular)
+	}
+	qt.TCopy(pf.Q)
+	r.Mul(pf.W, lu.Solve(&qt))
+	pf.Coef = &r
+
+	return pf
+}
+
+// Predict returns the responses predicted by the regression for the predictors
+// held in the rows of x.
+func (f PLSFactors) Predict(x Matrix) *Dense {
+	n, p := x.Dims()
+	if p != len(f.XMean) {
+		panic(ErrShape)
+	}
+	xc := DenseCopyOf(x)
+	for i := 0; i < n; i++ {
+		row := xc.rowView(i)
+		for j := range row {
+			row[j] -= f.XMean[j]
+		}
+	}
+	var y Dense
+	y.Mul(xc, f.Coef)
+	for i := 0; i < n; i++ {
+		row := y.rowView(i)
+		for j := range row {
+			row[j] += f.YMean[j]
+		}
+	}
+	return &y
+}
@@ -0,0 +1,84 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestPLS(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	n, p, m := 30, 4, 2
+	x := NewDense(n, p, nil)
+	y := NewDense(n, m, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < p; j++ {
+			x.Set(i, j, rnd.NormFloat64()+float64(j))
+		}
+		y.Set(i, 0, 1+2*x.At(i, 0)-x.At(i, 2)+0.1*rnd.NormFloat64())
+		y.Set(i, 1, -3+x.At(i, 1)+0.5*x.At(i, 3)+0.1*rnd.NormFloat64())
+	}
+	xOrig, yOrig := DenseCopyOf(x), DenseCopyOf(y)
+
+	// With all components PLS agrees with ordinary least squares on the
+	// centered data.
+	f := PLS(x, y, p)
+	c.Check(x.Equals(xOrig), check.Equals, true)
+	c.Check(y.Equals(yOrig), check.Equals, true)
+
+	xc, yc := DenseCopyOf(x), DenseCopyOf(y)
+	Center(xc, false)
+	Center(yc, false)
+	ols := QR(DenseCopyOf(xc)).Solve(yc)
+	c.Check(f.Coef.EqualsApprox(ols, 1e-8), check.Equals, true)
+
+	// The weights are orthonormal and the scores are orthogonal.
+	c.Check(isOrthonormal(f.W, 1e-10), check.Equals, true)
+	for i := 0; i < p; i++ {
+		for j := i + 1; j < p; j++ {
+			ti, tj := f.T.Col(nil, i), f.T.Col(nil, j)
+			c.Check(math.Abs(dotUnitary(ti, tj)) < 1e-8, check.Equals, true)
+		}
+	}
+
+	// Predictions reproduce the least squares fit.
+	pred := f.Predict(x)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			var want float64
+			for l := 0; l < p; l++ {
+				want += xc.At(i, l) * ols.At(l, j)
+			}
+			want += f.YMean[j]
+			c.Check(math.Abs(pred.At(i, j)-want) < 1e-8, check.Equals, true)
+		}
+	}
+
+	// Fewer components give a fit that is no better than least squares.
+	sse := func(pred *Dense) float64 {
+		var s float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < m; j++ {
+				d := pred.At(i, j) - y.At(i, j)
+				s += d * d
+			}
+		}
+		return s
+	}
+	best := sse(pred)
+	for k := 1; k < p; k++ {
+		f = PLS(x, y, k)
+		r, cols := f.Coef.Dims()
+		c.Check(r, check.Equals, p)
+		c.Check(cols, check.Equals, m)
+		c.Check(sse(f.Predict(x)) >= best-1e-10, check.Equals, true)
+	}
+
+	c.Check(func() { PLS(x, y, 5) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { PLS(x, NewDense(3, 1, nil), 1) }, check.PanicMatches, string(ErrShape))
+}
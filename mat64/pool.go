@@ -0,0 +1,140 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// poolBuckets is the number of size classes held by the buffer pool. Class b
+// holds slices with capacity of at least 1<<b.
+const poolBuckets = 48
+
+var floatPool [poolBuckets]sync.Pool
+
+// GetFloats returns a float64 slice of length l from the package's buffer pool,
+// allocating one if none of sufficient capacity is available. If clear is true
+// the elements are zeroed, otherwise they hold arbitrary values. If an
+// Allocator has been set, the slice is obtained from it instead.
+//
+// The caller owns the returned slice until it is passed to PutFloats.
+func GetFloats(l int, clear bool) []float64 {
+	if l < 0 {
+		panic(ErrShape)
+	}
+	atomic.AddInt64(&poolStats.Gets, 1)
+	if allocator != nil {
+		return allocator.Alloc(l)
+	}
+	b := ceilLog2(l)
+	if b >= poolBuckets {
+		return make([]float64, l)
+	}
+	v := floatPool[b].Get()
+	if v == nil {
+		return make([]float64, l, 1<<uint(b))
+	}
+	f := (*v.(*[]float64))[:l]
+	atomic.AddInt64(&poolStats.Hits, 1)
+	atomic.AddInt64(&poolStats.Bytes, -floatBytes(f))
+	if clear && l > 0 {
+		zero(f)
+	}
+	return f
+}
+
+// PutFloats returns f to the package's buffer pool for reuse by later calls to
+// GetFloats and GetDense, or to the Allocator if one has been set. The caller
+// must not retain f, or any slice sharing its backing array, after the call.
+func PutFloats(f []float64) {
+	c := cap(f)
+	if c == 0 {
+		return
+	}
+	if allocator != nil {
+		atomic.AddInt64(&poolStats.Puts, 1)
+		allocator.Free(f)
+		return
+	}
+	// Place the slice in the largest class it can serve.
+	b := ceilLog2(c)
+	if 1<<uint(b) > c {
+		b--
+	}
+	if b >= poolBuckets {
+		return
+	}
+	f = f[:c]
+	atomic.AddInt64(&poolStats.Puts, 1)
+	atomic.AddInt64(&poolStats.Bytes, floatBytes(f))
+	floatPool[b].Put(&f)
+}
+
+// GetDense returns an r-by-c matrix whose storage is taken from the package's
+// buffer pool. If clear is true the elements are zeroed, otherwise they hold
+// arbitrary values. GetDense is intended for temporary matrices in code that
+// performs many operations of the same size, where it reduces the load on the
+// garbage collector.
+//
+// The caller owns the returned matrix until it is passed to PutDense.
+func GetDense(r, c int, clear bool) *Dense {
+	if r < 0 || c < 0 {
+		panic(ErrShape)
+	}
+	return &Dense{RawMatrix{
+		Rows:   r,
+		Cols:   c,
+		Stride: c,
+		Data:   GetFloats(r*c, clear),
+	}}
+}
+
+// PutDense returns the storage of m to the package's buffer pool and resets m
+// to a zero matrix. The matrix m must own its storage, as a matrix obtained from
+// GetDense or NewDense does; it must not be a view of another matrix, and no
+// view of m may be used after the call.
+func PutDense(m *Dense) {
+	PutFloats(m.mat.Data)
+	m.mat = RawMatrix{}
+}
+
+// ceilLog2 returns the smallest b such that 1<<b >= n.
+func ceilLog2(n int) int {
+	var b int
+	for 1<<uint(b) < n {
+		b++
+	}
+	return b
+}
+
+// PoolStats holds counts of the use of the package's buffer pool.
+type PoolStats struct {
+	// Gets is the number of slices requested by GetFloats and GetDense,
+	// and Hits the number of those served from the pool rather than
+	// allocated.
+	Gets, Hits int64
+
+	// Puts is the number of slices returned to the pool by PutFloats and
+	// PutDense.
+	Puts int64
+
+	// Bytes is the storage held by the pool. The garbage collector may
+	// free pooled slices at any time, so Bytes is an upper bound.
+	Bytes int64
+}
+
+var poolStats PoolStats
+
+// ReadPoolStats returns the counts of the use of the package's buffer pool
+// since the program started.
+func ReadPoolStats() PoolStats {
+	return PoolStats{
+		Gets:  atomic.LoadInt64(&poolStats.Gets),
+		Hits:  atomic.LoadInt64(&poolStats.Hits),
+		Puts:  atomic.LoadInt64(&poolStats.Puts),
+		Bytes: atomic.LoadInt64(&poolStats.Bytes),
+	}
+}
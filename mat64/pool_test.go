@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestCeilLog2(c *check.C) {
+	for n, want := range map[int]int{0: 0, 1: 0, 2: 1, 3: 2, 4: 2, 5: 3, 1024: 10, 1025: 11} {
+		c.Check(ceilLog2(n), check.Equals, want, check.Commentf("n=%d", n))
+	}
+}
+
+func (s *S) TestPool(c *check.C) {
+	for _, l := range []int{0, 1, 3, 8, 100, 1000} {
+		f := GetFloats(l, true)
+		c.Check(len(f), check.Equals, l)
+		c.Check(cap(f) >= l, check.Equals, true)
+		for _, v := range f {
+			c.Check(v, check.Equals, 0.)
+		}
+		for i := range f {
+			f[i] = 1
+		}
+		PutFloats(f)
+
+		g := GetFloats(l, true)
+		c.Check(len(g), check.Equals, l)
+		for _, v := range g {
+			c.Check(v, check.Equals, 0.)
+		}
+		PutFloats(g)
+	}
+
+	// Slices with capacity that is not a power of two are pooled in the
+	// class they can fully serve.
+	PutFloats(make([]float64, 3, 7))
+	for i := 0; i < 10; i++ {
+		c.Check(cap(GetFloats(5, false)) >= 5, check.Equals, true)
+	}
+
+	m := GetDense(3, 4, true)
+	r, cols := m.Dims()
+	c.Check(r, check.Equals, 3)
+	c.Check(cols, check.Equals, 4)
+	c.Check(m.Equals(NewDense(3, 4, nil)), check.Equals, true)
+	m.Set(1, 2, 5)
+	PutDense(m)
+	c.Check(m.isZero(), check.Equals, true)
+
+	// The reset matrix may be used as a receiver.
+	m.Clone(NewDense(2, 2, []float64{1, 2, 3, 4}))
+	c.Check(m.Equals(NewDense(2, 2, []float64{1, 2, 3, 4})), check.Equals, true)
+
+	c.Check(GetDense(3, 4, true).Equals(NewDense(3, 4, nil)), check.Equals, true)
+	c.Check(func() { GetFloats(-1, false) }, check.PanicMatches, string(ErrShape))
+}
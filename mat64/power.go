@@ -0,0 +1,238 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math"
+
+// PowerFactors holds an estimate of an eigenpair of a square matrix found by
+// PowerIteration or InverseIteration.
+type PowerFactors struct {
+	// Value is the eigenvalue: that of largest magnitude for
+	// PowerIteration and that nearest the shift for InverseIteration.
+	Value float64
+
+	// Vector is the corresponding eigenvector with unit 2-norm.
+	Vector []float64
+
+	// Iterations is the number of iterations performed.
+	Iterations int
+
+	// Converged reports whether the residual criterion was met.
+	Converged bool
+}
+
+// PowerIteration estimates the dominant eigenpair of the square linear operator
+// a by the power method, which requires only products of a with a vector and so
+// is suited to large or implicit operators, and to problems such as PageRank
+// where a single eigenpair is wanted.
+// The iteration starts from the normalized vector of ones and stops when the
+// residual |a.v - λ.v| is at most tol times |λ|, or after maxIter iterations.
+// The eigenvalue estimate is the Rayleigh quotient v'.a.v.
+//
+// The method converges at a rate given by the ratio of the magnitudes of the
+// two largest eigenvalues, and does not converge if the dominant eigenvalue is
+// one of a complex pair or if eigenvalues of opposite sign share the largest
+// magnitude. PowerIteration will panic with ErrSquare if a is not square.
+func PowerIteration(a LinearOperator, tol float64, maxIter int) PowerFactors {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	v := make([]float64, n)
+	w := make([]float64, n)
+	if n == 0 {
+		return PowerFactors{Vector: v, Converged: true}
+	}
+	for i := range v {
+		v[i] = 1 / math.Sqrt(float64(n))
+	}
+
+	var f PowerFactors
+	for f.Iterations < maxIter {
+		f.Iterations++
+		a.Apply(w, v)
+		lambda := dotUnitary(v, w)
+		var res float64
+		for i, x := range w {
+			res = math.Hypot(res, x-lambda*v[i])
+		}
+		f.Value = lambda
+		if progress != nil {
+			progress("PowerIteration", f.Iterations, res)
+		}
+
+		norm := Vec(w).Norm(2)
+		if norm == 0 {
+			// v is in the null space of a.
+			f.Converged = true
+			break
+		}
+		if res <= tol*math.Abs(lambda) {
+			f.Converged = true
+			break
+		}
+		for i, x := range w {
+			v[i] = x / norm
+		}
+	}
+	f.Vector = v
+	return f
+}
+
+// InverseIteration estimates the eigenpair of the square matrix a whose
+// eigenvalue is nearest to the shift sigma, such as an approximate eigenvalue
+// obtained cheaply or from a previous computation, by shifted inverse
+// iteration. The LU factorization of a - sigma.I is computed once and reused to
+// solve for each iterate. The iteration starts from the normalized vector of
+// ones and stops when the residual |a.v - λ.v| is at most tol times the
+// infinity norm of a, or after maxIter iterations. The eigenvalue estimate is
+// the Rayleigh quotient v'.a.v.
+//
+// The method converges at a rate given by the ratio of the distances from sigma
+// to the nearest and next nearest eigenvalues, so a good shift gives
+// convergence in a few iterations. A shift equal to an eigenvalue is perturbed
+// to keep the factorization nonsingular. InverseIteration will panic with
+// ErrSquare if a is not square.
+func InverseIteration(a Matrix, sigma, tol float64, maxIter int) PowerFactors {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	v := make([]float64, n)
+	w := make([]float64, n)
+	if n == 0 {
+		return PowerFactors{Value: sigma, Vector: v, Converged: true}
+	}
+
+	var norm float64
+	shifted := DenseCopyOf(a)
+	for i := 0; i < n; i++ {
+		row := shifted.rowView(i)
+		var s float64
+		for _, x := range row {
+			s += math.Abs(x)
+		}
+		norm = math.Max(norm, s)
+		row[i] -= sigma
+	}
+	lu := LU(shifted)
+	for j := 0; j < n; j++ {
+		if lu.LU.At(j, j) == 0 {
+			lu.LU.Set(j, j, epsilon*math.Max(norm, 1))
+		}
+	}
+
+	for i := range v {
+		v[i] = 1 / math.Sqrt(float64(n))
+	}
+	x := NewDense(n, 1, nil)
+	var f PowerFactors
+	for f.Iterations < maxIter {
+		f.Iterations++
+		copy(x.mat.Data, v)
+		sol := lu.Solve(x)
+		vnorm := Vec(sol.mat.Data).Norm(2)
+		for i := range v {
+			v[i] = sol.mat.Data[i] / vnorm
+		}
+
+		matVec(w, a, v)
+		lambda := dotUnitary(v, w)
+		var res float64
+		for i, y := range w {
+			res = math.Hypot(res, y-lambda*v[i])
+		}
+		f.Value = lambda
+		if progress != nil {
+			progress("InverseIteration", f.Iterations, res)
+		}
+		if res <= tol*norm {
+			f.Converged = true
+			break
+		}
+	}
+	f.Vector = v
+	return f
+}
+
+// SpectralRadius estimates the spectral radius of the square linear operator a,
+// the largest magnitude of its eigenvalues, from the rate of growth of the norm
+// of repeated products of a with a vector. Unlike the eigenvalue estimate of
+// PowerIteration, the rate of growth converges to the spectral radius when the
+// dominant eigenvalues are a complex pair or have opposite signs. The iteration
+// stops when successive estimates differ relatively by at most tol, or after
+// maxIter iterations. SpectralRadius will panic with ErrSquare if a is not
+// square.
+func SpectralRadius(a LinearOperator, tol float64, maxIter int) float64 {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	if n == 0 {
+		return 0
+	}
+	v := make([]float64, n)
+	w := make([]float64, n)
+	for i := range v {
+		v[i] = 1 / math.Sqrt(float64(n))
+	}
+
+	// The estimate after k products is the geometric mean of the growth
+	// factors of the last k/2 products, discarding the early products
+	// that are dominated by the other eigenvalues.
+	logs := make([]float64, 0, maxIter)
+	var est float64
+	for k := 1; k <= maxIter; k++ {
+		a.Apply(w, v)
+		norm := Vec(w).Norm(2)
+		if norm == 0 {
+			return 0
+		}
+		logs = append(logs, math.Log(norm))
+		for i, x := range w {
+			v[i] = x / norm
+		}
+
+		var sum float64
+		tail := logs[len(logs)/2:]
+		for _, l := range tail {
+			sum += l
+		}
+		prev := est
+		est = math.Exp(sum / float64(len(tail)))
+		if progress != nil {
+			progress("SpectralRadius", k, math.Abs(est-prev))
+		}
+		if k > 2 && math.Abs(est-prev) <= tol*est {
+			break
+		}
+	}
+	return est
+}
+
+// matVec sets dst to the product of a with v.
+func matVec(dst []float64, a Matrix, v []float64) {
+	if a, ok := a.(RawMatrixer); ok {
+		amat := a.RawMatrix()
+		for i := range dst {
+			dst[i] = dotUnitary(amat.Data[i*amat.Stride:i*amat.Stride+amat.Cols], v)
+		}
+		return
+	}
+	if a, ok := a.(Vectorer); ok {
+		row := make([]float64, len(v))
+		for i := range dst {
+			dst[i] = dotUnitary(a.Row(row, i), v)
+		}
+		return
+	}
+	for i := range dst {
+		var s float64
+		for j, x := range v {
+			s += a.At(i, j) * x
+		}
+		dst[i] = s
+	}
+}
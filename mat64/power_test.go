@@ -0,0 +1,135 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestPowerIteration(c *check.C) {
+	for i, test := range []struct {
+		a    *Dense
+		want float64
+	}{
+		{
+			a: NewDense(3, 3, []float64{
+				4, 1, 1,
+				1, 2, 3,
+				1, 3, 6,
+			}),
+			want: 8.059320142656922,
+		},
+		{
+			// Dominant eigenvalue is negative.
+			a:    NewDense(2, 2, []float64{-5, 1, 1, 2}),
+			want: -5.140054944640259,
+		},
+		{
+			// Column stochastic link matrix: the dominant eigenvalue is one.
+			a: NewDense(3, 3, []float64{
+				0, 0.5, 1,
+				0.5, 0, 0,
+				0.5, 0.5, 0,
+			}),
+			want: 1,
+		},
+	} {
+		for _, a := range []Matrix{test.a, (*basicVectorer)(test.a), (*basicMatrix)(test.a)} {
+			f := PowerIteration(Operator(a), 1e-12, 1000)
+			comment := check.Commentf("test %d", i)
+			c.Check(f.Converged, check.Equals, true, comment)
+			c.Check(math.Abs(f.Value-test.want) < 1e-10, check.Equals, true, comment)
+			c.Check(math.Abs(Vec(f.Vector).Norm(2)-1) < 1e-14, check.Equals, true, comment)
+
+			n, _ := test.a.Dims()
+			av := make([]float64, n)
+			matVec(av, test.a, f.Vector)
+			for j, v := range av {
+				c.Check(math.Abs(v-f.Value*f.Vector[j]) < 1e-10, check.Equals, true, comment)
+			}
+		}
+	}
+
+	// A rotation has no dominant real eigenvalue.
+	rot := NewDense(2, 2, []float64{0, -1, 1, 0})
+	f := PowerIteration(Operator(rot), 1e-12, 50)
+	c.Check(f.Converged, check.Equals, false)
+	c.Check(f.Iterations, check.Equals, 50)
+
+	c.Check(func() { PowerIteration(Operator(NewDense(2, 3, nil)), 1e-12, 10) }, check.PanicMatches, string(ErrSquare))
+}
+
+func (s *S) TestSpectralRadius(c *check.C) {
+	for i, test := range []struct {
+		a    *Dense
+		want float64
+		tol  float64
+	}{
+		{
+			a: NewDense(3, 3, []float64{
+				4, 1, 1,
+				1, 2, 3,
+				1, 3, 6,
+			}),
+			want: 8.059320142656922,
+			tol:  1e-6,
+		},
+		{
+			// A scaled rotation with eigenvalues 2(cos t ± i sin t).
+			a:    NewDense(2, 2, []float64{2 * math.Cos(1), -2 * math.Sin(1), 2 * math.Sin(1), 2 * math.Cos(1)}),
+			want: 2,
+			tol:  1e-6,
+		},
+		{
+			// Eigenvalues 3 and -3.
+			a:    NewDense(2, 2, []float64{0, 9, 1, 0}),
+			want: 3,
+			tol:  1e-6,
+		},
+	} {
+		got := SpectralRadius(Operator(test.a), 1e-10, 5000)
+		c.Check(math.Abs(got-test.want) < test.tol*test.want, check.Equals, true, check.Commentf("test %d: got %v", i, got))
+	}
+	c.Check(SpectralRadius(Operator(NewDense(2, 2, nil)), 1e-10, 10), check.Equals, 0.)
+}
+
+func (s *S) TestInverseIteration(c *check.C) {
+	a := NewDense(3, 3, []float64{
+		4, 1, 1,
+		1, 2, 3,
+		1, 3, 6,
+	})
+	ef := Eigen(DenseCopyOf(a), epsilon)
+	for i, want := range ef.d {
+		for _, sigma := range []float64{want + 0.1, want - 0.05, want} {
+			f := InverseIteration(a, sigma, 1e-13, 100)
+			comment := check.Commentf("eigenvalue %d shift %v", i, sigma)
+			c.Check(f.Converged, check.Equals, true, comment)
+			c.Check(math.Abs(f.Value-want) < 1e-12, check.Equals, true, comment)
+			c.Check(f.Iterations <= 10, check.Equals, true, comment)
+
+			// The vector matches the eigenvector up to sign.
+			var d float64
+			for j, v := range f.Vector {
+				d += v * ef.V.At(j, i)
+			}
+			c.Check(math.Abs(math.Abs(d)-1) < 1e-12, check.Equals, true, comment)
+		}
+	}
+
+	// A non-symmetric matrix with eigenvalues 1, 2 and 3.
+	ns := NewDense(3, 3, []float64{
+		1, 5, 7,
+		0, 2, 11,
+		0, 0, 3,
+	})
+	f := InverseIteration((*basicMatrix)(ns), 2.2, 1e-13, 100)
+	c.Check(f.Converged, check.Equals, true)
+	c.Check(math.Abs(f.Value-2) < 1e-10, check.Equals, true)
+
+	c.Check(func() { InverseIteration(NewDense(2, 3, nil), 0, 1e-12, 10) }, check.PanicMatches, string(ErrSquare))
+}
@@ -0,0 +1,143 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"runtime"
+	"sync"
+)
+
+// OpStats holds the totals recorded for an operation while profiling is
+// enabled.
+type OpStats struct {
+	// Calls is the number of calls to the operation.
+	Calls int64
+
+	// Flops is the number of floating-point operations performed,
+	// estimated from the standard operation count of the algorithm for
+	// the dimensions of each call as given in Golub and Van Loan,
+	// "Matrix Computations". Iterative algorithms are counted for a
+	// typical number of iterations.
+	Flops int64
+
+	// Allocs and Bytes are the number of heap allocations and bytes
+	// allocated by the process during the calls. Allocations made by
+	// other goroutines running at the same time are included.
+	Allocs int64
+	Bytes  int64
+}
+
+var (
+	profiling bool
+
+	profileMu    sync.Mutex
+	profileStats = make(map[string]OpStats)
+)
+
+// SetProfiling enables or disables the recording of operation statistics and
+// returns the previous setting. Profiling is disabled by default. While it is
+// enabled, each call to one of the operations
+//
+//  Mul, MulAdd, Solve, Inverse, LU, QR, Cholesky, SVD and Eigen
+//
+// adds to the totals of that operation, which are returned by Profile. The
+// totals of an operation include those of the operations it calls, so a call to
+// Solve is also recorded under LU or QR. Measuring allocations stops the world
+// briefly at the start and end of each call, so profiling is intended for
+// capacity planning rather than production use.
+//
+// The totals may be published with the expvar package:
+//
+//  expvar.Publish("mat64", expvar.Func(func() interface{} { return mat64.Profile() }))
+//
+// SetProfiling must not be called concurrently with operations on matrices.
+func SetProfiling(on bool) bool {
+	prev := profiling
+	profiling = on
+	return prev
+}
+
+// Profile returns the totals recorded for each operation since profiling was
+// enabled or ResetProfile was last called, keyed by operation name.
+func Profile() map[string]OpStats {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	stats := make(map[string]OpStats, len(profileStats))
+	for op, s := range profileStats {
+		stats[op] = s
+	}
+	return stats
+}
+
+// ResetProfile discards the recorded totals.
+func ResetProfile() {
+	profileMu.Lock()
+	profileStats = make(map[string]OpStats)
+	profileMu.Unlock()
+}
+
+// profileSpan records the state of the allocator at the start of a profiled
+// call.
+type profileSpan struct {
+	mallocs, bytes uint64
+}
+
+// startProfile returns a span for a profiled call, or nil if profiling is
+// disabled. The caller records the call with
+//
+//  if p := startProfile(); p != nil {
+//  	defer p.stop(op, flops)
+//  }
+func startProfile() *profileSpan {
+	if !profiling {
+		return nil
+	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return &profileSpan{mallocs: ms.Mallocs, bytes: ms.TotalAlloc}
+}
+
+// stop adds a call to op performing flops floating-point operations to the
+// recorded totals.
+func (p *profileSpan) stop(op string, flops int) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	profileMu.Lock()
+	s := profileStats[op]
+	s.Calls++
+	s.Flops += int64(flops)
+	s.Allocs += int64(ms.Mallocs - p.mallocs)
+	s.Bytes += int64(ms.TotalAlloc - p.bytes)
+	profileStats[op] = s
+	profileMu.Unlock()
+}
+
+// factorFlops returns the operation count of the LU decomposition of an m-by-n
+// matrix, half that of its Householder QR decomposition.
+func factorFlops(m, n int) int {
+	k := min(m, n)
+	return max(m, n)*k*k - k*k*k/3
+}
+
+// solveFlops returns the operation count of Solve for an m-by-n matrix and k
+// right hand sides.
+func solveFlops(m, n, k int) int {
+	if m == n {
+		return factorFlops(n, n) + 2*n*n*k
+	}
+	return 2*factorFlops(m, n) + 4*m*n*k
+}
+
+// svdFlops returns the operation count of the Golub-Reinsch SVD of an m-by-n
+// matrix, with or without the singular vectors.
+func svdFlops(m, n int, vectors bool) int {
+	if m < n {
+		m, n = n, m
+	}
+	if vectors {
+		return 4*m*m*n + 8*m*n*n + 9*n*n*n
+	}
+	return 4*m*n*n - 4*n*n*n/3
+}
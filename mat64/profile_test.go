@@ -0,0 +1,76 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"context"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestProfile(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	a := NewNormal(10, 20, 0, 1, rnd)
+	b := NewNormal(20, 5, 0, 1, rnd)
+
+	// Nothing is recorded while profiling is disabled.
+	ResetProfile()
+	var m Dense
+	m.Mul(a, b)
+	c.Check(Profile(), check.HasLen, 0)
+
+	c.Check(SetProfiling(true), check.Equals, false)
+	defer SetProfiling(false)
+	defer ResetProfile()
+
+	m.Mul(a, b)
+	m.Mul(a, b)
+	stats := Profile()
+	c.Check(stats["Mul"].Calls, check.Equals, int64(2))
+	c.Check(stats["Mul"].Flops, check.Equals, int64(2*2*10*20*5))
+
+	// A fresh receiver allocates its storage.
+	var n Dense
+	n.Mul(a, b)
+	after := Profile()["Mul"]
+	c.Check(after.Allocs > 0, check.Equals, true)
+	c.Check(after.Bytes >= 8*10*5, check.Equals, true)
+
+	// Nested operations are recorded under their own names.
+	sq := NewNormal(6, 6, 0, 1, rnd)
+	Solve(sq, NewNormal(6, 2, 0, 1, rnd))
+	Eigen(DenseCopyOf(sq), epsilon)
+	stats = Profile()
+	c.Check(stats["Solve"].Calls, check.Equals, int64(1))
+	c.Check(stats["Solve"].Flops, check.Equals, int64(factorFlops(6, 6)+2*6*6*2))
+	c.Check(stats["LU"].Calls, check.Equals, int64(1))
+	c.Check(stats["Solve"].Flops > stats["LU"].Flops, check.Equals, true)
+	c.Check(stats["Eigen"].Flops, check.Equals, int64(25*6*6*6))
+
+	// Cancellable decompositions are recorded as the plain ones.
+	EigenContext(context.Background(), DenseCopyOf(sq), epsilon)
+	SVDContext(context.Background(), DenseCopyOf(sq), epsilon, small, false, false)
+	stats = Profile()
+	c.Check(stats["Eigen"].Calls, check.Equals, int64(2))
+	c.Check(stats["SVD"].Calls, check.Equals, int64(1))
+
+	// The returned totals are a copy.
+	stats["Mul"] = OpStats{}
+	c.Check(Profile()["Mul"].Calls, check.Equals, int64(3))
+
+	ResetProfile()
+	c.Check(Profile(), check.HasLen, 0)
+	c.Check(SetProfiling(false), check.Equals, true)
+	m.Mul(a, b)
+	c.Check(Profile(), check.HasLen, 0)
+}
+
+func (s *S) TestOpFlops(c *check.C) {
+	c.Check(factorFlops(3, 3), check.Equals, 27-9)
+	c.Check(factorFlops(10, 4), check.Equals, factorFlops(4, 10))
+	c.Check(svdFlops(4, 10, false), check.Equals, svdFlops(10, 4, false))
+	c.Check(svdFlops(10, 4, true) > svdFlops(10, 4, false), check.Equals, true)
+}
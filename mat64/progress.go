@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// A ProgressFunc receives the progress of an iterative algorithm. It is called
+// after each iteration with the name of the routine, the number of iterations
+// performed so far by the call and a measure of the distance from convergence,
+// such as a residual or an off-diagonal norm, that tends to zero as the
+// iteration converges. The routines reporting progress and their measures are:
+//
+//  tql2                norm of the remaining subdiagonal (symmetric Eigen)
+//  hqr2                norm of the active subdiagonal (non-symmetric Eigen, Schur)
+//  SVD                 norm of the remaining superdiagonal
+//  FastICA             deviation of the updated direction from the previous
+//  NIPALS              relative change in the scores of the current component
+//  PLS                 relative change in the scores of the current component
+//  NMF                 relative change in the norm of the residual
+//  JointDiagonalize    square root of the off-diagonal sum of squares
+//  EigenSymJacobi      norm of the off-diagonal part
+//  NearestCorrelation  relative change in the iterates
+//  PowerIteration      norm of the eigenpair residual
+//  InverseIteration    norm of the eigenpair residual
+//  SpectralRadius      change in the estimate
+type ProgressFunc func(op string, iter int, residual float64)
+
+var progress ProgressFunc
+
+// SetProgress sets fn as the function to receive the progress of the iterative
+// algorithms of the package, for progress reporting, logging or monitoring
+// convergence, and returns the previous setting. A nil fn disables reporting,
+// which is the default. fn is called by the goroutine performing the
+// computation, so it must be safe for concurrent use if computations are run
+// concurrently.
+//
+// SetProgress must not be called concurrently with operations on matrices.
+func SetProgress(fn ProgressFunc) ProgressFunc {
+	prev := progress
+	progress = fn
+	return prev
+}
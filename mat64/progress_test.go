@@ -0,0 +1,64 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+type progressRecord struct {
+	iter     int
+	residual float64
+}
+
+func (s *S) TestSetProgress(c *check.C) {
+	got := make(map[string][]progressRecord)
+	prev := SetProgress(func(op string, iter int, residual float64) {
+		got[op] = append(got[op], progressRecord{iter, residual})
+	})
+	defer SetProgress(prev)
+
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(8, 8, nil)
+	for i := range a.mat.Data {
+		a.mat.Data[i] = rnd.NormFloat64()
+	}
+	var at, sym Dense
+	at.TCopy(a)
+	sym.Add(a, &at)
+
+	Eigen(DenseCopyOf(&sym), epsilon)
+	Eigen(DenseCopyOf(a), epsilon)
+	SVD(DenseCopyOf(a), epsilon, small, true, true)
+	NIPALS(DenseCopyOf(a), 2, 1e-10, 100)
+	PLS(DenseCopyOf(a), NewDense(8, 2, a.mat.Data[:16]), 2)
+	JointDiagonalize([]*Dense{&sym}, 1e-12, 50)
+	for _, symmetric := range []bool{false, true} {
+		FastICA(a, 2, &ICAOptions{Symmetric: symmetric, Src: rand.New(rand.NewSource(1))})
+	}
+
+	for _, op := range []string{"tql2", "hqr2", "SVD", "NIPALS", "PLS", "JointDiagonalize", "FastICA"} {
+		recs := got[op]
+		c.Assert(len(recs) > 0, check.Equals, true, check.Commentf("%s not reported", op))
+		// Each call counts its iterations from one.
+		c.Check(recs[0].iter, check.Equals, 1, check.Commentf("%s", op))
+		for i, r := range recs {
+			if i > 0 && r.iter != 1 {
+				c.Check(r.iter, check.Equals, recs[i-1].iter+1, check.Commentf("%s", op))
+			}
+			c.Check(r.residual >= 0 && !math.IsInf(r.residual, 0), check.Equals, true, check.Commentf("%s", op))
+		}
+	}
+	last := got["JointDiagonalize"][len(got["JointDiagonalize"])-1]
+	c.Check(last.residual < 1e-10, check.Equals, true)
+
+	SetProgress(nil)
+	n := len(got["tql2"])
+	Eigen(DenseCopyOf(&sym), epsilon)
+	c.Check(len(got["tql2"]), check.Equals, n)
+}
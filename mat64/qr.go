@@ -24,6 +24,10 @@ type QRFactor struct {
 // This will fail if QRIsFullRank() returns false. The matrix a is overwritten by the
 // decomposition.
 func QR(a *Dense) QRFactor {
+	if p := startProfile(); p != nil {
+		m, n := a.Dims()
+		defer p.stop("QR", 2*factorFlops(m, n))
+	}
 	// Initialize.
 	m, n := a.Dims()
 	if m < n {
@@ -149,7 +153,7 @@ func (f QRFactor) Solve(b *Dense) (x *Dense) {
 		panic(ErrShape)
 	}
 	if !f.IsFullRank() {
-		panic("mat64: matrix is rank deficient")
+		panic(ErrRankDeficient)
 	}
 
 	// Compute Y = transpose(Q)*B
@@ -0,0 +1,239 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// QRUpdater holds the full QR factorization a = Q.R of an m-by-n matrix a, with
+// Q an m-by-m orthogonal matrix and R an m-by-n upper trapezoidal matrix, in a
+// form that is updated in O(m.(m+n)) operations when a row or column is added
+// to or removed from a, rather than refactorized in O(m.n²). This suits sliding
+// window regression, where the oldest observation is removed as each new one
+// arrives, and stepwise regression, where variables enter and leave the model.
+//
+// The updates apply Givens rotations as described in Golub and Van Loan,
+// "Matrix Computations", section 12.5.
+type QRUpdater struct {
+	q, r *Dense
+}
+
+// NewQRUpdater returns the full QR factorization of a ready for updating. The
+// matrix a is not modified.
+func NewQRUpdater(a Matrix) *QRUpdater {
+	m, n := a.Dims()
+	r := DenseCopyOf(a)
+	var q *Dense
+	if m <= n {
+		var lead Dense
+		lead.View(r, 0, 0, m, m)
+		q = householderQ(&lead)
+	} else {
+		q = householderQ(r)
+	}
+	var qt Dense
+	qt.TCopy(q)
+	r.Mul(&qt, a)
+
+	// Clear the rounding residue below the diagonal.
+	for i := 1; i < m && n > 0; i++ {
+		zero(r.rowView(i)[:min(i, n)])
+	}
+	return &QRUpdater{q: q, r: r}
+}
+
+// Dims returns the dimensions of the factored matrix.
+func (u *QRUpdater) Dims() (r, c int) { return u.r.Dims() }
+
+// Q returns a copy of the m-by-m orthogonal factor.
+func (u *QRUpdater) Q() *Dense { return DenseCopyOf(u.q) }
+
+// R returns a copy of the m-by-n upper trapezoidal factor.
+func (u *QRUpdater) R() *Dense { return DenseCopyOf(u.r) }
+
+// InsertRow updates the factorization for the insertion of row as row i of a,
+// so that the rows of a from i are moved down by one. InsertRow will panic with
+// ErrIndexOutOfRange if i is not in [0, m] and with ErrShape if len(row) is not
+// n.
+func (u *QRUpdater) InsertRow(i int, row []float64) {
+	m, n := u.Dims()
+	if i < 0 || i > m {
+		panic(ErrIndexOutOfRange)
+	}
+	if len(row) != n {
+		panic(ErrShape)
+	}
+
+	// Border Q with the new row and R with row, so that the new row of a
+	// is the last row of R, and then rotate away its leading elements.
+	// When m < n the remaining elements form the new row of the trapezoid.
+	q := NewDense(m+1, m+1, nil)
+	for k := 0; k < m; k++ {
+		dst := k
+		if k >= i {
+			dst++
+		}
+		copy(q.rowView(dst)[:m], u.q.rowView(k))
+	}
+	q.Set(i, m, 1)
+	r := NewDense(m+1, n, nil)
+	for k := 0; k < m; k++ {
+		copy(r.rowView(k), u.r.rowView(k))
+	}
+	copy(r.rowView(m), row)
+
+	for k := 0; k < min(m, n); k++ {
+		c, s, _ := Givens(r.At(k, k), r.At(m, k))
+		if s == 0 {
+			continue
+		}
+		r.RotateRows(k, m, c, s)
+		q.RotateCols(k, m, c, s)
+		r.Set(m, k, 0)
+	}
+
+	u.q, u.r = q, r
+}
+
+// DeleteRow updates the factorization for the removal of row i of a. DeleteRow
+// will panic with ErrIndexOutOfRange if i is not in [0, m).
+func (u *QRUpdater) DeleteRow(i int) {
+	m, n := u.Dims()
+	if i < 0 || i >= m {
+		panic(ErrIndexOutOfRange)
+	}
+
+	// Rotate row i of Q to a multiple of e_0, leaving R upper Hessenberg
+	// with its first row corresponding to the deleted row of a.
+	q, r := u.q, u.r
+	qi := q.rowView(i)
+	for k := m - 1; k > 0; k-- {
+		c, s, _ := Givens(qi[k-1], qi[k])
+		if s == 0 {
+			continue
+		}
+		r.RotateRows(k-1, k, c, s)
+		q.RotateCols(k-1, k, c, s)
+		qi[k] = 0
+	}
+
+	nq := NewDense(m-1, m-1, nil)
+	dst := 0
+	for k := 0; k < m; k++ {
+		if k == i {
+			continue
+		}
+		copy(nq.rowView(dst), q.rowView(k)[1:])
+		dst++
+	}
+	nr := NewDense(m-1, n, nil)
+	for k := 1; k < m; k++ {
+		row := nr.rowView(k - 1)
+		copy(row, r.rowView(k))
+		if l := min(k-1, n); l > 0 {
+			zero(row[:l])
+		}
+	}
+	u.q, u.r = nq, nr
+}
+
+// InsertCol updates the factorization for the insertion of col as column j of
+// a, so that the columns of a from j are moved right by one. InsertCol will
+// panic with ErrIndexOutOfRange if j is not in [0, n] and with ErrShape if
+// len(col) is not m.
+func (u *QRUpdater) InsertCol(j int, col []float64) {
+	m, n := u.Dims()
+	if j < 0 || j > n {
+		panic(ErrIndexOutOfRange)
+	}
+	if len(col) != m {
+		panic(ErrShape)
+	}
+
+	r := NewDense(m, n+1, nil)
+	for k := 0; k < m; k++ {
+		src, dst := u.r.rowView(k), r.rowView(k)
+		copy(dst[:j], src[:j])
+		copy(dst[j+1:], src[j:])
+	}
+	w := make([]float64, m)
+	mulTransVec(w, u.q, col)
+	for k, v := range w {
+		r.Set(k, j, v)
+	}
+
+	// Zero the new column below the diagonal from the bottom up. Each
+	// rotation only fills positions on or above the new diagonal.
+	q := u.q
+	for k := m - 1; k > j; k-- {
+		c, s, _ := Givens(r.At(k-1, j), r.At(k, j))
+		if s == 0 {
+			continue
+		}
+		r.RotateRows(k-1, k, c, s)
+		q.RotateCols(k-1, k, c, s)
+		r.Set(k, j, 0)
+	}
+	u.r = r
+}
+
+// DeleteCol updates the factorization for the removal of column j of a.
+// DeleteCol will panic with ErrIndexOutOfRange if j is not in [0, n).
+func (u *QRUpdater) DeleteCol(j int) {
+	m, n := u.Dims()
+	if j < 0 || j >= n {
+		panic(ErrIndexOutOfRange)
+	}
+
+	r := NewDense(m, n-1, nil)
+	for k := 0; k < m; k++ {
+		src, dst := u.r.rowView(k), r.rowView(k)
+		copy(dst[:j], src[:j])
+		copy(dst[j:], src[j+1:])
+	}
+
+	// The columns from j are upper Hessenberg; restore the triangle.
+	q := u.q
+	for k := j; k < min(m-1, n-1); k++ {
+		c, s, _ := Givens(r.At(k, k), r.At(k+1, k))
+		if s == 0 {
+			continue
+		}
+		r.RotateRows(k, k+1, c, s)
+		q.RotateCols(k, k+1, c, s)
+		r.Set(k+1, k, 0)
+	}
+	u.r = r
+}
+
+// Solve returns the least squares solution x minimizing the two norm of a.x - b,
+// where b has as many rows as a. The matrix b is not modified. Solve will panic
+// with ErrShape if a has fewer rows than columns or b does not have m rows, and
+// with ErrRankDeficient if a does not have full column rank.
+func (u *QRUpdater) Solve(b Matrix) *Dense {
+	m, n := u.Dims()
+	bm, bn := b.Dims()
+	if m < n || bm != m {
+		panic(ErrShape)
+	}
+	for k := 0; k < n; k++ {
+		if u.r.At(k, k) == 0 {
+			panic(ErrRankDeficient)
+		}
+	}
+
+	var qt, y Dense
+	qt.TCopy(u.q)
+	y.Mul(&qt, b)
+
+	x := NewDense(n, bn, nil)
+	x.Copy(&y)
+	for k := n - 1; k >= 0; k-- {
+		row := x.rowView(k)
+		rk := u.r.rowView(k)
+		for i := k + 1; i < n; i++ {
+			axpyUnitary(-rk[i], x.rowView(i), row)
+		}
+		scalUnitary(1/rk[k], row)
+	}
+	return x
+}
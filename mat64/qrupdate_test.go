@@ -0,0 +1,147 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math/rand"
+)
+
+// checkQRUpdater checks that u holds a valid full QR factorization of a.
+func checkQRUpdater(c *check.C, u *QRUpdater, a *Dense, comment check.CommentInterface) {
+	m, n := a.Dims()
+	um, un := u.Dims()
+	c.Check([]int{um, un}, check.DeepEquals, []int{m, n}, comment)
+
+	q, r := u.Q(), u.R()
+	c.Check(isOrthonormal(q, 1e-12), check.Equals, true, comment)
+	for i := 0; i < m; i++ {
+		for j := 0; j < min(i, n); j++ {
+			c.Check(r.At(i, j), check.Equals, 0., comment)
+		}
+	}
+	var qr Dense
+	qr.Mul(q, r)
+	c.Check(qr.EqualsApprox(a, 1e-12), check.Equals, true, comment)
+}
+
+func (s *S) TestQRUpdater(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	randSlice := func(n int) []float64 {
+		v := make([]float64, n)
+		for i := range v {
+			v[i] = rnd.NormFloat64()
+		}
+		return v
+	}
+
+	for _, dims := range [][2]int{{6, 3}, {3, 3}, {2, 4}} {
+		m, n := dims[0], dims[1]
+		a := NewDense(m, n, randSlice(m*n))
+		u := NewQRUpdater(a)
+		checkQRUpdater(c, u, a, check.Commentf("new %dx%d", m, n))
+
+		for step := 0; step < 12; step++ {
+			am, an := a.Dims()
+			var op string
+			switch rnd.Intn(4) {
+			case 0:
+				i := rnd.Intn(am + 1)
+				row := randSlice(an)
+				u.InsertRow(i, row)
+				a = insertRow(a, i, row)
+				op = "insert row"
+			case 1:
+				if am == 1 {
+					continue
+				}
+				i := rnd.Intn(am)
+				u.DeleteRow(i)
+				a = deleteRow(a, i)
+				op = "delete row"
+			case 2:
+				j := rnd.Intn(an + 1)
+				col := randSlice(am)
+				u.InsertCol(j, col)
+				a = transpose(insertRow(transpose(a), j, col))
+				op = "insert col"
+			case 3:
+				if an == 1 {
+					continue
+				}
+				j := rnd.Intn(an)
+				u.DeleteCol(j)
+				a = transpose(deleteRow(transpose(a), j))
+				op = "delete col"
+			}
+			checkQRUpdater(c, u, a, check.Commentf("%dx%d step %d: %s", m, n, step, op))
+		}
+	}
+}
+
+func (s *S) TestQRUpdaterSolve(c *check.C) {
+	// Sliding window least squares agrees with a fresh factorization.
+	rnd := rand.New(rand.NewSource(1))
+	const window = 10
+	x := NewDense(window, 2, nil)
+	y := NewDense(window, 1, nil)
+	for i := 0; i < window; i++ {
+		x.Set(i, 0, 1)
+		x.Set(i, 1, float64(i))
+		y.Set(i, 0, 2+3*float64(i)+rnd.NormFloat64())
+	}
+	u := NewQRUpdater(x)
+	for i := window; i < window+5; i++ {
+		u.DeleteRow(0)
+		u.InsertRow(window-1, []float64{1, float64(i)})
+		x = insertRow(deleteRow(x, 0), window-1, []float64{1, float64(i)})
+		y = insertRow(deleteRow(y, 0), window-1, []float64{2 + 3*float64(i) + rnd.NormFloat64()})
+
+		got := u.Solve(y)
+		want := QR(DenseCopyOf(x)).Solve(DenseCopyOf(y))
+		c.Check(got.EqualsApprox(want, 1e-10), check.Equals, true)
+	}
+
+	c.Check(func() { NewQRUpdater(NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})).Solve(NewDense(2, 1, nil)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { NewQRUpdater(NewDense(3, 2, []float64{1, 0, 1, 0, 1, 0})).Solve(NewDense(3, 1, nil)) }, check.PanicMatches, string(ErrRankDeficient))
+	c.Check(func() { u.DeleteRow(window) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { u.InsertCol(0, []float64{1}) }, check.PanicMatches, string(ErrShape))
+}
+
+// insertRow returns a with row inserted as row i.
+func insertRow(a *Dense, i int, row []float64) *Dense {
+	m, n := a.Dims()
+	b := NewDense(m+1, n, nil)
+	for k := 0; k < m; k++ {
+		dst := k
+		if k >= i {
+			dst++
+		}
+		copy(b.rowView(dst), a.rowView(k))
+	}
+	copy(b.rowView(i), row)
+	return b
+}
+
+// deleteRow returns a with row i removed.
+func deleteRow(a *Dense, i int) *Dense {
+	m, n := a.Dims()
+	b := NewDense(m-1, n, nil)
+	dst := 0
+	for k := 0; k < m; k++ {
+		if k != i {
+			copy(b.rowView(dst), a.rowView(k))
+			dst++
+		}
+	}
+	return b
+}
+
+// transpose returns a newly allocated transpose of a.
+func transpose(a *Dense) *Dense {
+	var t Dense
+	t.TCopy(a)
+	return &t
+}
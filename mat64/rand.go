@@ -0,0 +1,68 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "math/rand"
+
+// A Source provides the random values used by the randomized routines in the
+// package. A *rand.Rand from the math/rand package satisfies Source, so a seeded
+// generator can be used to obtain reproducible results. Other generators, for
+// example one backed by crypto/rand, can be used by implementing the interface.
+//
+// Functions accepting a Source use the top-level math/rand functions when the
+// Source is nil.
+type Source interface {
+	// Float64 returns a pseudo-random number in [0.0,1.0).
+	Float64() float64
+
+	// NormFloat64 returns a normally distributed pseudo-random number
+	// with mean 0 and standard deviation 1.
+	NormFloat64() float64
+
+	// Intn returns a pseudo-random number in [0,n). It panics if n <= 0.
+	Intn(n int) int
+}
+
+var _ Source = (*rand.Rand)(nil)
+
+// globalSource is the Source used when a nil Source is provided.
+type globalSource struct{}
+
+func (globalSource) Float64() float64     { return rand.Float64() }
+func (globalSource) NormFloat64() float64 { return rand.NormFloat64() }
+func (globalSource) Intn(n int) int       { return rand.Intn(n) }
+
+// source returns src, or a Source using the top-level math/rand functions
+// if src is nil.
+func source(src Source) Source {
+	if src == nil {
+		return globalSource{}
+	}
+	return src
+}
+
+// NewUniform returns an r-by-c matrix with elements drawn independently from
+// the uniform distribution on [lo, hi) using src.
+func NewUniform(r, c int, lo, hi float64, src Source) *Dense {
+	src = source(src)
+	return NewRandom(r, c, func() float64 { return lo + (hi-lo)*src.Float64() })
+}
+
+// NewNormal returns an r-by-c matrix with elements drawn independently from
+// the normal distribution with the given mean and standard deviation using src.
+func NewNormal(r, c int, mean, std float64, src Source) *Dense {
+	src = source(src)
+	return NewRandom(r, c, func() float64 { return mean + std*src.NormFloat64() })
+}
+
+// NewRandom returns an r-by-c matrix with elements set by successive calls to
+// rnd in row-major order, allowing an arbitrary distribution to be used.
+func NewRandom(r, c int, rnd func() float64) *Dense {
+	m := NewDense(r, c, nil)
+	for i := range m.mat.Data {
+		m.mat.Data[i] = rnd()
+	}
+	return m
+}
@@ -0,0 +1,65 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSource(c *check.C) {
+	src := source(nil)
+	for i := 0; i < 100; i++ {
+		f := src.Float64()
+		c.Check(f >= 0 && f < 1, check.Equals, true)
+		n := src.Intn(5)
+		c.Check(n >= 0 && n < 5, check.Equals, true)
+	}
+
+	a := source(rand.New(rand.NewSource(1)))
+	b := source(rand.New(rand.NewSource(1)))
+	for i := 0; i < 10; i++ {
+		c.Check(a.NormFloat64(), check.Equals, b.NormFloat64())
+	}
+}
+
+func (s *S) TestNewRandom(c *check.C) {
+	const r, cols = 200, 50
+	u := NewUniform(r, cols, -2, 3, rand.New(rand.NewSource(1)))
+	var mean float64
+	for _, v := range u.mat.Data {
+		c.Check(v >= -2 && v < 3, check.Equals, true)
+		mean += v
+	}
+	mean /= r * cols
+	c.Check(math.Abs(mean-0.5) < 0.05, check.Equals, true, check.Commentf("mean %v", mean))
+
+	n := NewNormal(r, cols, 4, 2, rand.New(rand.NewSource(1)))
+	mean = 0
+	for _, v := range n.mat.Data {
+		mean += v
+	}
+	mean /= r * cols
+	var variance float64
+	for _, v := range n.mat.Data {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= r*cols - 1
+	c.Check(math.Abs(mean-4) < 0.05, check.Equals, true, check.Commentf("mean %v", mean))
+	c.Check(math.Abs(variance-4) < 0.2, check.Equals, true, check.Commentf("variance %v", variance))
+
+	// The same seed gives the same matrix.
+	c.Check(NewNormal(3, 4, 0, 1, rand.New(rand.NewSource(5))).Equals(
+		NewNormal(3, 4, 0, 1, rand.New(rand.NewSource(5)))), check.Equals, true)
+
+	var k float64
+	m := NewRandom(2, 3, func() float64 { k++; return k })
+	c.Check(m.Equals(NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})), check.Equals, true)
+
+	r0, c0 := NewUniform(0, 3, 0, 1, nil).Dims()
+	c.Check(r0 == 0 && c0 == 3, check.Equals, true)
+}
@@ -0,0 +1,135 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// ErrDistribution is the panic value used by the random matrix generators when
+// a parameter of the distribution is out of range.
+const ErrDistribution = Error("mat64: invalid distribution parameter")
+
+// NewSPD returns a random symmetric positive definite matrix Q.diag(eigenvalues).Q'
+// with Q a random orthogonal matrix drawn using src, so that the eigenvalues of
+// the returned matrix are those given and its eigenvectors are uniformly
+// distributed. NewSPD will panic with ErrNotPositiveDefinite if an eigenvalue is
+// not positive.
+func NewSPD(eigenvalues []float64, src Source) *Dense {
+	for _, v := range eigenvalues {
+		if !(v > 0) {
+			panic(ErrNotPositiveDefinite)
+		}
+	}
+	n := len(eigenvalues)
+	q := NewOrthogonal(n, n, src)
+	qd := NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		row, qr := qd.rowView(i), q.rowView(i)
+		for j, v := range eigenvalues {
+			row[j] = qr[j] * v
+		}
+	}
+	var qt Dense
+	qt.TCopy(q)
+	a := NewDense(n, n, nil)
+	a.Mul(qd, &qt)
+	Symmetrize(a)
+	return a
+}
+
+// NewSPDCond returns a random n-by-n symmetric positive definite matrix with two
+// norm condition number cond, with eigenvalues spaced geometrically from 1 down
+// to 1/cond, as described for NewSPD. NewSPDCond will panic with
+// ErrDistribution if cond is less than one.
+func NewSPDCond(n int, cond float64, src Source) *Dense {
+	if !(cond >= 1) {
+		panic(ErrDistribution)
+	}
+	eigenvalues := make([]float64, n)
+	for i := range eigenvalues {
+		eigenvalues[i] = 1
+		if n > 1 {
+			eigenvalues[i] = math.Pow(cond, -float64(i)/float64(n-1))
+		}
+	}
+	return NewSPD(eigenvalues, src)
+}
+
+// NewCorrelation returns a random n-by-n correlation matrix drawn from the
+// distribution of Lewandowski, Kurowicka and Joe with density proportional to
+// det(R)^(eta-1), using their onion method, "Generating random correlation
+// matrices based on vines and extended onion method", J. Multivariate Anal.
+// 100:1989-2001, 2009. With eta equal to one the matrix is uniformly
+// distributed over the correlation matrices; larger eta concentrates the
+// distribution towards the identity and smaller eta away from it. NewCorrelation
+// will panic with ErrDistribution if eta is not positive.
+func NewCorrelation(n int, eta float64, src Source) *Dense {
+	if !(eta > 0) {
+		panic(ErrDistribution)
+	}
+	src = source(src)
+
+	// Build the Cholesky factor L of the matrix a row at a time. Each new
+	// row is [w' sqrt(1-|w|²)] with w = sqrt(y).u, u uniform on the unit
+	// sphere and y ~ Beta(k/2, beta), which extends the correlation
+	// matrix L.L' by one row and column.
+	l := NewDense(n, n, nil)
+	if n > 0 {
+		l.Set(0, 0, 1)
+	}
+	for k := 1; k < n; k++ {
+		beta := eta + float64(n-1-k)/2
+		y := betaRand(float64(k)/2, beta, src)
+		w := l.rowView(k)[:k]
+		var norm float64
+		for i := range w {
+			w[i] = src.NormFloat64()
+			norm = math.Hypot(norm, w[i])
+		}
+		scalUnitary(math.Sqrt(y)/norm, w)
+		l.Set(k, k, math.Sqrt(1-y))
+	}
+
+	var lt Dense
+	lt.TCopy(l)
+	a := NewDense(n, n, nil)
+	a.Mul(l, &lt)
+	Symmetrize(a)
+	for i := 0; i < n; i++ {
+		a.Set(i, i, 1)
+	}
+	return a
+}
+
+// betaRand returns a Beta(a, b) distributed random number using src.
+func betaRand(a, b float64, src Source) float64 {
+	x := gammaRand(a, src)
+	y := gammaRand(b, src)
+	return x / (x + y)
+}
+
+// gammaRand returns a Gamma(a, 1) distributed random number using src, by the
+// method of Marsaglia and Tsang, "A simple method for generating gamma
+// variables", ACM Trans. Math. Softw. 26:363-372, 2000.
+func gammaRand(a float64, src Source) float64 {
+	if a < 1 {
+		return gammaRand(a+1, src) * math.Pow(src.Float64(), 1/a)
+	}
+	d := a - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := src.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := src.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < x*x/2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	check "launchpad.net/gocheck"
+)
+
+// symEigenvalues returns the eigenvalues of the symmetric matrix a in
+// ascending order.
+func symEigenvalues(a *Dense) []float64 {
+	var vals []float64
+	for _, v := range Eigen(DenseCopyOf(a), epsilon).Values() {
+		vals = append(vals, real(v))
+	}
+	sort.Float64s(vals)
+	return vals
+}
+
+func (s *S) TestNewSPD(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	for _, eigs := range [][]float64{
+		{1},
+		{3, 1},
+		{1, 2, 3, 4, 5},
+		{1e-3, 10, 0.5, 7, 7, 2},
+	} {
+		a := NewSPD(eigs, src)
+		c.Check(symmetric(a), check.Equals, true)
+		want := append([]float64(nil), eigs...)
+		sort.Float64s(want)
+		got := symEigenvalues(a)
+		for i := range want {
+			c.Check(math.Abs(got[i]-want[i]) < 1e-12*want[len(want)-1], check.Equals, true,
+				check.Commentf("got %v want %v", got, want))
+		}
+	}
+	r, cols := NewSPD(nil, src).Dims()
+	c.Check(r == 0 && cols == 0, check.Equals, true)
+	c.Check(func() { NewSPD([]float64{1, 0}, src) }, check.PanicMatches, string(ErrNotPositiveDefinite))
+
+	for _, cond := range []float64{1, 10, 1e6} {
+		a := NewSPDCond(6, cond, src)
+		c.Check(symmetric(a), check.Equals, true)
+		got := symEigenvalues(a)
+		c.Check(math.Abs(got[5]-1) < 1e-12, check.Equals, true)
+		c.Check(math.Abs(got[5]/got[0]-cond) < 1e-8*cond, check.Equals, true,
+			check.Commentf("cond %v got %v", cond, got[5]/got[0]))
+	}
+	c.Check(func() { NewSPDCond(3, 0.5, src) }, check.PanicMatches, string(ErrDistribution))
+}
+
+func (s *S) TestNewCorrelation(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 5, 10} {
+		for _, eta := range []float64{0.5, 1, 10} {
+			a := NewCorrelation(n, eta, src)
+			c.Check(symmetric(a), check.Equals, true)
+			for i := 0; i < n; i++ {
+				c.Check(a.At(i, i), check.Equals, 1.0)
+				for j := 0; j < i; j++ {
+					c.Check(math.Abs(a.At(i, j)) < 1, check.Equals, true)
+				}
+			}
+			if n > 0 {
+				c.Check(Cholesky(DenseCopyOf(a)).SPD, check.Equals, true)
+			}
+		}
+	}
+	c.Check(func() { NewCorrelation(3, 0, src) }, check.PanicMatches, string(ErrDistribution))
+
+	// Each off-diagonal element has the marginal distribution 2B-1 with
+	// B ~ Beta(alpha, alpha) and alpha = eta-1+n/2, so mean zero and
+	// variance 1/(2*alpha+1).
+	const samples = 4000
+	for _, test := range []struct {
+		n   int
+		eta float64
+	}{
+		{n: 3, eta: 1},
+		{n: 4, eta: 2},
+	} {
+		alpha := test.eta - 1 + float64(test.n)/2
+		want := 1 / (2*alpha + 1)
+		for i := 0; i < test.n; i++ {
+			for j := 0; j < i; j++ {
+				var mean, sq float64
+				src := rand.New(rand.NewSource(2))
+				for k := 0; k < samples; k++ {
+					v := NewCorrelation(test.n, test.eta, src).At(i, j)
+					mean += v
+					sq += v * v
+				}
+				mean /= samples
+				sq /= samples
+				c.Check(math.Abs(mean) < 0.03, check.Equals, true, check.Commentf("mean %v", mean))
+				c.Check(math.Abs(sq-want) < 0.02, check.Equals, true,
+					check.Commentf("n=%d (%d,%d) variance %v want %v", test.n, i, j, sq, want))
+			}
+		}
+	}
+}
+
+func (s *S) TestGammaRand(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	const samples = 20000
+	for _, a := range []float64{0.3, 1, 2.5, 10} {
+		var mean, sq float64
+		for i := 0; i < samples; i++ {
+			v := gammaRand(a, src)
+			mean += v
+			sq += v * v
+		}
+		mean /= samples
+		variance := sq/samples - mean*mean
+		c.Check(math.Abs(mean-a) < 0.05*a+0.01, check.Equals, true, check.Commentf("a=%v mean %v", a, mean))
+		c.Check(math.Abs(variance-a) < 0.1*a, check.Equals, true, check.Commentf("a=%v variance %v", a, variance))
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// Givens returns the cosine c and sine s of the plane rotation G = [c s; -s c]
+// such that G.[a; b] = [r; 0], with r = ±sqrt(a²+b²) taking the sign of a. If b
+// is zero, the identity rotation is returned with r = a.
+//
+// The rotation is applied to a matrix with RotateRows and RotateCols.
+func Givens(a, b float64) (c, s, r float64) {
+	if b == 0 {
+		return 1, 0, a
+	}
+	r = math.Hypot(a, b)
+	if a < 0 {
+		r = -r
+	}
+	return a / r, b / r, r
+}
+
+// RotateRows applies the plane rotation [c s; -s c] to rows p and q of the
+// receiver from the left, replacing row p with c.row_p + s.row_q and row q with
+// c.row_q - s.row_p. RotateRows will panic with ErrIndexOutOfRange if p or q is
+// out of bounds.
+func (m *Dense) RotateRows(p, q int, c, s float64) {
+	if p < 0 || p >= m.mat.Rows || q < 0 || q >= m.mat.Rows {
+		panic(ErrIndexOutOfRange)
+	}
+	rp, rq := m.rowView(p), m.rowView(q)
+	for j, x := range rp {
+		y := rq[j]
+		rp[j] = c*x + s*y
+		rq[j] = c*y - s*x
+	}
+}
+
+// RotateCols applies the transpose of the plane rotation [c s; -s c] to
+// columns p and q of the receiver from the right, replacing column p with
+// c.col_p + s.col_q and column q with c.col_q - s.col_p. Applying the same
+// rotation with RotateRows and RotateCols forms the similarity transform
+// G.m.G'. RotateCols will panic with ErrIndexOutOfRange if p or q is out of
+// bounds.
+func (m *Dense) RotateCols(p, q int, c, s float64) {
+	if p < 0 || p >= m.mat.Cols || q < 0 || q >= m.mat.Cols {
+		panic(ErrIndexOutOfRange)
+	}
+	for i := 0; i < m.mat.Rows; i++ {
+		row := m.rowView(i)
+		x, y := row[p], row[q]
+		row[p] = c*x + s*y
+		row[q] = c*y - s*x
+	}
+}
+
+// Householder returns the elementary reflector H = I - tau.v.v' that maps x to
+// beta.e_0, where v[0] is 1 and |beta| is the two norm of x. The sign of beta is
+// opposite to that of x[0] to avoid cancellation. If x is already a multiple of
+// e_0, tau is zero and H is the identity. The vector x is not modified.
+//
+// The reflector is applied to a matrix with ReflectRows and ReflectCols. This is
+// the convention of LAPACK's dlarfg.
+func Householder(x []float64) (v []float64, tau, beta float64) {
+	v = make([]float64, len(x))
+	if len(x) == 0 {
+		return v, 0, 0
+	}
+	v[0] = 1
+	alpha := x[0]
+	var xnorm float64
+	for _, e := range x[1:] {
+		xnorm = math.Hypot(xnorm, e)
+	}
+	if xnorm == 0 {
+		return v, 0, alpha
+	}
+
+	beta = math.Hypot(alpha, xnorm)
+	if alpha > 0 {
+		beta = -beta
+	}
+	tau = (beta - alpha) / beta
+	scale := 1 / (alpha - beta)
+	for i, e := range x[1:] {
+		v[i+1] = e * scale
+	}
+	return v, tau, beta
+}
+
+// ReflectRows applies the reflector I - tau.v.v' from the left to the len(v)
+// rows of the receiver starting at row i. ReflectRows will panic with
+// ErrIndexOutOfRange if the rows are out of bounds.
+func (m *Dense) ReflectRows(v []float64, tau float64, i int) {
+	if i < 0 || i+len(v) > m.mat.Rows {
+		panic(ErrIndexOutOfRange)
+	}
+	if tau == 0 {
+		return
+	}
+	w := make([]float64, m.mat.Cols)
+	for k, vk := range v {
+		if vk != 0 {
+			axpyUnitary(vk, m.rowView(i+k), w)
+		}
+	}
+	for k, vk := range v {
+		if vk != 0 {
+			axpyUnitary(-tau*vk, w, m.rowView(i+k))
+		}
+	}
+}
+
+// ReflectCols applies the reflector I - tau.v.v' from the right to the len(v)
+// columns of the receiver starting at column j. ReflectCols will panic with
+// ErrIndexOutOfRange if the columns are out of bounds.
+func (m *Dense) ReflectCols(v []float64, tau float64, j int) {
+	if j < 0 || j+len(v) > m.mat.Cols {
+		panic(ErrIndexOutOfRange)
+	}
+	if tau == 0 {
+		return
+	}
+	for r := 0; r < m.mat.Rows; r++ {
+		row := m.rowView(r)[j : j+len(v)]
+		axpyUnitary(-tau*dotUnitary(row, v), v, row)
+	}
+}
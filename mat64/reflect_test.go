@@ -0,0 +1,98 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math"
+)
+
+func (s *S) TestGivens(c *check.C) {
+	for _, t := range [][2]float64{{3, 4}, {-3, 4}, {0, 2}, {5, 0}, {1e-300, 1e-300}, {1e300, -1e300}} {
+		a, b := t[0], t[1]
+		cs, sn, r := Givens(a, b)
+		c.Check(math.Abs(cs*cs+sn*sn-1) < 1e-15, check.Equals, true)
+		c.Check(math.Abs(cs*a+sn*b-r) <= 1e-15*math.Abs(r), check.Equals, true, check.Commentf("%v", t))
+		c.Check(math.Abs(cs*b-sn*a) <= 1e-15*math.Abs(r), check.Equals, true, check.Commentf("%v", t))
+	}
+
+	m := NewDense(2, 3, []float64{
+		3, 1, 2,
+		4, 5, 6,
+	})
+	cs, sn, r := Givens(3, 4)
+	m.RotateRows(0, 1, cs, sn)
+	c.Check(m.At(0, 0), check.Equals, r)
+	c.Check(math.Abs(m.At(1, 0)) < 1e-15, check.Equals, true)
+
+	// A rotation applied from both sides is a similarity transform.
+	a := NewDense(3, 3, []float64{
+		2, 1, 0,
+		1, 3, 1,
+		0, 1, 4,
+	})
+	want := a.Trace()
+	cs, sn, _ = Givens(1, 2)
+	a.RotateRows(0, 2, cs, sn)
+	a.RotateCols(0, 2, cs, sn)
+	c.Check(math.Abs(a.Trace()-want) < 1e-14, check.Equals, true)
+	c.Check(math.Abs(a.At(0, 2)-a.At(2, 0)) < 1e-14, check.Equals, true)
+
+	c.Check(func() { a.RotateRows(0, 3, cs, sn) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { a.RotateCols(-1, 0, cs, sn) }, check.PanicMatches, string(ErrIndexOutOfRange))
+}
+
+func (s *S) TestHouseholder(c *check.C) {
+	for _, x := range [][]float64{
+		{3, 4},
+		{-1, 2, 2},
+		{0, 1, 0, 0},
+		{5, 0, 0},
+		{2},
+	} {
+		v, tau, beta := Householder(x)
+		c.Check(v[0], check.Equals, 1.)
+		var norm float64
+		for _, e := range x {
+			norm = math.Hypot(norm, e)
+		}
+		c.Check(math.Abs(math.Abs(beta)-norm) < 1e-14, check.Equals, true, check.Commentf("%v", x))
+
+		// H.x = beta.e_0, applied to x as a column.
+		m := NewDense(len(x), 1, append([]float64(nil), x...))
+		m.ReflectRows(v, tau, 0)
+		c.Check(math.Abs(m.At(0, 0)-beta) < 1e-14, check.Equals, true, check.Commentf("%v", x))
+		for i := 1; i < len(x); i++ {
+			c.Check(math.Abs(m.At(i, 0)) < 1e-14, check.Equals, true, check.Commentf("%v", x))
+		}
+
+		// x'.H' = beta.e_0', applied to x as a row.
+		m = NewDense(1, len(x), append([]float64(nil), x...))
+		m.ReflectCols(v, tau, 0)
+		c.Check(math.Abs(m.At(0, 0)-beta) < 1e-14, check.Equals, true, check.Commentf("%v", x))
+
+		// H is orthogonal and symmetric.
+		h := identityDense(len(x))
+		h.ReflectRows(v, tau, 0)
+		c.Check(isOrthonormal(h, 1e-14), check.Equals, true)
+		var ht Dense
+		ht.TCopy(h)
+		c.Check(h.EqualsApprox(&ht, 1e-15), check.Equals, true)
+	}
+
+	// Reflecting a block within a larger matrix leaves the rest unchanged.
+	m := NewDense(3, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	})
+	v, tau, _ := Householder([]float64{5, 8})
+	m.ReflectRows(v, tau, 1)
+	c.Check(m.rowView(0), check.DeepEquals, []float64{1, 2, 3})
+	c.Check(math.Abs(m.At(2, 1)) < 1e-14, check.Equals, true)
+
+	c.Check(func() { m.ReflectRows(v, tau, 2) }, check.PanicMatches, string(ErrIndexOutOfRange))
+	c.Check(func() { m.ReflectCols(v, tau, 2) }, check.PanicMatches, string(ErrIndexOutOfRange))
+}
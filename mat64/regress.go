@@ -0,0 +1,97 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// Vandermonde returns the len(x)-by-(degree+1) design matrix for a polynomial of
+// the given degree evaluated at the points in x. Element (i, j) of the result
+// is x[i]^j.
+func Vandermonde(x Vec, degree int) *Dense {
+	if degree < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	m := NewDense(len(x), degree+1, nil)
+	for i, v := range x {
+		row := m.rowView(i)
+		p := 1.0
+		for j := range row {
+			row[j] = p
+			p *= v
+		}
+	}
+	return m
+}
+
+// PolyFit returns the coefficients of the polynomial of the given degree that
+// fits the points (x[i], y[i]) in the least squares sense. The coefficient of
+// x^j is held in element j of the result. PolyFit will panic with ErrShape if x
+// and y differ in length or if there are fewer points than coefficients.
+func PolyFit(x, y Vec, degree int) Vec {
+	if len(x) != len(y) {
+		panic(ErrShape)
+	}
+	v := Vandermonde(x, degree)
+	if len(x) < degree+1 {
+		panic(ErrShape)
+	}
+	b := NewDense(len(y), 1, append([]float64(nil), y...))
+	c := QR(v).Solve(b)
+	return Vec(c.Col(nil, 0))
+}
+
+// PolyEval returns the value at x of the polynomial with coefficients c, where
+// the coefficient of x^j is held in c[j].
+func PolyEval(c Vec, x float64) float64 {
+	var v float64
+	for j := len(c) - 1; j >= 0; j-- {
+		v = v*x + c[j]
+	}
+	return v
+}
+
+// RidgePath returns the ridge regression solutions of a.x = b for each of the
+// regularization parameters in lambdas, that is, for each λ the matrix x that
+// minimizes ||a.x - b||^2 + λ||x||^2 in the Frobenius norm. All the solutions are
+// computed from a single singular value decomposition of a. For λ == 0 the
+// minimum norm least squares solution is returned. RidgePath will panic with
+// ErrShape if a and b have different numbers of rows.
+func RidgePath(a, b Matrix, lambdas []float64) []*Dense {
+	m, n := a.Dims()
+	bm, bn := b.Dims()
+	if m != bm {
+		panic(ErrShape)
+	}
+	svd := SVD(DenseCopyOf(a), epsilon, small, true, true)
+	k := min(m, n)
+	tol := float64(max(m, n)) * epsilon * svd.Sigma[0]
+
+	// utb holds the first k rows of U'.b.
+	var u, ut, utb Dense
+	u.View(svd.U, 0, 0, m, k)
+	ut.TCopy(&u)
+	utb.Mul(&ut, b)
+
+	var v Dense
+	v.View(svd.V, 0, 0, n, k)
+
+	xs := make([]*Dense, len(lambdas))
+	scaled := NewDense(k, bn, nil)
+	for l, lambda := range lambdas {
+		for i := 0; i < k; i++ {
+			s := svd.Sigma[i]
+			var f float64
+			if lambda != 0 || s > tol {
+				f = s / (s*s + lambda)
+			}
+			row := scaled.rowView(i)
+			for j, e := range utb.rowView(i) {
+				row[j] = f * e
+			}
+		}
+		x := &Dense{}
+		x.Mul(&v, scaled)
+		xs[l] = x
+	}
+	return xs
+}
@@ -0,0 +1,74 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestVandermonde(c *check.C) {
+	v := Vandermonde(Vec{1, 2, -3}, 2)
+	c.Check(v.Equals(NewDense(3, 3, []float64{
+		1, 1, 1,
+		1, 2, 4,
+		1, -3, 9,
+	})), check.Equals, true)
+}
+
+func (s *S) TestPolyFit(c *check.C) {
+	want := Vec{1, -2, 0.5}
+	x := Vec{-2, -1, 0, 0.5, 1, 2, 3}
+	y := make(Vec, len(x))
+	for i, v := range x {
+		y[i] = PolyEval(want, v)
+	}
+	got := PolyFit(x, y, 2)
+	for i := range want {
+		c.Check(math.Abs(got[i]-want[i]) < 1e-12, check.Equals, true, check.Commentf("coefficient %d: %v", i, got[i]))
+	}
+
+	// A line fit through noisy data.
+	got = PolyFit(Vec{0, 1, 2, 3}, Vec{1, 2, 2, 3}, 1)
+	c.Check(math.Abs(got[0]-1.1) < 1e-12, check.Equals, true)
+	c.Check(math.Abs(got[1]-0.6) < 1e-12, check.Equals, true)
+
+	c.Check(func() { PolyFit(x, y[1:], 2) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { PolyFit(Vec{1, 2}, Vec{1, 2}, 2) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestRidgePath(c *check.C) {
+	a := NewDense(4, 2, []float64{
+		1, 0,
+		1, 1,
+		1, 2,
+		1, 3,
+	})
+	b := NewDense(4, 1, []float64{1, 2, 2, 3})
+	lambdas := []float64{0, 0.1, 10}
+	xs := RidgePath(a, b, lambdas)
+	c.Check(len(xs), check.Equals, len(lambdas))
+
+	for i, lambda := range lambdas {
+		// Solve the normal equations (a'a + λI) x = a'b.
+		var at, ata, atb Dense
+		at.TCopy(a)
+		ata.Mul(&at, a)
+		for j := 0; j < 2; j++ {
+			ata.Set(j, j, ata.At(j, j)+lambda)
+		}
+		atb.Mul(&at, b)
+		want := Solve(&ata, &atb)
+		c.Check(xs[i].EqualsApprox(want, 1e-12), check.Equals, true, check.Commentf("lambda %v", lambda))
+	}
+
+	// The minimum norm solution of a rank deficient system.
+	d := NewDense(2, 2, []float64{1, 1, 1, 1})
+	x := RidgePath(d, NewDense(2, 1, []float64{2, 2}), []float64{0})[0]
+	c.Check(x.EqualsApprox(NewDense(2, 1, []float64{1, 1}), 1e-12), check.Equals, true)
+
+	c.Check(func() { RidgePath(a, NewDense(3, 1, nil), lambdas) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,79 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"sort"
+)
+
+// ErrNaNScore is the panic value used by ROC when a score is NaN.
+const ErrNaNScore = Error("mat64: NaN score")
+
+// ROC returns the receiver operating characteristic curve of a binary classifier
+// with the given scores for observations with the true classes in labels, where
+// a higher score indicates the positive class. The curve is returned as a matrix
+// with the false positive rate in column 0 and the true positive rate in column
+// 1, with one row for each distinct score threshold in decreasing order, preceded
+// by the origin. Observations with equal scores are treated as a single step of
+// the curve. The area under the curve is computed using the trapezoid rule.
+//
+// ROC will panic with ErrShape if scores and labels differ in length and with
+// ErrNaNScore if a score is NaN, since NaN scores cannot be ordered. If labels
+// does not hold both positive and negative observations the corresponding rates,
+// and the area, are NaN.
+func ROC(scores Vec, labels []bool) (curve *Dense, auc float64) {
+	if len(scores) != len(labels) {
+		panic(ErrShape)
+	}
+	for _, s := range scores {
+		if math.IsNaN(s) {
+			panic(ErrNaNScore)
+		}
+	}
+	idx := make([]int, len(scores))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Sort(byScoreDesc{idx: idx, scores: scores})
+
+	var pos, neg float64
+	for _, l := range labels {
+		if l {
+			pos++
+		} else {
+			neg++
+		}
+	}
+
+	data := []float64{0, 0}
+	var tp, fp float64
+	for k := 0; k < len(idx); {
+		s := scores[idx[k]]
+		for ; k < len(idx) && scores[idx[k]] == s; k++ {
+			if labels[idx[k]] {
+				tp++
+			} else {
+				fp++
+			}
+		}
+		data = append(data, fp/neg, tp/pos)
+	}
+	curve = NewDense(len(data)/2, 2, data)
+
+	for i := 2; i < len(data); i += 2 {
+		auc += (data[i] - data[i-2]) * (data[i+1] + data[i-1]) / 2
+	}
+	return curve, auc
+}
+
+type byScoreDesc struct {
+	idx    []int
+	scores Vec
+}
+
+func (s byScoreDesc) Len() int           { return len(s.idx) }
+func (s byScoreDesc) Less(i, j int) bool { return s.scores[s.idx[i]] > s.scores[s.idx[j]] }
+func (s byScoreDesc) Swap(i, j int)      { s.idx[i], s.idx[j] = s.idx[j], s.idx[i] }
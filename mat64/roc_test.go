@@ -0,0 +1,54 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestROC(c *check.C) {
+	for i, test := range []struct {
+		scores Vec
+		labels []bool
+		curve  []float64
+		auc    float64
+	}{
+		{
+			scores: Vec{0.9, 0.8, 0.7, 0.6},
+			labels: []bool{true, true, false, false},
+			curve:  []float64{0, 0, 0, 0.5, 0, 1, 0.5, 1, 1, 1},
+			auc:    1,
+		},
+		{
+			scores: Vec{0.1, 0.4, 0.35, 0.8},
+			labels: []bool{false, false, true, true},
+			curve:  []float64{0, 0, 0, 0.5, 0.5, 0.5, 0.5, 1, 1, 1},
+			auc:    0.75,
+		},
+		{
+			// Tied scores form a single diagonal step.
+			scores: Vec{0.5, 0.5, 0.5, 0.5},
+			labels: []bool{true, false, true, false},
+			curve:  []float64{0, 0, 1, 1},
+			auc:    0.5,
+		},
+	} {
+		curve, auc := ROC(test.scores, test.labels)
+		c.Check(curve.Equals(NewDense(len(test.curve)/2, 2, test.curve)), check.Equals, true, check.Commentf("Test %d", i))
+		c.Check(math.Abs(auc-test.auc) < 1e-15, check.Equals, true, check.Commentf("Test %d: auc %v", i, auc))
+	}
+
+	_, auc := ROC(Vec{1, 2}, []bool{true, true})
+	c.Check(math.IsNaN(auc), check.Equals, true)
+	c.Check(func() { ROC(Vec{1}, nil) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { ROC(Vec{0.1, math.NaN(), 0.7}, []bool{false, true, true}) }, check.PanicMatches, string(ErrNaNScore))
+
+	// Infinite scores are ordered and grouped like finite ones.
+	curve, auc := ROC(Vec{math.Inf(1), 0, math.Inf(-1), math.Inf(1)}, []bool{true, false, false, true})
+	c.Check(curve.Equals(NewDense(4, 2, []float64{0, 0, 0, 1, 0.5, 1, 1, 1})), check.Equals, true)
+	c.Check(auc, check.Equals, 1.)
+}
@@ -0,0 +1,141 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// SaddlePoint returns the augmented saddle point matrix for the equality
+// constrained least squares problem min ||a.x - b|| subject to c.x = d:
+//
+//  ⎡ I   a   0  ⎤
+//  ⎢ a'  0   c' ⎥
+//  ⎣ 0   c   0  ⎦
+//
+// where a is m-by-n and c is p-by-n. If c is nil the constraint blocks are
+// omitted and the matrix is the (m+n)-by-(m+n) system [I, a; a', 0].
+// SaddlePoint will panic with ErrShape if a and c do not have the same number
+// of columns.
+func SaddlePoint(a, c Matrix) *Dense {
+	m, n := a.Dims()
+	var p int
+	if c != nil {
+		var cc int
+		p, cc = c.Dims()
+		if cc != n {
+			panic(ErrShape)
+		}
+	}
+
+	k := NewDense(m+n+p, m+n+p, nil)
+	for i := 0; i < m; i++ {
+		k.Set(i, i, 1)
+	}
+
+	var w Dense
+	w.View(k, 0, m, m, n)
+	w.Copy(a)
+	w.View(k, m, 0, n, m)
+	w.TCopy(a)
+	if p != 0 {
+		w.View(k, m+n, m, p, n)
+		w.Copy(c)
+		w.View(k, m, m+n, n, p)
+		w.TCopy(c)
+	}
+
+	return k
+}
+
+// ConstrainedLeastSquares returns the matrix x that minimizes the Frobenius norm
+// of a.x - b subject to c.x = d, the solution of the saddle point system returned
+// by SaddlePoint. The constraint matrices c and d may both be nil, in which case
+// the unconstrained least squares solution is returned. The matrices b and d must
+// have the same number of columns; each column is treated as a separate right
+// hand side.
+//
+// The saddle point system is solved by block elimination rather than assembled.
+// The constraints are eliminated with the nullspace method, as for LSE, leaving
+// the unconstrained problem min ||a.Q2.y - (b - a.x0)|| over the nullspace of c,
+// which is solved by the QR factorization of a.Q2. This avoids both forming the
+// normal equations a'.a, which would square the condition number of the problem,
+// and factorizing the full (m+n+p)-by-(m+n+p) system, so the cost is that of QR
+// factorizations of the p-by-n matrix c and the m-by-(n-p) matrix a.Q2.
+//
+// ConstrainedLeastSquares will panic with ErrShape if the dimensions of the
+// arguments do not agree, and with ErrSingular if the saddle point system is
+// singular, which is the case when c does not have full row rank or the stacked
+// matrix [a; c] does not have full column rank. LSE handles the latter case.
+func ConstrainedLeastSquares(a, b, c, d Matrix) *Dense {
+	m, n := a.Dims()
+	bm, bn := b.Dims()
+	if bm != m {
+		panic(ErrShape)
+	}
+	if (c == nil) != (d == nil) {
+		panic(ErrShape)
+	}
+	var p int
+	if c != nil {
+		var cn int
+		p, cn = c.Dims()
+		if dm, dn := d.Dims(); cn != n || dm != p || dn != bn {
+			panic(ErrShape)
+		}
+	}
+	if p > n {
+		panic(ErrSingular)
+	}
+
+	// Eliminate the constraints, leaving a problem in the n-p unknowns
+	// of the nullspace of c.
+	x := NewDense(n, bn, nil)
+	ar := &Dense{}
+	rhs := DenseCopyOf(b)
+	var q2 *Dense
+	if p == 0 {
+		ar.Clone(a)
+	} else {
+		var ok bool
+		x, q2, ok = constraintNullspace(c, d)
+		if !ok {
+			panic(ErrSingular)
+		}
+		if p == n {
+			return x
+		}
+		ar.Mul(a, q2)
+		var ax0 Dense
+		ax0.Mul(a, x)
+		rhs.Sub(rhs, &ax0)
+	}
+
+	// The reduced problem has a unique solution only if a.Q2 has full
+	// column rank.
+	if m < n-p {
+		panic(ErrSingular)
+	}
+	qr := QR(ar)
+	var maxDiag float64
+	for _, v := range qr.rDiag {
+		maxDiag = math.Max(maxDiag, math.Abs(v))
+	}
+	for _, v := range qr.rDiag {
+		if math.Abs(v) <= float64(m)*epsilon*maxDiag || maxDiag == 0 {
+			panic(ErrSingular)
+		}
+	}
+	y := qr.Solve(rhs)
+
+	if q2 == nil {
+		x.Copy(y)
+		return x
+	}
+	var q2y Dense
+	q2y.Mul(q2, y)
+	x.Add(x, &q2y)
+	return x
+}
@@ -0,0 +1,98 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSaddlePoint(c *check.C) {
+	a := NewDense(3, 2, []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+	})
+	con := NewDense(1, 2, []float64{7, 8})
+
+	k := SaddlePoint(a, nil)
+	c.Check(k.Equals(NewDense(5, 5, []float64{
+		1, 0, 0, 1, 2,
+		0, 1, 0, 3, 4,
+		0, 0, 1, 5, 6,
+		1, 3, 5, 0, 0,
+		2, 4, 6, 0, 0,
+	})), check.Equals, true)
+
+	k = SaddlePoint(a, con)
+	c.Check(k.Equals(NewDense(6, 6, []float64{
+		1, 0, 0, 1, 2, 0,
+		0, 1, 0, 3, 4, 0,
+		0, 0, 1, 5, 6, 0,
+		1, 3, 5, 0, 0, 7,
+		2, 4, 6, 0, 0, 8,
+		0, 0, 0, 7, 8, 0,
+	})), check.Equals, true)
+
+	c.Check(func() { SaddlePoint(a, NewDense(1, 3, nil)) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestConstrainedLeastSquares(c *check.C) {
+	for i, test := range []struct {
+		a, b, c, d *Dense
+		x          *Dense
+	}{
+		{
+			a: NewDense(3, 2, []float64{
+				1, 0,
+				0, 1,
+				1, 1,
+			}),
+			b: NewDense(3, 1, []float64{1, 1, 0}),
+			x: NewDense(2, 1, []float64{1.0 / 3, 1.0 / 3}),
+		},
+		{
+			a: eye(),
+			b: NewDense(3, 2, []float64{
+				1, 4,
+				2, 5,
+				3, 6,
+			}),
+			c: NewDense(1, 3, []float64{1, 1, 1}),
+			d: NewDense(1, 2, []float64{3, 3}),
+			x: NewDense(3, 2, []float64{
+				0, 0,
+				1, 1,
+				2, 2,
+			}),
+		},
+	} {
+		var x *Dense
+		if test.c == nil {
+			x = ConstrainedLeastSquares(test.a, test.b, nil, nil)
+			want := Solve(test.a, test.b)
+			c.Check(x.EqualsApprox(want, 1e-12), check.Equals, true, check.Commentf("Test %d", i))
+		} else {
+			x = ConstrainedLeastSquares(test.a, test.b, test.c, test.d)
+			var cx Dense
+			cx.Mul(test.c, x)
+			c.Check(cx.EqualsApprox(test.d, 1e-12), check.Equals, true, check.Commentf("Test %d", i))
+		}
+		c.Check(x.EqualsApprox(test.x, 1e-12), check.Equals, true, check.Commentf("Test %d: got %v", i, x))
+	}
+
+	// Problems without a unique solution are singular.
+	a := NewDense(2, 3, []float64{
+		1, 1, 0,
+		1, 1, 0,
+	})
+	b := NewDense(2, 1, []float64{2, 2})
+	c.Check(func() {
+		ConstrainedLeastSquares(a, b, NewDense(1, 3, []float64{1, -1, 0}), NewDense(1, 1, nil))
+	}, check.PanicMatches, string(ErrSingular))
+	c.Check(func() {
+		ConstrainedLeastSquares(a, b, NewDense(2, 3, []float64{1, 0, 1, 2, 0, 2}), NewDense(2, 1, nil))
+	}, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { ConstrainedLeastSquares(a, b, nil, nil) }, check.PanicMatches, string(ErrSingular))
+}
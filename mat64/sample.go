@@ -0,0 +1,130 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "sort"
+
+// RandomPermutation returns a permutation of order n drawn uniformly at random
+// using src.
+func RandomPermutation(n int, src Source) Permutation {
+	p := IdentityPermutation(n)
+	shuffleInts(p, src)
+	return p
+}
+
+// ShuffleRows randomly permutes the rows of the receiver in place using src, so
+// that each ordering is equally likely, and returns the permutation applied:
+// row i of the shuffled matrix is row p[i] of the original. The permutation may
+// be used to shuffle aligned targets in the same way with PermuteRows.
+func (m *Dense) ShuffleRows(src Source) Permutation {
+	src = source(src)
+	n := m.mat.Rows
+	p := IdentityPermutation(n)
+	for i := n - 1; i > 0; i-- {
+		j := src.Intn(i + 1)
+		if i == j {
+			continue
+		}
+		p[i], p[j] = p[j], p[i]
+		ri, rj := m.rowView(i), m.rowView(j)
+		for k, v := range ri {
+			ri[k], rj[k] = rj[k], v
+		}
+	}
+	return p
+}
+
+// SampleRows returns a view of k rows of m chosen at random using src, in the
+// order drawn. If replace is false the rows are distinct and each subset of k
+// rows is equally likely, otherwise each row is drawn independently. SampleRows
+// will panic with ErrShape if k is negative, or is greater than the number of
+// rows of m when replace is false.
+func SampleRows(m *Dense, k int, replace bool, src Source) *RowSubset {
+	src = source(src)
+	r, _ := m.Dims()
+	if k < 0 || (!replace && k > r) {
+		panic(ErrShape)
+	}
+	rows := make([]int, k)
+	if replace {
+		for i := range rows {
+			rows[i] = src.Intn(r)
+		}
+		return NewRowSubset(m, rows)
+	}
+
+	// A partial Fisher-Yates shuffle places the sample in the first k
+	// positions.
+	idx := IdentityPermutation(r)
+	for i := range rows {
+		j := i + src.Intn(r-i)
+		idx[i], idx[j] = idx[j], idx[i]
+	}
+	copy(rows, idx[:k])
+	return NewRowSubset(m, rows)
+}
+
+// StratifiedSampleRows returns a view of k distinct rows of m chosen at random
+// using src such that the proportion of each label among the chosen rows is as
+// close as possible to its proportion among all rows, where labels holds the
+// label of each row of m. The number of rows taken from each label is its
+// proportional share of k rounded by the largest remainder method, with ties
+// going to the label that appears first, and the rows of the view are in
+// ascending order. StratifiedSampleRows will panic with
+// ErrShape if the length of labels is not the number of rows of m or k is not in
+// [0, len(labels)].
+func StratifiedSampleRows(m *Dense, labels []int, k int, src Source) *RowSubset {
+	r, _ := m.Dims()
+	n := len(labels)
+	if n != r || k < 0 || k > n {
+		panic(ErrShape)
+	}
+
+	var classes [][]int
+	index := make(map[int]int)
+	for i, l := range labels {
+		c, ok := index[l]
+		if !ok {
+			c = len(classes)
+			index[l] = c
+			classes = append(classes, nil)
+		}
+		classes[c] = append(classes[c], i)
+	}
+
+	// Allocate the floor of each share, then distribute the remaining
+	// rows to the classes with the largest remainders.
+	counts := make([]int, len(classes))
+	order := make([]int, len(classes))
+	rem := make([]int, len(classes))
+	var taken int
+	for c, members := range classes {
+		counts[c] = len(members) * k / n
+		rem[c] = len(members) * k % n
+		order[c] = c
+		taken += counts[c]
+	}
+	sort.Stable(byRemainder{order: order, rem: rem})
+	for _, c := range order[:k-taken] {
+		counts[c]++
+	}
+
+	var rows []int
+	for c, members := range classes {
+		shuffleInts(members, src)
+		rows = append(rows, members[:counts[c]]...)
+	}
+	sort.Ints(rows)
+	return NewRowSubset(m, rows)
+}
+
+// byRemainder sorts class indices by decreasing remainder.
+type byRemainder struct {
+	order, rem []int
+}
+
+func (b byRemainder) Len() int           { return len(b.order) }
+func (b byRemainder) Less(i, j int) bool { return b.rem[b.order[i]] > b.rem[b.order[j]] }
+func (b byRemainder) Swap(i, j int)      { b.order[i], b.order[j] = b.order[j], b.order[i] }
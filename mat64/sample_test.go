@@ -0,0 +1,113 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestShuffleRows(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	p := RandomPermutation(10, src)
+	c.Check(p.Valid(), check.Equals, true)
+	c.Check(RandomPermutation(0, src), check.HasLen, 0)
+
+	// Row i holds its original index in every column.
+	m := NewDense(6, 3, nil)
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 3; j++ {
+			m.Set(i, j, float64(i))
+		}
+	}
+	orig := DenseCopyOf(m)
+	perm := m.ShuffleRows(src)
+	c.Check(perm.Valid(), check.Equals, true)
+	var want Dense
+	want.PermuteRows(perm, orig)
+	c.Check(m.Equals(&want), check.Equals, true)
+
+	// Each row is equally likely to land in each position.
+	const samples = 6000
+	var counts [3][3]int
+	for n := 0; n < samples; n++ {
+		a := NewDense(3, 1, []float64{0, 1, 2})
+		a.ShuffleRows(src)
+		for i := 0; i < 3; i++ {
+			counts[i][int(a.At(i, 0))]++
+		}
+	}
+	for i := range counts {
+		for _, v := range counts[i] {
+			c.Check(math.Abs(float64(v)/samples-1.0/3) < 0.03, check.Equals, true)
+		}
+	}
+}
+
+func (s *S) TestSampleRows(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	m := NewDense(10, 2, nil)
+	for i := 0; i < 10; i++ {
+		m.Set(i, 0, float64(i))
+	}
+	for _, k := range []int{0, 1, 5, 10} {
+		v := SampleRows(m, k, false, src)
+		r, cols := v.Dims()
+		c.Check(r == k && cols == 2, check.Equals, true)
+		seen := make(map[int]bool)
+		for i, row := range v.Indices() {
+			c.Check(seen[row], check.Equals, false)
+			seen[row] = true
+			c.Check(v.At(i, 0), check.Equals, float64(row))
+		}
+	}
+	v := SampleRows(m, 25, true, src)
+	r, _ := v.Dims()
+	c.Check(r, check.Equals, 25)
+
+	// Each row is equally likely to be chosen.
+	const samples = 5000
+	hits := make([]int, 10)
+	for n := 0; n < samples; n++ {
+		for _, row := range SampleRows(m, 3, false, src).Indices() {
+			hits[row]++
+		}
+	}
+	for _, h := range hits {
+		c.Check(math.Abs(float64(h)/samples-0.3) < 0.03, check.Equals, true)
+	}
+
+	c.Check(func() { SampleRows(m, 11, false, src) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { SampleRows(m, -1, true, src) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestStratifiedSampleRows(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	labels := []int{0, 0, 0, 0, 0, 0, 1, 1, 1, 2, 0, 1}
+	m := NewDense(len(labels), 1, nil)
+	for _, test := range []struct {
+		k    int
+		want map[int]int
+	}{
+		{k: 12, want: map[int]int{0: 7, 1: 4, 2: 1}},
+		{k: 6, want: map[int]int{0: 4, 1: 2}},
+		{k: 3, want: map[int]int{0: 2, 1: 1}},
+		{k: 0, want: map[int]int{}},
+	} {
+		v := StratifiedSampleRows(m, labels, test.k, src)
+		got := make(map[int]int)
+		last := -1
+		for _, row := range v.Indices() {
+			c.Check(row > last, check.Equals, true)
+			last = row
+			got[labels[row]]++
+		}
+		c.Check(got, check.DeepEquals, test.want, check.Commentf("k=%d", test.k))
+	}
+	c.Check(func() { StratifiedSampleRows(m, labels[1:], 3, src) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { StratifiedSampleRows(m, labels, 13, src) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,200 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// Scaling is an affine transform applied to each column, or to each row if ByRow
+// is true, of a matrix by
+//
+//  x' = (x - Offset[j]) / Scale[j]
+//
+// where j is the index of the column or row. A nil Offset is treated as zero and
+// a nil Scale as one. A Scaling is returned by Center, Standardize and Normalize
+// so that the transform fitted to one matrix can be applied to others, such as
+// test data transformed with the means and deviations of the training data.
+type Scaling struct {
+	Offset []float64
+	Scale  []float64
+	ByRow  bool
+}
+
+// Center subtracts the mean of each column of m, or of each row if byRow is
+// true, in place and returns the transform applied.
+func Center(m *Dense, byRow bool) Scaling {
+	r, c := m.Dims()
+	if !byRow {
+		mean := make([]float64, c)
+		if r == 0 {
+			return Scaling{Offset: mean}
+		}
+		for i := 0; i < r; i++ {
+			axpyUnitary(1, m.rowView(i), mean)
+		}
+		for j := range mean {
+			mean[j] /= float64(r)
+		}
+		for i := 0; i < r; i++ {
+			axpyUnitary(-1, mean, m.rowView(i))
+		}
+		return Scaling{Offset: mean}
+	}
+	mean := make([]float64, r)
+	for i := range mean {
+		row := m.rowView(i)
+		var s float64
+		for _, v := range row {
+			s += v
+		}
+		if c > 0 {
+			mean[i] = s / float64(c)
+		}
+		for j := range row {
+			row[j] -= mean[i]
+		}
+	}
+	return Scaling{Offset: mean, ByRow: true}
+}
+
+// Standardize transforms each column of m, or each row if byRow is true, in
+// place to zero mean and unit sample standard deviation, the z-score, and
+// returns the transform applied. A column or row with zero deviation, or with
+// fewer than two elements, is centered but not scaled and has a Scale of one.
+func Standardize(m *Dense, byRow bool) Scaling {
+	s := Center(m, byRow)
+	r, c := m.Dims()
+	n, k := r, c
+	if byRow {
+		n, k = c, r
+	}
+	ss := make([]float64, k)
+	for i := 0; i < r; i++ {
+		for j, v := range m.rowView(i) {
+			if byRow {
+				ss[i] += v * v
+			} else {
+				ss[j] += v * v
+			}
+		}
+	}
+	s.Scale = make([]float64, k)
+	for j, v := range ss {
+		s.Scale[j] = 1
+		if n > 1 && v > 0 {
+			s.Scale[j] = math.Sqrt(v / float64(n-1))
+		}
+	}
+	s.divide(m)
+	return s
+}
+
+// Normalize scales each column of m, or each row if byRow is true, in place to
+// unit norm and returns the transform applied. The norm of order ord is computed
+// as by Vec.Norm, so ord is 1, 2 or math.Inf(1) for the L1, L2 and max norms. A
+// column or row that is zero is not scaled and has a Scale of one. Normalize will
+// panic with ErrNormOrder if ord is not a valid order for Vec.Norm.
+func Normalize(m *Dense, ord float64, byRow bool) Scaling {
+	r, c := m.Dims()
+	var scale []float64
+	if byRow {
+		scale = make([]float64, r)
+		for i := range scale {
+			scale[i] = Vec(m.rowView(i)).Norm(ord)
+		}
+	} else {
+		scale = make([]float64, c)
+		col := make(Vec, r)
+		for j := range scale {
+			for i := range col {
+				col[i] = m.at(i, j)
+			}
+			scale[j] = col.Norm(ord)
+		}
+	}
+	for j, v := range scale {
+		if v == 0 {
+			scale[j] = 1
+		}
+	}
+	s := Scaling{Scale: scale, ByRow: byRow}
+	s.divide(m)
+	return s
+}
+
+// checkDims panics with ErrShape if the transform does not match the columns,
+// or rows, of m.
+func (s Scaling) checkDims(m *Dense) {
+	r, c := m.Dims()
+	k := c
+	if s.ByRow {
+		k = r
+	}
+	if (s.Offset != nil && len(s.Offset) != k) || (s.Scale != nil && len(s.Scale) != k) {
+		panic(ErrShape)
+	}
+}
+
+// divide divides the columns, or rows, of m by the scales.
+func (s Scaling) divide(m *Dense) {
+	if s.Scale == nil {
+		return
+	}
+	r, _ := m.Dims()
+	for i := 0; i < r; i++ {
+		row := m.rowView(i)
+		if s.ByRow {
+			scalUnitary(1/s.Scale[i], row)
+			continue
+		}
+		for j := range row {
+			row[j] /= s.Scale[j]
+		}
+	}
+}
+
+// Apply transforms m in place. Apply will panic with ErrShape if the length of
+// Offset or Scale does not match the number of columns of m, or rows if ByRow is
+// true.
+func (s Scaling) Apply(m *Dense) {
+	s.checkDims(m)
+	r, _ := m.Dims()
+	if s.Offset != nil {
+		for i := 0; i < r; i++ {
+			row := m.rowView(i)
+			for j := range row {
+				if s.ByRow {
+					row[j] -= s.Offset[i]
+				} else {
+					row[j] -= s.Offset[j]
+				}
+			}
+		}
+	}
+	s.divide(m)
+}
+
+// Invert reverses the transform on m in place, so that s.Invert(m) undoes
+// s.Apply(m) up to rounding. Invert will panic with ErrShape as for Apply.
+func (s Scaling) Invert(m *Dense) {
+	s.checkDims(m)
+	r, _ := m.Dims()
+	for i := 0; i < r; i++ {
+		row := m.rowView(i)
+		for j := range row {
+			k := j
+			if s.ByRow {
+				k = i
+			}
+			if s.Scale != nil {
+				row[j] *= s.Scale[k]
+			}
+			if s.Offset != nil {
+				row[j] += s.Offset[k]
+			}
+		}
+	}
+}
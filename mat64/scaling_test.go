@@ -0,0 +1,163 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestCenter(c *check.C) {
+	m := NewDense(3, 2, []float64{
+		1, 4,
+		2, 6,
+		3, 14,
+	})
+	sc := Center(m, false)
+	c.Check(sc.Offset, check.DeepEquals, []float64{2, 8})
+	c.Check(sc.Scale, check.IsNil)
+	c.Check(m.Equals(NewDense(3, 2, []float64{-1, -4, 0, -2, 1, 6})), check.Equals, true)
+
+	m = NewDense(2, 3, []float64{
+		1, 2, 3,
+		4, 6, 14,
+	})
+	sc = Center(m, true)
+	c.Check(sc.ByRow, check.Equals, true)
+	c.Check(sc.Offset, check.DeepEquals, []float64{2, 8})
+	c.Check(m.Equals(NewDense(2, 3, []float64{-1, 0, 1, -4, -2, 6})), check.Equals, true)
+}
+
+func (s *S) TestStandardize(c *check.C) {
+	m := NewDense(4, 3, []float64{
+		1, 10, 5,
+		2, 20, 5,
+		3, 30, 5,
+		4, 40, 5,
+	})
+	orig := DenseCopyOf(m)
+	sc := Standardize(m, false)
+	c.Check(sc.Offset, check.DeepEquals, []float64{2.5, 25, 5})
+	sd := math.Sqrt(5.0 / 3)
+	want := []float64{sd, 10 * sd, 1}
+	for j, v := range sc.Scale {
+		c.Check(math.Abs(v-want[j]) < 1e-14*want[j], check.Equals, true, check.Commentf("col %d", j))
+	}
+	for j := 0; j < 2; j++ {
+		var sum, ss float64
+		for i := 0; i < 4; i++ {
+			sum += m.At(i, j)
+			ss += m.At(i, j) * m.At(i, j)
+		}
+		c.Check(math.Abs(sum) < 1e-14, check.Equals, true)
+		c.Check(math.Abs(ss/3-1) < 1e-14, check.Equals, true)
+	}
+	for i := 0; i < 4; i++ {
+		c.Check(m.At(i, 2), check.Equals, 0.)
+	}
+
+	// Inverting the transform recovers the original.
+	sc.Invert(m)
+	c.Check(m.EqualsApprox(orig, 1e-14), check.Equals, true)
+
+	// Rows are standardized as the columns of the transpose.
+	m = new(Dense)
+	m.TCopy(orig)
+	sr := Standardize(m, true)
+	c.Check(sr.ByRow, check.Equals, true)
+	c.Check(sr.Offset, check.DeepEquals, sc.Offset)
+	c.Check(sr.Scale, check.DeepEquals, sc.Scale)
+	st := DenseCopyOf(orig)
+	Standardize(st, false)
+	var stt Dense
+	stt.TCopy(st)
+	c.Check(m.EqualsApprox(&stt, 1e-14), check.Equals, true)
+
+	// A single row has no deviation to scale by.
+	m = NewDense(1, 2, []float64{3, 4})
+	sc = Standardize(m, false)
+	c.Check(sc.Scale, check.DeepEquals, []float64{1, 1})
+	c.Check(m.Equals(NewDense(1, 2, nil)), check.Equals, true)
+}
+
+func (s *S) TestNormalize(c *check.C) {
+	for _, test := range []struct {
+		ord   float64
+		byRow bool
+		scale []float64
+		want  []float64
+	}{
+		{
+			ord: 1, byRow: true,
+			scale: []float64{7, 1},
+			want:  []float64{3.0 / 7, -4.0 / 7, 0, 0},
+		},
+		{
+			ord: 2, byRow: true,
+			scale: []float64{5, 1},
+			want:  []float64{0.6, -0.8, 0, 0},
+		},
+		{
+			ord: math.Inf(1), byRow: true,
+			scale: []float64{4, 1},
+			want:  []float64{0.75, -1, 0, 0},
+		},
+		{
+			ord: 2, byRow: false,
+			scale: []float64{3, 4},
+			want:  []float64{1, -1, 0, 0},
+		},
+	} {
+		m := NewDense(2, 2, []float64{
+			3, -4,
+			0, 0,
+		})
+		sc := Normalize(m, test.ord, test.byRow)
+		c.Check(sc.Offset, check.IsNil)
+		c.Check(sc.ByRow, check.Equals, test.byRow)
+		c.Check(sc.Scale, check.DeepEquals, test.scale, check.Commentf("ord %v byRow %v", test.ord, test.byRow))
+		c.Check(m.EqualsApprox(NewDense(2, 2, test.want), 1e-15), check.Equals, true, check.Commentf("ord %v byRow %v", test.ord, test.byRow))
+	}
+
+	c.Check(func() { Normalize(NewDense(1, 1, []float64{1}), -3, false) }, check.PanicMatches, string(ErrNormOrder))
+}
+
+func (s *S) TestScalingApply(c *check.C) {
+	train := NewDense(3, 2, []float64{
+		1, 100,
+		2, 300,
+		3, 500,
+	})
+	sc := Standardize(train, false)
+
+	// New data is transformed with the fitted means and scales.
+	m := NewDense(2, 2, []float64{
+		2, 300,
+		4, 100,
+	})
+	orig := DenseCopyOf(m)
+	sc.Apply(m)
+	c.Check(m.EqualsApprox(NewDense(2, 2, []float64{0, 0, 2, -1}), 1e-14), check.Equals, true)
+	sc.Invert(m)
+	c.Check(m.EqualsApprox(orig, 1e-14), check.Equals, true)
+
+	// A view is transformed without touching the rest of its storage.
+	big := NewDense(3, 3, []float64{
+		1, 2, 300,
+		1, 4, 100,
+		1, 1, 1,
+	})
+	var v Dense
+	v.View(big, 0, 1, 2, 2)
+	sc.Apply(&v)
+	c.Check(big.EqualsApprox(NewDense(3, 3, []float64{1, 0, 0, 1, 2, -1, 1, 1, 1}), 1e-14), check.Equals, true)
+
+	c.Check(func() { sc.Apply(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { sc.Invert(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+	sc.ByRow = true
+	c.Check(func() { sc.Apply(NewDense(3, 2, nil)) }, check.PanicMatches, string(ErrShape))
+	sc.Apply(NewDense(2, 3, nil))
+}
@@ -0,0 +1,253 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// SchurFactors holds the real Schur decomposition of a square matrix a, such
+// that a = Z.T.Z' where Z is orthogonal and T is quasi-upper triangular with
+// 1-by-1 diagonal blocks holding the real eigenvalues of a and 2-by-2 diagonal
+// blocks holding the complex conjugate pairs.
+type SchurFactors struct {
+	T *Dense
+	Z *Dense
+}
+
+// Schur returns the real Schur decomposition of the square matrix a. The matrix
+// a is overwritten during the decomposition.
+func Schur(a *Dense, epsilon float64) SchurFactors {
+	m, n := a.Dims()
+	if m != n {
+		panic(ErrSquare)
+	}
+	checkFiniteDense("Schur", 0, a)
+
+	d := make([]float64, n)
+	e := make([]float64, n)
+
+	// Reduce to Hessenberg form and then to real Schur form.
+	t, z := orthes(a, nil, nil)
+	hqr2(d, e, t, z, epsilon, false, nil)
+
+	// Clear the residue below the subdiagonal and the negligible
+	// subdiagonal elements outside the complex pair blocks.
+	for i := 2; i < n; i++ {
+		zero(t.rowView(i)[:i-1])
+	}
+	for i := 0; i < n-1; i++ {
+		if e[i] <= 0 {
+			t.Set(i+1, i, 0)
+		}
+	}
+
+	return SchurFactors{T: t, Z: z}
+}
+
+// Select reorders the Schur decomposition so that the diagonal blocks whose
+// eigenvalues satisfy selector lead the diagonal of T, updating T and Z in place
+// while maintaining a = Z.T.Z'. For a complex conjugate pair, selector is called
+// once with the eigenvalue having positive imaginary part and the pair is moved
+// together. The leading columns of Z, up to the returned number of selected
+// eigenvalues, then form an orthonormal basis for the invariant subspace of a
+// associated with the selected eigenvalues.
+//
+// Select will panic with ErrSingular if a selected block must be moved past an
+// unselected block with the same eigenvalues.
+func (f SchurFactors) Select(selector func(re, im float64) bool) int {
+	sizes := schurBlocks(f.T)
+	keys := make([]int, len(sizes))
+	var ks, i int
+	for b, p := range sizes {
+		if re, im := schurBlockEigenvalue(f.T, i, p); selector(re, im) {
+			ks += p
+		} else {
+			keys[b] = 1
+		}
+		i += p
+	}
+	sortSchurBlocks(f.T, f.Z, sizes, keys)
+	return ks
+}
+
+// schurBlocks returns the sizes of the diagonal blocks of the quasi-triangular t.
+func schurBlocks(t *Dense) []int {
+	n, _ := t.Dims()
+	var sizes []int
+	for i := 0; i < n; {
+		p := schurBlockSize(t, i)
+		sizes = append(sizes, p)
+		i += p
+	}
+	return sizes
+}
+
+// sortSchurBlocks reorders the diagonal blocks of t by swapping adjacent blocks
+// so that they appear in ascending order of keys, accumulating the transformation
+// into z. The ordering is stable. The block sizes and keys of the current
+// ordering are held in sizes and keys, which are updated.
+func sortSchurBlocks(t, z *Dense, sizes, keys []int) {
+	for i := 1; i < len(sizes); i++ {
+		for j := i; j > 0 && keys[j-1] > keys[j]; j-- {
+			var start int
+			for _, p := range sizes[:j-1] {
+				start += p
+			}
+			swapSchur(t, z, start, sizes[j-1], sizes[j])
+			sizes[j-1], sizes[j] = sizes[j], sizes[j-1]
+			keys[j-1], keys[j] = keys[j], keys[j-1]
+		}
+	}
+}
+
+// InvariantSubspace returns an orthonormal basis for the invariant subspace of
+// the square matrix a associated with the eigenvalues satisfying selector, as
+// the columns of the returned matrix. The basis is obtained from the ordered
+// Schur decomposition of a, and complex conjugate eigenvalue pairs are selected
+// as described for SchurFactors.Select.
+func InvariantSubspace(a *Dense, selector func(re, im float64) bool) *Dense {
+	f := Schur(DenseCopyOf(a), epsilon)
+	k := f.Select(selector)
+	n, _ := a.Dims()
+	basis := NewDense(n, k, nil)
+	basis.Copy(f.Z)
+	return basis
+}
+
+// schurBlockSize returns the size of the diagonal block of t starting at i.
+func schurBlockSize(t *Dense, i int) int {
+	n, _ := t.Dims()
+	if i+1 < n && t.At(i+1, i) != 0 {
+		return 2
+	}
+	return 1
+}
+
+// schurBlockEigenvalue returns the eigenvalue of the diagonal block of t
+// starting at i with size p. For a 2-by-2 block the eigenvalue with positive
+// imaginary part is returned.
+func schurBlockEigenvalue(t *Dense, i, p int) (re, im float64) {
+	if p == 1 {
+		return t.At(i, i), 0
+	}
+	a, b := t.At(i, i), t.At(i, i+1)
+	c, d := t.At(i+1, i), t.At(i+1, i+1)
+	h := (a - d) / 2
+	disc := h*h + b*c
+	return (a + d) / 2, math.Sqrt(math.Max(-disc, 0))
+}
+
+// swapSchur swaps the adjacent diagonal blocks of t starting at j with sizes p
+// and q, accumulating the orthogonal transformation into z.
+//
+// This is the direct swapping algorithm described in Bai and Demmel, "On swapping
+// diagonal blocks in real Schur form", Linear Algebra Appl. 186:73-95, 1993.
+func swapSchur(t, z *Dense, j, p, q int) {
+	n, _ := t.Dims()
+	k := p + q
+
+	// Solve the Sylvester equation A11.X - X.A22 = A12.
+	var a11, a12, a22 Dense
+	a11.View(t, j, j, p, p)
+	a12.View(t, j, j+p, p, q)
+	a22.View(t, j+p, j+p, q, q)
+	x, ok := sylvesterSmall(&a11, &a22, &a12)
+	if !ok {
+		panic(ErrSingular)
+	}
+
+	// The columns of [-X; I] span the invariant subspace of the A22 block.
+	m := NewDense(k, q, nil)
+	for c := 0; c < q; c++ {
+		for r := 0; r < p; r++ {
+			m.Set(r, c, -x.At(r, c))
+		}
+		m.Set(p+c, c, 1)
+	}
+	qm := householderQ(m)
+	qt := &Dense{}
+	qt.TCopy(qm)
+
+	var w, tmp Dense
+	w.View(t, j, 0, k, n)
+	tmp.Mul(qt, &w)
+	w.Copy(&tmp)
+
+	tmp.Reset()
+	w.View(t, 0, j, n, k)
+	tmp.Mul(&w, qm)
+	w.Copy(&tmp)
+
+	tmp.Reset()
+	w.View(z, 0, j, n, k)
+	tmp.Mul(&w, qm)
+	w.Copy(&tmp)
+
+	// Clear the block below the swapped diagonal blocks.
+	for r := j + q; r < j+k; r++ {
+		zero(t.rowView(r)[j : j+q])
+	}
+}
+
+// sylvesterSmall solves the Sylvester equation a.x - x.b = c for x, where a is
+// p-by-p, b is q-by-q and c is p-by-q, by solving the equivalent linear system
+// of order p*q formed from Kronecker products. It is intended for the small
+// systems arising from the diagonal blocks of a real Schur form. The returned
+// bool is false if the system is singular.
+func sylvesterSmall(a, b, c Matrix) (*Dense, bool) {
+	p, _ := a.Dims()
+	q, _ := b.Dims()
+	s := NewDense(p*q, p*q, nil)
+	rhs := NewDense(p*q, 1, nil)
+	for col := 0; col < q; col++ {
+		for r := 0; r < p; r++ {
+			row := r + col*p
+			rhs.Set(row, 0, c.At(r, col))
+			for i := 0; i < p; i++ {
+				s.Set(row, i+col*p, s.At(row, i+col*p)+a.At(r, i))
+			}
+			for i := 0; i < q; i++ {
+				s.Set(row, r+i*p, s.At(row, r+i*p)-b.At(i, col))
+			}
+		}
+	}
+	lu := LU(s)
+	if lu.IsSingular() {
+		return nil, false
+	}
+	v := lu.Solve(rhs)
+	x := NewDense(p, q, nil)
+	for col := 0; col < q; col++ {
+		for r := 0; r < p; r++ {
+			x.Set(r, col, v.At(r+col*p, 0))
+		}
+	}
+	return x, true
+}
+
+// householderQ returns the full k-by-k orthogonal factor of the QR decomposition
+// of the k-by-q matrix m with k >= q, so that the leading q columns of the result
+// span the columns of m.
+func householderQ(m *Dense) *Dense {
+	k, q := m.Dims()
+	a := DenseCopyOf(m)
+	qm := identityDense(k)
+
+	x := make([]float64, k)
+	for c := 0; c < q; c++ {
+		for i := c; i < k; i++ {
+			x[i] = a.At(i, c)
+		}
+		v, tau, _ := Householder(x[c:])
+
+		// Apply H to the remaining columns of a and accumulate Q = Q.H.
+		var rest Dense
+		rest.View(a, 0, c, k, q-c)
+		rest.ReflectRows(v, tau, c)
+		qm.ReflectCols(v, tau, c)
+	}
+	return qm
+}
@@ -0,0 +1,108 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func schurTestMatrix() *Dense {
+	return NewDense(5, 5, []float64{
+		4, 1, 0, 0, 2,
+		-3, 4, 0, 1, 0,
+		0, 0, 1, 0, 0,
+		1, 0, 0, -2, 3,
+		0, 2, 1, -4, 3,
+	})
+}
+
+func isOrthonormal(q *Dense, tol float64) bool {
+	_, k := q.Dims()
+	var qtq Dense
+	qtq.TCopy(q)
+	qtq.Mul(&qtq, q)
+	id := NewDense(k, k, nil)
+	for i := 0; i < k; i++ {
+		id.Set(i, i, 1)
+	}
+	return qtq.EqualsApprox(id, tol)
+}
+
+func checkSchur(c *check.C, a *Dense, f SchurFactors, comment check.CommentInterface) {
+	n, _ := a.Dims()
+	var zt, rec Dense
+	zt.TCopy(f.Z)
+	rec.Mul(f.Z, f.T)
+	rec.Mul(&rec, &zt)
+	c.Check(rec.EqualsApprox(a, 1e-12), check.Equals, true, comment)
+	c.Check(isOrthonormal(f.Z, 1e-12), check.Equals, true, comment)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			c.Check(f.T.At(i, j), check.Equals, 0.0, comment)
+		}
+	}
+	for i := 0; i < n-2; i++ {
+		c.Check(f.T.At(i+1, i) == 0 || f.T.At(i+2, i+1) == 0, check.Equals, true, comment)
+	}
+}
+
+func (s *S) TestSchur(c *check.C) {
+	a := schurTestMatrix()
+	f := Schur(DenseCopyOf(a), epsilon)
+	checkSchur(c, a, f, check.Commentf("unordered"))
+
+	k := f.Select(func(re, im float64) bool { return re < 2 })
+	checkSchur(c, a, f, check.Commentf("ordered"))
+	for i := 0; i < 5; {
+		p := schurBlockSize(f.T, i)
+		re, _ := schurBlockEigenvalue(f.T, i, p)
+		c.Check(re < 2, check.Equals, i < k, check.Commentf("block at %d", i))
+		i += p
+	}
+
+	c.Check(func() { Schur(NewDense(2, 3, nil), epsilon) }, check.PanicMatches, string(ErrSquare))
+}
+
+func (s *S) TestInvariantSubspace(c *check.C) {
+	a := schurTestMatrix()
+	orig := DenseCopyOf(a)
+
+	ef := Eigen(DenseCopyOf(a), epsilon)
+	for _, sel := range []func(re, im float64) bool{
+		func(re, im float64) bool { return re > 2 },
+		func(re, im float64) bool { return im != 0 },
+		func(re, im float64) bool { return im == 0 },
+		func(re, im float64) bool { return false },
+		func(re, im float64) bool { return true },
+	} {
+		var want int
+		for i, d := range ef.d {
+			im := ef.e[i]
+			if sel(d, math.Abs(im)) {
+				want++
+			}
+		}
+
+		q := InvariantSubspace(a, sel)
+		c.Check(a.Equals(orig), check.Equals, true)
+		n, k := q.Dims()
+		c.Check(n, check.Equals, 5)
+		c.Check(k, check.Equals, want)
+		if k == 0 {
+			continue
+		}
+		c.Check(isOrthonormal(q, 1e-12), check.Equals, true)
+
+		// The subspace is invariant: a.Q = Q.(Q'.a.Q).
+		var qt, aq, b, qb Dense
+		qt.TCopy(q)
+		aq.Mul(a, q)
+		b.Mul(&qt, &aq)
+		qb.Mul(q, &b)
+		c.Check(aq.EqualsApprox(&qb, 1e-12), check.Equals, true)
+	}
+}
@@ -0,0 +1,561 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+var (
+	_ Matrix = Mat2{}
+	_ Matrix = Mat3{}
+	_ Matrix = Mat4{}
+)
+
+// Mat2, Mat3 and Mat4 are square matrices of fixed size held by value in
+// row-major order, so that m[i][j] is the element at row i and column j. They
+// are intended for geometric transforms and other code that performs many
+// operations on small matrices, where the determinant, inverse and eigenvalues
+// are given in closed form and no operation allocates.
+type (
+	Mat2 [2][2]float64
+	Mat3 [3][3]float64
+	Mat4 [4][4]float64
+)
+
+// checkSmall panics with ErrShape if a is not n-by-n.
+func checkSmall(a Matrix, n int) {
+	if r, c := a.Dims(); r != n || c != n {
+		panic(ErrShape)
+	}
+}
+
+// checkSmallAccess panics with ErrRowAccess or ErrColAccess if (r, c) is not
+// an element of an n-by-n matrix.
+func checkSmallAccess(r, c, n int) {
+	if r >= n || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= n || c < 0 {
+		panic(ErrColAccess)
+	}
+}
+
+// NewMat2 returns the elements of the 2-by-2 matrix a. NewMat2 will panic with
+// ErrShape if a is not 2-by-2.
+func NewMat2(a Matrix) Mat2 {
+	checkSmall(a, 2)
+	var m Mat2
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = a.At(i, j)
+		}
+	}
+	return m
+}
+
+func (m Mat2) Dims() (r, c int) { return 2, 2 }
+
+// At returns the element at row r and column c.
+func (m Mat2) At(r, c int) float64 {
+	checkSmallAccess(r, c, 2)
+	return m[r][c]
+}
+
+// Dense returns a newly allocated dense copy of the matrix.
+func (m Mat2) Dense() *Dense { return DenseCopyOf(m) }
+
+// T returns the transpose of the matrix.
+func (m Mat2) T() Mat2 {
+	return Mat2{
+		{m[0][0], m[1][0]},
+		{m[0][1], m[1][1]},
+	}
+}
+
+// Mul returns the matrix product m.b.
+func (m Mat2) Mul(b Mat2) Mat2 {
+	var p Mat2
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] = m[i][0]*b[0][j] + m[i][1]*b[1][j]
+		}
+	}
+	return p
+}
+
+// MulVec returns the product of the matrix with v.
+func (m Mat2) MulVec(v [2]float64) [2]float64 {
+	return [2]float64{
+		m[0][0]*v[0] + m[0][1]*v[1],
+		m[1][0]*v[0] + m[1][1]*v[1],
+	}
+}
+
+// Det returns the determinant of the matrix.
+func (m Mat2) Det() float64 {
+	return m[0][0]*m[1][1] - m[0][1]*m[1][0]
+}
+
+// Inverse returns the inverse of the matrix. Inverse will panic with
+// ErrSingular if the determinant is zero.
+func (m Mat2) Inverse() Mat2 {
+	det := m.Det()
+	if det == 0 {
+		panic(ErrSingular)
+	}
+	f := 1 / det
+	return Mat2{
+		{f * m[1][1], -f * m[0][1]},
+		{-f * m[1][0], f * m[0][0]},
+	}
+}
+
+// Eigenvalues returns the eigenvalues of the matrix, the roots of its
+// characteristic polynomial. Real eigenvalues are returned in ascending order,
+// and a complex conjugate pair with the negative imaginary part first.
+func (m Mat2) Eigenvalues() [2]complex128 {
+	var e [2]complex128
+	quadRoots(e[:], -(m[0][0] + m[1][1]), m.Det())
+	return e
+}
+
+// NewMat3 returns the elements of the 3-by-3 matrix a. NewMat3 will panic with
+// ErrShape if a is not 3-by-3.
+func NewMat3(a Matrix) Mat3 {
+	checkSmall(a, 3)
+	var m Mat3
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = a.At(i, j)
+		}
+	}
+	return m
+}
+
+func (m Mat3) Dims() (r, c int) { return 3, 3 }
+
+// At returns the element at row r and column c.
+func (m Mat3) At(r, c int) float64 {
+	checkSmallAccess(r, c, 3)
+	return m[r][c]
+}
+
+// Dense returns a newly allocated dense copy of the matrix.
+func (m Mat3) Dense() *Dense { return DenseCopyOf(m) }
+
+// T returns the transpose of the matrix.
+func (m Mat3) T() Mat3 {
+	var t Mat3
+	for i := range t {
+		for j := range t[i] {
+			t[i][j] = m[j][i]
+		}
+	}
+	return t
+}
+
+// Mul returns the matrix product m.b.
+func (m Mat3) Mul(b Mat3) Mat3 {
+	var p Mat3
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] = m[i][0]*b[0][j] + m[i][1]*b[1][j] + m[i][2]*b[2][j]
+		}
+	}
+	return p
+}
+
+// MulVec returns the product of the matrix with v.
+func (m Mat3) MulVec(v [3]float64) [3]float64 {
+	var p [3]float64
+	for i := range p {
+		p[i] = m[i][0]*v[0] + m[i][1]*v[1] + m[i][2]*v[2]
+	}
+	return p
+}
+
+// Det returns the determinant of the matrix.
+func (m Mat3) Det() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// Inverse returns the inverse of the matrix, the transposed matrix of
+// cofactors divided by the determinant. Inverse will panic with ErrSingular if
+// the determinant is zero.
+func (m Mat3) Inverse() Mat3 {
+	c := Mat3{
+		{
+			m[1][1]*m[2][2] - m[1][2]*m[2][1],
+			m[1][2]*m[2][0] - m[1][0]*m[2][2],
+			m[1][0]*m[2][1] - m[1][1]*m[2][0],
+		},
+		{
+			m[0][2]*m[2][1] - m[0][1]*m[2][2],
+			m[0][0]*m[2][2] - m[0][2]*m[2][0],
+			m[0][1]*m[2][0] - m[0][0]*m[2][1],
+		},
+		{
+			m[0][1]*m[1][2] - m[0][2]*m[1][1],
+			m[0][2]*m[1][0] - m[0][0]*m[1][2],
+			m[0][0]*m[1][1] - m[0][1]*m[1][0],
+		},
+	}
+	det := m[0][0]*c[0][0] + m[0][1]*c[0][1] + m[0][2]*c[0][2]
+	if det == 0 {
+		panic(ErrSingular)
+	}
+	f := 1 / det
+	var inv Mat3
+	for i := range inv {
+		for j := range inv[i] {
+			inv[i][j] = f * c[j][i]
+		}
+	}
+	return inv
+}
+
+// minors2 returns the sum of the principal 2-by-2 minors of the n-by-n matrix
+// whose elements are given by at.
+func minors2(n int, at func(i, j int) float64) float64 {
+	var s float64
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			s += at(i, i)*at(j, j) - at(i, j)*at(j, i)
+		}
+	}
+	return s
+}
+
+// Eigenvalues returns the eigenvalues of the matrix, the roots of its
+// characteristic polynomial found by Cardano's method. Real eigenvalues are
+// returned in ascending order followed by any complex conjugate pair, with the
+// negative imaginary part first.
+func (m Mat3) Eigenvalues() [3]complex128 {
+	var e [3]complex128
+	e2 := minors2(3, func(i, j int) float64 { return m[i][j] })
+	cubicRoots(e[:], -(m[0][0] + m[1][1] + m[2][2]), e2, -m.Det())
+	return e
+}
+
+// NewMat4 returns the elements of the 4-by-4 matrix a. NewMat4 will panic with
+// ErrShape if a is not 4-by-4.
+func NewMat4(a Matrix) Mat4 {
+	checkSmall(a, 4)
+	var m Mat4
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = a.At(i, j)
+		}
+	}
+	return m
+}
+
+func (m Mat4) Dims() (r, c int) { return 4, 4 }
+
+// At returns the element at row r and column c.
+func (m Mat4) At(r, c int) float64 {
+	checkSmallAccess(r, c, 4)
+	return m[r][c]
+}
+
+// Dense returns a newly allocated dense copy of the matrix.
+func (m Mat4) Dense() *Dense { return DenseCopyOf(m) }
+
+// T returns the transpose of the matrix.
+func (m Mat4) T() Mat4 {
+	var t Mat4
+	for i := range t {
+		for j := range t[i] {
+			t[i][j] = m[j][i]
+		}
+	}
+	return t
+}
+
+// Mul returns the matrix product m.b.
+func (m Mat4) Mul(b Mat4) Mat4 {
+	var p Mat4
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] = m[i][0]*b[0][j] + m[i][1]*b[1][j] + m[i][2]*b[2][j] + m[i][3]*b[3][j]
+		}
+	}
+	return p
+}
+
+// MulVec returns the product of the matrix with v.
+func (m Mat4) MulVec(v [4]float64) [4]float64 {
+	var p [4]float64
+	for i := range p {
+		p[i] = m[i][0]*v[0] + m[i][1]*v[1] + m[i][2]*v[2] + m[i][3]*v[3]
+	}
+	return p
+}
+
+// minors returns the 2-by-2 minors of the first two rows, s, and of the last
+// two rows, c, from which the determinant and inverse are formed by Laplace
+// expansion.
+func (m Mat4) minors() (s, c [6]float64) {
+	s = [6]float64{
+		m[0][0]*m[1][1] - m[1][0]*m[0][1],
+		m[0][0]*m[1][2] - m[1][0]*m[0][2],
+		m[0][0]*m[1][3] - m[1][0]*m[0][3],
+		m[0][1]*m[1][2] - m[1][1]*m[0][2],
+		m[0][1]*m[1][3] - m[1][1]*m[0][3],
+		m[0][2]*m[1][3] - m[1][2]*m[0][3],
+	}
+	c = [6]float64{
+		m[2][0]*m[3][1] - m[3][0]*m[2][1],
+		m[2][0]*m[3][2] - m[3][0]*m[2][2],
+		m[2][0]*m[3][3] - m[3][0]*m[2][3],
+		m[2][1]*m[3][2] - m[3][1]*m[2][2],
+		m[2][1]*m[3][3] - m[3][1]*m[2][3],
+		m[2][2]*m[3][3] - m[3][2]*m[2][3],
+	}
+	return s, c
+}
+
+// Det returns the determinant of the matrix.
+func (m Mat4) Det() float64 {
+	s, c := m.minors()
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// Inverse returns the inverse of the matrix, formed from the 2-by-2 minors of
+// its upper and lower halves. Inverse will panic with ErrSingular if the
+// determinant is zero.
+func (m Mat4) Inverse() Mat4 {
+	s, c := m.minors()
+	det := s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+	if det == 0 {
+		panic(ErrSingular)
+	}
+	f := 1 / det
+	return Mat4{
+		{
+			f * (m[1][1]*c[5] - m[1][2]*c[4] + m[1][3]*c[3]),
+			f * (-m[0][1]*c[5] + m[0][2]*c[4] - m[0][3]*c[3]),
+			f * (m[3][1]*s[5] - m[3][2]*s[4] + m[3][3]*s[3]),
+			f * (-m[2][1]*s[5] + m[2][2]*s[4] - m[2][3]*s[3]),
+		},
+		{
+			f * (-m[1][0]*c[5] + m[1][2]*c[2] - m[1][3]*c[1]),
+			f * (m[0][0]*c[5] - m[0][2]*c[2] + m[0][3]*c[1]),
+			f * (-m[3][0]*s[5] + m[3][2]*s[2] - m[3][3]*s[1]),
+			f * (m[2][0]*s[5] - m[2][2]*s[2] + m[2][3]*s[1]),
+		},
+		{
+			f * (m[1][0]*c[4] - m[1][1]*c[2] + m[1][3]*c[0]),
+			f * (-m[0][0]*c[4] + m[0][1]*c[2] - m[0][3]*c[0]),
+			f * (m[3][0]*s[4] - m[3][1]*s[2] + m[3][3]*s[0]),
+			f * (-m[2][0]*s[4] + m[2][1]*s[2] - m[2][3]*s[0]),
+		},
+		{
+			f * (-m[1][0]*c[3] + m[1][1]*c[1] - m[1][2]*c[0]),
+			f * (m[0][0]*c[3] - m[0][1]*c[1] + m[0][2]*c[0]),
+			f * (-m[3][0]*s[3] + m[3][1]*s[1] - m[3][2]*s[0]),
+			f * (m[2][0]*s[3] - m[2][1]*s[1] + m[2][2]*s[0]),
+		},
+	}
+}
+
+// Eigenvalues returns the eigenvalues of the matrix, the roots of its
+// characteristic polynomial found by Ferrari's method and refined by Newton's
+// method. Real eigenvalues are returned in ascending order followed by any
+// complex conjugate pairs, each with the negative imaginary part first.
+func (m Mat4) Eigenvalues() [4]complex128 {
+	// The coefficients of the characteristic polynomial are the sums of
+	// the principal minors of each order.
+	var e3 float64
+	for k := 0; k < 4; k++ {
+		var sub Mat3
+		for i, si := 0, 0; i < 4; i++ {
+			if i == k {
+				continue
+			}
+			for j, sj := 0, 0; j < 4; j++ {
+				if j == k {
+					continue
+				}
+				sub[si][sj] = m[i][j]
+				sj++
+			}
+			si++
+		}
+		e3 += sub.Det()
+	}
+	e2 := minors2(4, func(i, j int) float64 { return m[i][j] })
+	var e [4]complex128
+	quarticRoots(e[:], -(m[0][0] + m[1][1] + m[2][2] + m[3][3]), e2, -e3, m.Det())
+	return e
+}
+
+// quadRoots places the roots of x^2 + b*x + c in dst.
+func quadRoots(dst []complex128, b, c float64) {
+	disc := b*b - 4*c
+	if disc < 0 {
+		re, im := -b/2, math.Sqrt(-disc)/2
+		dst[0], dst[1] = complex(re, -im), complex(re, im)
+		return
+	}
+	// Avoid cancellation by forming the root of larger magnitude first.
+	q := -(b + math.Copysign(math.Sqrt(disc), b)) / 2
+	r := 0.0
+	if q != 0 {
+		r = c / q
+	}
+	dst[0], dst[1] = complex(math.Min(q, r), 0), complex(math.Max(q, r), 0)
+}
+
+// cubicRoots places the roots of x^3 + a*x^2 + b*x + c in dst.
+func cubicRoots(dst []complex128, a, b, c float64) {
+	// Substituting x = t - a/3 gives t^3 + p*t + q.
+	shift := a / 3
+	p := b - a*shift
+	q := 2*shift*shift*shift - b*shift + c
+	d := q*q/4 + p*p*p/27
+	if d < 0 {
+		// Three distinct real roots, given by the trigonometric form.
+		r := 2 * math.Sqrt(-p/3)
+		arg := 3 * q / (p * r)
+		theta := math.Acos(math.Max(-1, math.Min(1, arg))) / 3
+		roots := [3]float64{}
+		for k := range roots {
+			roots[k] = r*math.Cos(theta-2*math.Pi*float64(k)/3) - shift
+		}
+		if roots[0] > roots[1] {
+			roots[0], roots[1] = roots[1], roots[0]
+		}
+		if roots[1] > roots[2] {
+			roots[1], roots[2] = roots[2], roots[1]
+		}
+		if roots[0] > roots[1] {
+			roots[0], roots[1] = roots[1], roots[0]
+		}
+		for k, v := range roots {
+			dst[k] = complex(v, 0)
+		}
+		return
+	}
+
+	// One real root and a conjugate pair, or repeated real roots when the
+	// discriminant vanishes to working precision.
+	double := d <= 1e-14*(q*q/4+math.Abs(p*p*p)/27)
+	if double {
+		d = 0
+	}
+	u := math.Cbrt(-q/2 - math.Copysign(math.Sqrt(d), q))
+	v := 0.0
+	if u != 0 {
+		v = -p / (3 * u)
+	}
+	t := u + v
+	re, im := -t/2-shift, math.Sqrt(3)/2*math.Abs(u-v)
+	if double || im == 0 {
+		roots := [3]float64{t - shift, re, re}
+		if roots[0] > re {
+			roots[0], roots[2] = re, roots[0]
+		}
+		for k, v := range roots {
+			dst[k] = complex(v, 0)
+		}
+		return
+	}
+	dst[0], dst[1], dst[2] = complex(t-shift, 0), complex(re, -im), complex(re, im)
+}
+
+// quarticRoots places the roots of x^4 + a*x^3 + b*x^2 + c*x + d in dst.
+func quarticRoots(dst []complex128, a, b, c, d float64) {
+	// Substituting x = y - a/4 gives y^4 + p*y^2 + q*y + r.
+	shift := a / 4
+	sq := shift * shift
+	p := b - 6*sq
+	q := c - 2*b*shift + 8*sq*shift
+	r := d - c*shift + b*sq - 3*sq*sq
+
+	// Ferrari's method writes the quartic as a difference of squares,
+	//  (y^2 + p/2 + s^2/2)^2 - (s*y - q/(2*s))^2,
+	// where m = s^2/2 is a positive root of the resolvent cubic.
+	var res [3]complex128
+	cubicRoots(res[:], p, p*p/4-r, -q*q/8)
+	m := math.Inf(-1)
+	for _, v := range res {
+		if imag(v) == 0 && real(v) > m {
+			m = real(v)
+		}
+	}
+	// A positive root that is negligible relative to the coefficients is
+	// treated as zero, since the quadratic factors are then ill-determined.
+	var y [4]complex128
+	if m > 1e-10*(math.Abs(p)+math.Sqrt(math.Abs(r))) {
+		s := math.Sqrt(2 * m)
+		quadRoots(y[:2], -s, p/2+m+q/(2*s))
+		quadRoots(y[2:], s, p/2+m-q/(2*s))
+	} else {
+		// The quartic is biquadratic in y.
+		var z [2]complex128
+		quadRoots(z[:], p, r)
+		for k, v := range z {
+			w := cmplx.Sqrt(v)
+			y[2*k], y[2*k+1] = -w, w
+		}
+	}
+
+	// Refine each root by Newton's method on the original polynomial,
+	// stopping when a step does not reduce the residual.
+	poly := func(x complex128) complex128 {
+		return (((x+complex(a, 0))*x+complex(b, 0))*x+complex(c, 0))*x + complex(d, 0)
+	}
+	for k, v := range y {
+		x := v - complex(shift, 0)
+		f := poly(x)
+		for it := 0; it < 3 && f != 0; it++ {
+			df := ((4*x+complex(3*a, 0))*x+complex(2*b, 0))*x + complex(c, 0)
+			if df == 0 {
+				break
+			}
+			next := x - f/df
+			fn := poly(next)
+			if cmplx.Abs(fn) >= cmplx.Abs(f) {
+				break
+			}
+			x, f = next, fn
+		}
+		y[k] = x
+	}
+	sortEigenvalues(y[:])
+	copy(dst, y[:])
+}
+
+// sortEigenvalues orders roots of a real polynomial with the real roots
+// ascending first, followed by the complex roots in conjugate pairs ordered by
+// real part, each with the negative imaginary part first. Roots with an
+// imaginary part that is negligible relative to their magnitude are made real.
+func sortEigenvalues(x []complex128) {
+	for k, v := range x {
+		if math.Abs(imag(v)) <= 1e-12*cmplx.Abs(v) {
+			x[k] = complex(real(v), 0)
+		}
+	}
+	less := func(u, v complex128) bool {
+		ru, rv := imag(u) == 0, imag(v) == 0
+		if ru != rv {
+			return ru
+		}
+		if real(u) != real(v) {
+			return real(u) < real(v)
+		}
+		return imag(u) < imag(v)
+	}
+	for i := 1; i < len(x); i++ {
+		for j := i; j > 0 && less(x[j], x[j-1]); j-- {
+			x[j], x[j-1] = x[j-1], x[j]
+		}
+	}
+}
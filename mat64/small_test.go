@@ -0,0 +1,190 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sort"
+
+	check "launchpad.net/gocheck"
+)
+
+// sameEigenvalues returns whether the eigenvalues in got match those of a
+// computed by Eigen to within tol relative to the largest magnitude.
+func sameEigenvalues(got []complex128, a *Dense, tol float64) bool {
+	want := Eigen(DenseCopyOf(a), epsilon).Values()
+	var scale float64 = 1
+	for _, v := range want {
+		scale = math.Max(scale, cmplx.Abs(v))
+	}
+	used := make([]bool, len(want))
+	for _, g := range got {
+		found := false
+		for k, w := range want {
+			if !used[k] && cmplx.Abs(g-w) < tol*scale {
+				used[k], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func isSorted(e []complex128) bool {
+	var re []float64
+	for k, v := range e {
+		if imag(v) != 0 {
+			for _, w := range e[k:] {
+				if imag(w) == 0 {
+					return false
+				}
+			}
+			break
+		}
+		re = append(re, real(v))
+	}
+	return sort.Float64sAreSorted(re)
+}
+
+func (s *S) TestMat2(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		a := NewNormal(2, 2, 0, 1, rnd)
+		b := NewNormal(2, 2, 0, 1, rnd)
+		m, n := NewMat2(a), NewMat2(b)
+		c.Check(m.Dense().Equals(a), check.Equals, true)
+
+		var p Dense
+		p.Mul(a, b)
+		c.Check(m.Mul(n).Dense().EqualsApprox(&p, 1e-14), check.Equals, true)
+		var t Dense
+		t.TCopy(a)
+		c.Check(m.T().Dense().Equals(&t), check.Equals, true)
+		v := m.MulVec([2]float64{1, -2})
+		c.Check(math.Abs(v[1]-(a.At(1, 0)-2*a.At(1, 1))) < 1e-14, check.Equals, true)
+
+		c.Check(math.Abs(m.Det()-Det(a)) < 1e-12, check.Equals, true)
+		c.Check(m.Mul(m.Inverse()).Dense().EqualsApprox(identityDense(2), 1e-10), check.Equals, true)
+		e := m.Eigenvalues()
+		c.Check(sameEigenvalues(e[:], a, 1e-10), check.Equals, true)
+		c.Check(isSorted(e[:]), check.Equals, true)
+	}
+
+	e := Mat2{{0, -1}, {1, 0}}.Eigenvalues()
+	c.Check(e, check.Equals, [2]complex128{-1i, 1i})
+	e = Mat2{{3, 0}, {0, 3}}.Eigenvalues()
+	c.Check(e, check.Equals, [2]complex128{3, 3})
+
+	c.Check(func() { Mat2{{1, 2}, {2, 4}}.Inverse() }, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { NewMat2(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { Mat2{}.At(2, 0) }, check.PanicMatches, string(ErrRowAccess))
+	c.Check(func() { Mat2{}.At(0, -1) }, check.PanicMatches, string(ErrColAccess))
+}
+
+func (s *S) TestMat3(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		a := NewNormal(3, 3, 0, 1, rnd)
+		if i%2 == 0 {
+			Symmetrize(a)
+		}
+		b := NewNormal(3, 3, 0, 1, rnd)
+		m, n := NewMat3(a), NewMat3(b)
+		c.Check(m.Dense().Equals(a), check.Equals, true)
+
+		var p Dense
+		p.Mul(a, b)
+		c.Check(m.Mul(n).Dense().EqualsApprox(&p, 1e-14), check.Equals, true)
+		var t Dense
+		t.TCopy(a)
+		c.Check(m.T().Dense().Equals(&t), check.Equals, true)
+		v := m.MulVec([3]float64{1, 0, 2})
+		c.Check(math.Abs(v[2]-(a.At(2, 0)+2*a.At(2, 2))) < 1e-14, check.Equals, true)
+
+		c.Check(math.Abs(m.Det()-Det(a)) < 1e-12, check.Equals, true)
+		c.Check(m.Mul(m.Inverse()).Dense().EqualsApprox(eye(), 1e-10), check.Equals, true)
+		e := m.Eigenvalues()
+		c.Check(sameEigenvalues(e[:], a, 1e-9), check.Equals, true, check.Commentf("got %v", e))
+		c.Check(isSorted(e[:]), check.Equals, true)
+		if i%2 == 0 {
+			for _, v := range e {
+				c.Check(imag(v), check.Equals, 0.0)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		m    Mat3
+		want [3]complex128
+	}{
+		{Mat3{{2, 0, 0}, {0, 2, 0}, {0, 0, 2}}, [3]complex128{2, 2, 2}},
+		{Mat3{{1, 0, 0}, {0, 5, 0}, {0, 0, 1}}, [3]complex128{1, 1, 5}},
+		{Mat3{{4, 0, 0}, {0, 0, -2}, {0, 2, 0}}, [3]complex128{4, -2i, 2i}},
+	} {
+		e := test.m.Eigenvalues()
+		for k := range e {
+			c.Check(cmplx.Abs(e[k]-test.want[k]) < 1e-6, check.Equals, true, check.Commentf("got %v", e))
+		}
+	}
+
+	c.Check(func() { Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}.Inverse() }, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { NewMat3(NewDense(2, 2, nil)) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestMat4(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		a := NewNormal(4, 4, 0, 1, rnd)
+		if i%2 == 0 {
+			Symmetrize(a)
+		}
+		b := NewNormal(4, 4, 0, 1, rnd)
+		m, n := NewMat4(a), NewMat4(b)
+		c.Check(m.Dense().Equals(a), check.Equals, true)
+
+		var p Dense
+		p.Mul(a, b)
+		c.Check(m.Mul(n).Dense().EqualsApprox(&p, 1e-14), check.Equals, true)
+		var t Dense
+		t.TCopy(a)
+		c.Check(m.T().Dense().Equals(&t), check.Equals, true)
+		v := m.MulVec([4]float64{0, 1, 0, -1})
+		c.Check(math.Abs(v[0]-(a.At(0, 1)-a.At(0, 3))) < 1e-14, check.Equals, true)
+
+		c.Check(math.Abs(m.Det()-Det(a)) < 1e-12, check.Equals, true)
+		c.Check(m.Mul(m.Inverse()).Dense().EqualsApprox(identityDense(4), 1e-10), check.Equals, true)
+		e := m.Eigenvalues()
+		c.Check(sameEigenvalues(e[:], a, 1e-9), check.Equals, true, check.Commentf("got %v", e))
+		c.Check(isSorted(e[:]), check.Equals, true)
+		if i%2 == 0 {
+			for _, v := range e {
+				c.Check(imag(v), check.Equals, 0.0)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		m    Mat4
+		want [4]complex128
+	}{
+		{Mat4{{2, 0, 0, 0}, {0, 2, 0, 0}, {0, 0, 2, 0}, {0, 0, 0, 2}}, [4]complex128{2, 2, 2, 2}},
+		{Mat4{{1, 0, 0, 0}, {0, 2, 0, 0}, {0, 0, 3, 0}, {0, 0, 0, 4}}, [4]complex128{1, 2, 3, 4}},
+		{Mat4{{0, -1, 0, 0}, {1, 0, 0, 0}, {0, 0, 1, -2}, {0, 0, 2, 1}}, [4]complex128{-1i, 1i, 1 - 2i, 1 + 2i}},
+		{Mat4{{-1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 0, -1}, {0, 0, 1, 0}}, [4]complex128{-1, 1, -1i, 1i}},
+	} {
+		e := test.m.Eigenvalues()
+		for k := range e {
+			c.Check(cmplx.Abs(e[k]-test.want[k]) < 1e-6, check.Equals, true, check.Commentf("got %v", e))
+		}
+	}
+
+	c.Check(func() { Mat4{{1, 2, 3, 4}, {2, 4, 6, 8}}.Inverse() }, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { NewMat4(NewDense(4, 3, nil)) }, check.PanicMatches, string(ErrShape))
+}
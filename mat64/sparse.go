@@ -0,0 +1,141 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"sort"
+)
+
+var _ Matrix = (*Sparse)(nil)
+
+// Sparse is a general matrix held in compressed sparse row form. Elements that
+// are not stored are zero.
+type Sparse struct {
+	r, c   int
+	rowPtr []int
+	colIdx []int
+	vals   []float64
+}
+
+// NewRandomSparse returns a random r-by-c sparse matrix with round(density*r*c)
+// stored elements at positions chosen uniformly without replacement using src.
+// The values of the stored elements are set by successive calls to rnd in
+// row-major order, or are standard normal values drawn from src if rnd is nil.
+// For a random projection sketch, rnd may return ±1/sqrt(density) with equal
+// probability. NewRandomSparse will panic with ErrDistribution if density is not
+// in [0, 1].
+func NewRandomSparse(r, c int, density float64, rnd func() float64, src Source) *Sparse {
+	if !(density >= 0 && density <= 1) {
+		panic(ErrDistribution)
+	}
+	src = source(src)
+	if rnd == nil {
+		rnd = src.NormFloat64
+	}
+
+	// Choose the positions by Floyd's algorithm, which draws nnz
+	// distinct indices in O(nnz) time.
+	n := r * c
+	nnz := int(math.Floor(density*float64(n) + 0.5))
+	chosen := make(map[int]struct{}, nnz)
+	idx := make([]int, 0, nnz)
+	for j := n - nnz; j < n; j++ {
+		t := src.Intn(j + 1)
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		idx = append(idx, t)
+	}
+	sort.Ints(idx)
+
+	m := &Sparse{
+		r:      r,
+		c:      c,
+		rowPtr: make([]int, r+1),
+		colIdx: make([]int, nnz),
+		vals:   make([]float64, nnz),
+	}
+	for k, t := range idx {
+		m.rowPtr[t/c+1]++
+		m.colIdx[k] = t % c
+		m.vals[k] = rnd()
+	}
+	for i := 1; i <= r; i++ {
+		m.rowPtr[i] += m.rowPtr[i-1]
+	}
+	return m
+}
+
+func (m *Sparse) Dims() (r, c int) { return m.r, m.c }
+
+// At returns the element at row r and column c.
+func (m *Sparse) At(r, c int) float64 {
+	if r >= m.r || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= m.c || c < 0 {
+		panic(ErrColAccess)
+	}
+	cols := m.colIdx[m.rowPtr[r]:m.rowPtr[r+1]]
+	k := sort.SearchInts(cols, c)
+	if k < len(cols) && cols[k] == c {
+		return m.vals[m.rowPtr[r]+k]
+	}
+	return 0
+}
+
+// NNZ returns the number of stored elements.
+func (m *Sparse) NNZ() int { return len(m.vals) }
+
+// Row calls fn for each stored element of row r in increasing column order.
+func (m *Sparse) Row(r int, fn func(c int, v float64)) {
+	for k := m.rowPtr[r]; k < m.rowPtr[r+1]; k++ {
+		fn(m.colIdx[k], m.vals[k])
+	}
+}
+
+// MulVec sets dst to the product of the matrix with x and returns dst. If dst
+// is nil a new slice is allocated. MulVec will panic with ErrShape if x does not
+// have length equal to the number of columns, or a non-nil dst does not have
+// length equal to the number of rows.
+func (m *Sparse) MulVec(dst, x []float64) []float64 {
+	if len(x) != m.c {
+		panic(ErrShape)
+	}
+	if dst == nil {
+		dst = make([]float64, m.r)
+	} else if len(dst) != m.r {
+		panic(ErrShape)
+	}
+	for r := 0; r < m.r; r++ {
+		var s float64
+		for k := m.rowPtr[r]; k < m.rowPtr[r+1]; k++ {
+			s += m.vals[k] * x[m.colIdx[k]]
+		}
+		dst[r] = s
+	}
+	return dst
+}
+
+// Apply sets dst to the product of the matrix with x, as required by the
+// LinearOperator interface.
+func (m *Sparse) Apply(dst, x []float64) {
+	checkApply(m, dst, x)
+	m.MulVec(dst, x)
+}
+
+// Dense returns a newly allocated dense copy of the matrix.
+func (m *Sparse) Dense() *Dense {
+	d := NewDense(m.r, m.c, nil)
+	for r := 0; r < m.r; r++ {
+		row := d.rowView(r)
+		for k := m.rowPtr[r]; k < m.rowPtr[r+1]; k++ {
+			row[m.colIdx[k]] = m.vals[k]
+		}
+	}
+	return d
+}
@@ -0,0 +1,90 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestNewRandomSparse(c *check.C) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c    int
+		density float64
+	}{
+		{r: 10, c: 10, density: 0},
+		{r: 10, c: 10, density: 1},
+		{r: 30, c: 7, density: 0.1},
+		{r: 5, c: 40, density: 0.5},
+		{r: 0, c: 4, density: 0.5},
+		{r: 1000, c: 1000, density: 1e-3},
+	} {
+		m := NewRandomSparse(test.r, test.c, test.density, nil, src)
+		r, cols := m.Dims()
+		c.Check(r == test.r && cols == test.c, check.Equals, true)
+		nnz := int(math.Floor(test.density*float64(test.r*test.c) + 0.5))
+		c.Check(m.NNZ(), check.Equals, nnz)
+
+		d := m.Dense()
+		var count int
+		for i := 0; i < test.r; i++ {
+			last := -1
+			m.Row(i, func(j int, v float64) {
+				c.Check(j > last, check.Equals, true)
+				last = j
+				c.Check(v != 0, check.Equals, true)
+				count++
+			})
+			for j := 0; j < test.c; j++ {
+				c.Check(m.At(i, j), check.Equals, d.At(i, j))
+			}
+		}
+		c.Check(count, check.Equals, nnz)
+
+		x := make([]float64, test.c)
+		for i := range x {
+			x[i] = src.NormFloat64()
+		}
+		want := make([]float64, test.r)
+		for i := range want {
+			want[i] = dotUnitary(d.rowView(i), x)
+		}
+		got := m.MulVec(nil, x)
+		for i := range want {
+			c.Check(math.Abs(got[i]-want[i]) < 1e-12, check.Equals, true)
+		}
+	}
+
+	// Values come from rnd in row-major order.
+	var k float64
+	m := NewRandomSparse(4, 6, 0.5, func() float64 { k++; return k }, src)
+	want := 1.0
+	for i := 0; i < 4; i++ {
+		m.Row(i, func(_ int, v float64) {
+			c.Check(v, check.Equals, want)
+			want++
+		})
+	}
+
+	// Each position is equally likely.
+	hits := make([]int, 12)
+	const samples = 6000
+	for n := 0; n < samples; n++ {
+		m := NewRandomSparse(3, 4, 0.25, nil, src)
+		for i := 0; i < 3; i++ {
+			m.Row(i, func(j int, _ float64) { hits[4*i+j]++ })
+		}
+	}
+	for _, h := range hits {
+		c.Check(math.Abs(float64(h)/samples-0.25) < 0.03, check.Equals, true)
+	}
+
+	c.Check(func() { NewRandomSparse(3, 3, 1.5, nil, src) }, check.PanicMatches, string(ErrDistribution))
+	c.Check(func() { m.At(4, 0) }, check.PanicMatches, string(ErrRowAccess))
+	c.Check(func() { m.MulVec(nil, make([]float64, 3)) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,145 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// StaircaseFactors holds an orthogonal staircase form of the linear system
+// (a, b, c), where A = T'.a.T, B = T'.b and C = c.T for the orthogonal matrix T.
+//
+// For the controllability staircase form
+//
+//  A = [ Ac  A12 ]   B = [ Bc ]   C = [ Cc  Cu ]
+//      [ 0   Au  ]       [ 0  ]
+//
+// where Ac is of order Order and (Ac, Bc) is controllable. Ac is in upper block
+// Hessenberg form with diagonal blocks of the orders held in Blocks, and Bc has
+// nonzero elements only in its leading Blocks[0] rows.
+//
+// For the observability staircase form
+//
+//  A = [ Ao   0  ]   B = [ Bo ]   C = [ Co  0 ]
+//      [ A21  Au ]       [ Bu ]
+//
+// where Ao is of order Order and (Ao, Co) is observable. Ao is in lower block
+// Hessenberg form with diagonal blocks of the orders held in Blocks.
+type StaircaseFactors struct {
+	A, B, C *Dense
+	T       *Dense
+
+	Blocks []int
+	Order  int
+}
+
+// ControllabilityStaircase returns the controllability staircase form of the
+// system (a, b, c), separating the controllable subspace of (a, b) by a sequence
+// of rank revealing orthogonal transformations. Singular values not greater than
+// tol times the larger of the Frobenius norms of a and b are treated as zero. The
+// matrix c may be nil. None of the matrices is modified.
+func ControllabilityStaircase(a, b, c *Dense, tol float64) StaircaseFactors {
+	n, ac := a.Dims()
+	if n != ac {
+		panic(ErrSquare)
+	}
+	br, m := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if c != nil {
+		if _, cc := c.Dims(); cc != n {
+			panic(ErrShape)
+		}
+	}
+
+	f := StaircaseFactors{
+		A: DenseCopyOf(a),
+		B: DenseCopyOf(b),
+		T: identityDense(n),
+	}
+	thresh := tol * math.Max(a.Norm(0), b.Norm(0))
+
+	// The first stage compresses the rows of b, subsequent stages the
+	// subdiagonal block of a left by the previous stage.
+	var off, prev int
+	for off < n {
+		var blk Dense
+		if off == 0 {
+			blk.View(f.B, 0, 0, n, m)
+		} else {
+			blk.View(f.A, off, prev, n-off, off-prev)
+		}
+		u, sigma := fullLeftSingular(DenseCopyOf(&blk))
+		r := rankAbove(sigma, thresh)
+
+		if r > 0 {
+			var w Dense
+			w.View(f.A, off, 0, n-off, n)
+			transformRows(&w, u)
+			w.View(f.A, 0, off, n, n-off)
+			transformCols(&w, u)
+			w.View(f.B, off, 0, n-off, m)
+			transformRows(&w, u)
+			w.View(f.T, 0, off, n, n-off)
+			transformCols(&w, u)
+		}
+
+		// Clear the negligible rows of the compressed block.
+		for i := off + r; i < n; i++ {
+			if off == 0 {
+				zero(f.B.rowView(i))
+			} else {
+				zero(f.A.rowView(i)[prev:off])
+			}
+		}
+		if r == 0 {
+			// The remaining states are uncontrollable.
+			break
+		}
+
+		f.Blocks = append(f.Blocks, r)
+		prev = off
+		off += r
+	}
+	f.Order = off
+
+	if c != nil {
+		f.C = &Dense{}
+		f.C.Mul(c, f.T)
+	}
+	return f
+}
+
+// ObservabilityStaircase returns the observability staircase form of the system
+// (a, b, c), separating the observable subspace of (a, c). It is the dual of
+// ControllabilityStaircase applied to (a', c', b'), with tol used in the same
+// way. The matrix b may be nil. None of the matrices is modified.
+func ObservabilityStaircase(a, b, c *Dense, tol float64) StaircaseFactors {
+	var at, bt, ct Dense
+	at.TCopy(a)
+	ct.TCopy(c)
+	var btp *Dense
+	if b != nil {
+		bt.TCopy(b)
+		btp = &bt
+	}
+	d := ControllabilityStaircase(&at, &ct, btp, tol)
+
+	f := StaircaseFactors{
+		A:      &Dense{},
+		C:      &Dense{},
+		T:      d.T,
+		Blocks: d.Blocks,
+		Order:  d.Order,
+	}
+	f.A.TCopy(d.A)
+	f.C.TCopy(d.B)
+	if b != nil {
+		f.B = &Dense{}
+		f.B.TCopy(d.C)
+	}
+	return f
+}
@@ -0,0 +1,82 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+// staircaseSystem returns a system with a two dimensional controllable and
+// observable part hidden by an orthogonal change of basis.
+func staircaseSystem() (a, b, c *Dense) {
+	// In the original basis states 0 and 1 are controllable, state 2
+	// is not; states 0 and 2 are observable, state 1 is not.
+	a0 := NewDense(3, 3, []float64{
+		1, 0, 1,
+		1, 2, 0,
+		0, 0, 3,
+	})
+	b0 := NewDense(3, 1, []float64{1, 0, 0})
+	c0 := NewDense(1, 3, []float64{1, 0, 1})
+
+	// A rotation mixing all the states.
+	q := householderQ(NewDense(3, 1, []float64{1, 2, 2}))
+	var qt Dense
+	qt.TCopy(q)
+	a, b, c = &Dense{}, &Dense{}, &Dense{}
+	a.Mul(&qt, a0)
+	a.Mul(a, q)
+	b.Mul(&qt, b0)
+	c.Mul(c0, q)
+	return a, b, c
+}
+
+func checkStaircaseTransform(c *check.C, f StaircaseFactors, a, b, cm *Dense) {
+	var tt, m Dense
+	tt.TCopy(f.T)
+	c.Check(isOrthonormal(f.T, 1e-12), check.Equals, true)
+	m.Mul(&tt, a)
+	m.Mul(&m, f.T)
+	c.Check(m.EqualsApprox(f.A, 1e-12), check.Equals, true)
+	m.Reset()
+	m.Mul(&tt, b)
+	c.Check(m.EqualsApprox(f.B, 1e-12), check.Equals, true)
+	m.Reset()
+	m.Mul(cm, f.T)
+	c.Check(m.EqualsApprox(f.C, 1e-12), check.Equals, true)
+}
+
+func (s *S) TestControllabilityStaircase(c *check.C) {
+	a, b, cm := staircaseSystem()
+	f := ControllabilityStaircase(a, b, cm, 1e-12)
+	checkStaircaseTransform(c, f, a, b, cm)
+	c.Check(f.Order, check.Equals, 2)
+	c.Check(f.Blocks, check.DeepEquals, []int{1, 1})
+	for i := 1; i < 3; i++ {
+		c.Check(f.B.At(i, 0), check.Equals, 0.0)
+	}
+	c.Check(f.A.At(2, 0), check.Equals, 0.0)
+	c.Check(f.A.At(2, 1), check.Equals, 0.0)
+	c.Check(f.A.At(2, 2)-3 < 1e-12 && 3-f.A.At(2, 2) < 1e-12, check.Equals, true)
+
+	// A fully controllable system.
+	f = ControllabilityStaircase(eye(), eye(), nil, 1e-12)
+	c.Check(f.Order, check.Equals, 3)
+	c.Check(f.Blocks, check.DeepEquals, []int{3})
+	c.Check(f.C, check.IsNil)
+
+	c.Check(func() { ControllabilityStaircase(a, NewDense(2, 1, nil), nil, 1e-12) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestObservabilityStaircase(c *check.C) {
+	a, b, cm := staircaseSystem()
+	f := ObservabilityStaircase(a, b, cm, 1e-12)
+	checkStaircaseTransform(c, f, a, b, cm)
+	c.Check(f.Order, check.Equals, 2)
+	c.Check(f.C.At(0, 2), check.Equals, 0.0)
+	c.Check(f.A.At(0, 2), check.Equals, 0.0)
+	c.Check(f.A.At(1, 2), check.Equals, 0.0)
+	c.Check(f.A.At(2, 2)-2 < 1e-12 && 2-f.A.At(2, 2) < 1e-12, check.Equals, true)
+}
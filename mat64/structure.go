@@ -0,0 +1,117 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"strings"
+)
+
+// Structure describes the structure detected in a matrix by DetectStructure.
+type Structure struct {
+	// Rows and Cols are the dimensions of the matrix.
+	Rows, Cols int
+
+	// Lower and Upper are the lower and upper half-bandwidths: the
+	// greatest distances below and above the diagonal of an element
+	// that is not negligible. They are zero for a matrix with no such
+	// elements.
+	Lower, Upper int
+
+	// Symmetric is true if the matrix is square and equal to its
+	// transpose.
+	Symmetric bool
+
+	// Toeplitz is true if each diagonal of the matrix is constant.
+	Toeplitz bool
+}
+
+// Diagonal returns whether the matrix is diagonal.
+func (s Structure) Diagonal() bool { return s.Lower == 0 && s.Upper == 0 }
+
+// Tridiagonal returns whether the matrix is square with no negligible elements
+// more than one position from the diagonal. A diagonal matrix is tridiagonal.
+func (s Structure) Tridiagonal() bool {
+	return s.Rows == s.Cols && s.Lower <= 1 && s.Upper <= 1
+}
+
+// UpperTriangular returns whether the matrix has no non-negligible elements
+// below the diagonal.
+func (s Structure) UpperTriangular() bool { return s.Lower == 0 }
+
+// LowerTriangular returns whether the matrix has no non-negligible elements
+// above the diagonal.
+func (s Structure) LowerTriangular() bool { return s.Upper == 0 }
+
+// Banded returns whether the bandwidth of the matrix, Lower+Upper+1, is less
+// than the smaller of its dimensions, so that a banded representation saves
+// storage.
+func (s Structure) Banded() bool {
+	return s.Lower+s.Upper+1 < min(s.Rows, s.Cols)
+}
+
+// BandWidth returns the lower and upper half-bandwidths of the matrix.
+func (s Structure) BandWidth() (k1, k2 int) { return s.Lower, s.Upper }
+
+func (s Structure) String() string {
+	var kinds []string
+	switch {
+	case s.Diagonal():
+		kinds = append(kinds, "diagonal")
+	case s.Tridiagonal():
+		kinds = append(kinds, "tridiagonal")
+	case s.UpperTriangular():
+		kinds = append(kinds, "upper triangular")
+	case s.LowerTriangular():
+		kinds = append(kinds, "lower triangular")
+	case s.Banded():
+		kinds = append(kinds, "banded")
+	}
+	if s.Symmetric {
+		kinds = append(kinds, "symmetric")
+	}
+	if s.Toeplitz {
+		kinds = append(kinds, "Toeplitz")
+	}
+	if kinds == nil {
+		return "general"
+	}
+	return strings.Join(kinds, " ")
+}
+
+var _ BandWidther = Structure{}
+
+// DetectStructure inspects the elements of a and returns its structure, so that
+// a generic computation can be routed to a specialized representation or
+// solver. Elements with magnitude not greater than tol are negligible, and two
+// elements are equal if they differ by no more than tol.
+func DetectStructure(a *Dense, tol float64) Structure {
+	r, c := a.Dims()
+	s := Structure{
+		Rows:      r,
+		Cols:      c,
+		Symmetric: r == c,
+		Toeplitz:  true,
+	}
+	for i := 0; i < r; i++ {
+		row := a.rowView(i)
+		for j, v := range row {
+			if math.Abs(v) > tol {
+				if j < i {
+					s.Lower = max(s.Lower, i-j)
+				} else {
+					s.Upper = max(s.Upper, j-i)
+				}
+			}
+			if s.Symmetric && j < i && math.Abs(v-a.at(j, i)) > tol {
+				s.Symmetric = false
+			}
+			if s.Toeplitz && i > 0 && j > 0 && math.Abs(v-a.at(i-1, j-1)) > tol {
+				s.Toeplitz = false
+			}
+		}
+	}
+	return s
+}
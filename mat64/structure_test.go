@@ -0,0 +1,96 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestDetectStructure(c *check.C) {
+	for i, test := range []struct {
+		a            *Dense
+		tol          float64
+		lower, upper int
+		sym, toep    bool
+		diag, tri    bool
+		banded       bool
+		str          string
+	}{
+		{
+			a:   NewDense(3, 3, []float64{2, 0, 0, 0, 2, 0, 0, 0, 2}),
+			sym: true, toep: true, diag: true, tri: true, banded: true,
+			str: "diagonal symmetric Toeplitz",
+		},
+		{
+			a:   NewDense(3, 3, []float64{1, 0, 0, 0, 2, 0, 0, 0, 3}),
+			sym: true, diag: true, tri: true, banded: true,
+			str: "diagonal symmetric",
+		},
+		{
+			a:     NewDense(4, 4, []float64{2, -1, 0, 0, -1, 2, -1, 0, 0, -1, 2, -1, 0, 0, -1, 2}),
+			lower: 1, upper: 1, sym: true, toep: true, tri: true, banded: true,
+			str: "tridiagonal symmetric Toeplitz",
+		},
+		{
+			a:     NewDense(3, 3, []float64{1, 2, 3, 0, 4, 5, 0, 0, 6}),
+			upper: 2,
+			str:   "upper triangular",
+		},
+		{
+			a:     NewDense(3, 3, []float64{1, 0, 0, 2, 4, 0, 3, 5, 6}),
+			lower: 2,
+			str:   "lower triangular",
+		},
+		{
+			a: NewDense(5, 5, []float64{
+				1, 2, 0, 0, 0,
+				3, 1, 2, 0, 0,
+				4, 3, 1, 2, 0,
+				0, 4, 3, 1, 2,
+				0, 0, 4, 3, 1,
+			}),
+			lower: 2, upper: 1, toep: true, banded: true,
+			str: "banded Toeplitz",
+		},
+		{
+			a:     NewDense(2, 3, []float64{1, 2, 3, 4, 1, 2}),
+			lower: 1, upper: 2, toep: true,
+			str: "Toeplitz",
+		},
+		{
+			a:     NewDense(2, 2, []float64{1, 2, 2.5, 3}),
+			lower: 1, upper: 1, tri: true,
+			str: "tridiagonal",
+		},
+		{
+			a:   NewDense(2, 2, []float64{1, 1e-12, 2e-12, 1}),
+			tol: 1e-10,
+			sym: true, toep: true, diag: true, tri: true, banded: true,
+			str: "diagonal symmetric Toeplitz",
+		},
+		{
+			a:     NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 0}),
+			lower: 2, upper: 2,
+			str: "general",
+		},
+	} {
+		st := DetectStructure(test.a, test.tol)
+		comment := check.Commentf("test %d", i)
+		r, cols := test.a.Dims()
+		c.Check(st.Rows, check.Equals, r, comment)
+		c.Check(st.Cols, check.Equals, cols, comment)
+		k1, k2 := st.BandWidth()
+		c.Check(k1, check.Equals, test.lower, comment)
+		c.Check(k2, check.Equals, test.upper, comment)
+		c.Check(st.Symmetric, check.Equals, test.sym, comment)
+		c.Check(st.Toeplitz, check.Equals, test.toep, comment)
+		c.Check(st.Diagonal(), check.Equals, test.diag, comment)
+		c.Check(st.Tridiagonal(), check.Equals, test.tri, comment)
+		c.Check(st.Banded(), check.Equals, test.banded, comment)
+		c.Check(st.UpperTriangular(), check.Equals, test.lower == 0, comment)
+		c.Check(st.LowerTriangular(), check.Equals, test.upper == 0, comment)
+		c.Check(st.String(), check.Equals, test.str, comment)
+	}
+}
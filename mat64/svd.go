@@ -29,8 +29,25 @@ type SVDFactors struct {
 //
 // The matrix condition number and the effective numerical rank can be computed from
 // this decomposition.
+//
+// If the registered LAPACK backend implements LapackSVD, the decomposition is
+// computed by its Dgesvd and epsilon and small are not used.
 func SVD(a *Dense, epsilon, small float64, wantu, wantv bool) SVDFactors {
+	return svd(a, epsilon, small, wantu, wantv, nil)
+}
+
+// svd implements SVD, abandoning the iteration by panicking with canceled if
+// done is closed.
+func svd(a *Dense, epsilon, small float64, wantu, wantv bool, done <-chan struct{}) SVDFactors {
 	m, n := a.Dims()
+	if p := startProfile(); p != nil {
+		defer p.stop("SVD", svdFlops(m, n, wantu || wantv))
+	}
+	checkFiniteDense("SVD", 0, a)
+
+	if l, ok := lapackEngine.(LapackSVD); ok {
+		return svdLapack(l, a, wantu, wantv)
+	}
 
 	trans := false
 	if m < n {
@@ -224,10 +241,14 @@ func SVD(a *Dense, epsilon, small float64, wantu, wantv bool) SVDFactors {
 
 	// Main iteration loop for the singular values.
 	pp := p - 1
+	var sweeps int
 	for iter := 0; p > 0; {
 		var k, kase int
 
 		// Here is where a test for too many iterations would go.
+		checkFiniteSlice("SVD", iter, "sigma", sigma)
+		checkFiniteSlice("SVD", iter, "e", e)
+		checkDone(done)
 
 		// This section of the program inspects for
 		// negligible elements in the sigma and e arrays.  On
@@ -385,6 +406,10 @@ func SVD(a *Dense, epsilon, small float64, wantu, wantv bool) SVDFactors {
 			}
 			e[p-2] = f
 			iter++
+			sweeps++
+			if progress != nil {
+				progress("SVD", sweeps, Vec(e[:p-1]).Norm(2))
+			}
 
 		// Convergence.
 		case 4:
@@ -451,8 +476,8 @@ func SVD(a *Dense, epsilon, small float64, wantu, wantv bool) SVDFactors {
 // factorisation.
 func (f SVDFactors) S() *Dense {
 	s := NewDense(len(f.Sigma), len(f.Sigma), nil)
-	for i, v := range f.Sigma {
-		s.Set(i, i, v)
+	if len(f.Sigma) != 0 {
+		s.SetDiag(0, f.Sigma)
 	}
 	return s
 }
@@ -198,4 +198,9 @@ func (s *S) TestSVD(c *check.C) {
 			c.Check(svd.U.EqualsApprox(t.a, 1e-12), check.Equals, true)
 		}
 	}
+
+	// A factorisation with no singular values has an empty S.
+	r, cols := SVDFactors{}.S().Dims()
+	c.Check(r, check.Equals, 0)
+	c.Check(cols, check.Equals, 0)
 }
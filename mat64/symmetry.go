@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// Symmetrize replaces the square matrix a with its symmetric part (a + a')/2,
+// removing the rounding asymmetry of products such as a'.a so that routines
+// testing for exact symmetry, such as Eigen, take their symmetric path.
+// Symmetrize will panic with ErrSquare if a is not square.
+func Symmetrize(a *Dense) {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			v := (a.at(i, j) + a.at(j, i)) / 2
+			a.Set(i, j, v)
+			a.Set(j, i, v)
+		}
+	}
+}
+
+// Asymmetry returns the largest magnitude of the difference a[i,j] - a[j,i]
+// relative to the largest magnitude of an element of the square matrix a, or
+// zero if a is zero. Asymmetry will panic with ErrSquare if a is not square.
+func Asymmetry(a Matrix) float64 {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	var diff, norm float64
+	for i := 0; i < n; i++ {
+		norm = math.Max(norm, math.Abs(a.At(i, i)))
+		for j := 0; j < i; j++ {
+			aij, aji := a.At(i, j), a.At(j, i)
+			diff = math.Max(diff, math.Abs(aij-aji))
+			norm = math.Max(norm, math.Max(math.Abs(aij), math.Abs(aji)))
+		}
+	}
+	if norm == 0 {
+		return 0
+	}
+	return diff / norm
+}
+
+// IsSymmetric reports whether a is square and symmetric to within the relative
+// tolerance tol, that is whether Asymmetry(a) <= tol. With tol zero the test is
+// for exact symmetry.
+func IsSymmetric(a Matrix, tol float64) bool {
+	if r, c := a.Dims(); r != c {
+		return false
+	}
+	return Asymmetry(a) <= tol
+}
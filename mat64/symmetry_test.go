@@ -0,0 +1,42 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSymmetry(c *check.C) {
+	a := NewDense(3, 3, []float64{
+		4, 1, 2,
+		1.5, 5, 3,
+		2, 3, 8,
+	})
+	c.Check(Asymmetry(a), check.Equals, 0.5/8)
+	c.Check(IsSymmetric(a, 0), check.Equals, false)
+	c.Check(IsSymmetric(a, 0.1), check.Equals, true)
+	c.Check(IsSymmetric(a, 0.01), check.Equals, false)
+
+	Symmetrize(a)
+	c.Check(a.Equals(NewDense(3, 3, []float64{
+		4, 1.25, 2,
+		1.25, 5, 3,
+		2, 3, 8,
+	})), check.Equals, true)
+	c.Check(Asymmetry(a), check.Equals, 0.0)
+	c.Check(IsSymmetric(a, 0), check.Equals, true)
+	c.Check(symmetric(a), check.Equals, true)
+
+	// Rounding asymmetry is within a small tolerance.
+	x, y := 0.1, 0.2
+	b := NewDense(2, 2, []float64{1, x + y, 0.3, 1})
+	c.Check(IsSymmetric(b, 0), check.Equals, false)
+	c.Check(IsSymmetric(b, 1e-15), check.Equals, true)
+
+	c.Check(Asymmetry(NewDense(2, 2, nil)), check.Equals, 0.0)
+	c.Check(IsSymmetric(NewDense(2, 3, nil), 1), check.Equals, false)
+	c.Check(func() { Asymmetry(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrSquare))
+	c.Check(func() { Symmetrize(NewDense(2, 3, nil)) }, check.PanicMatches, string(ErrSquare))
+}
@@ -0,0 +1,112 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import "sync"
+
+var (
+	syncDense *SyncDense
+
+	_ Matrix       = syncDense
+	_ Mutable      = syncDense
+	_ Vectorer     = syncDense
+	_ VectorSetter = syncDense
+)
+
+// SyncDense is a dense matrix that is safe for concurrent use. Any number of
+// goroutines may read the matrix at the same time, while a write excludes all
+// other readers and writers.
+//
+// The element methods lock the matrix for each call, so a sequence of calls may
+// observe the writes of other goroutines in between. Operations that must see or
+// leave the matrix in a consistent state, such as using it as an operand or
+// receiver of a Dense method, are performed within Read or Write, which hold the
+// lock for their duration.
+type SyncDense struct {
+	mu  sync.RWMutex
+	mat *Dense
+}
+
+// NewSyncDense returns a SyncDense holding m. The SyncDense takes ownership of
+// m, which, together with any view sharing its storage, must not be used other
+// than through the SyncDense after the call.
+func NewSyncDense(m *Dense) *SyncDense {
+	return &SyncDense{mat: m}
+}
+
+func (s *SyncDense) Dims() (r, c int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mat.Dims()
+}
+
+// At returns the element at row r and column c.
+func (s *SyncDense) At(r, c int) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mat.At(r, c)
+}
+
+// Set sets the element at row r and column c to v.
+func (s *SyncDense) Set(r, c int, v float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mat.Set(r, c, v)
+}
+
+// Row copies the elements of row r into row as for Dense.Row.
+func (s *SyncDense) Row(row []float64, r int) []float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mat.Row(row, r)
+}
+
+// Col copies the elements of column c into col as for Dense.Col.
+func (s *SyncDense) Col(col []float64, c int) []float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mat.Col(col, c)
+}
+
+// SetRow sets the elements of row r as for Dense.SetRow.
+func (s *SyncDense) SetRow(r int, row []float64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mat.SetRow(r, row)
+}
+
+// SetCol sets the elements of column c as for Dense.SetCol.
+func (s *SyncDense) SetCol(c int, col []float64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mat.SetCol(c, col)
+}
+
+// Read calls fn with the underlying matrix while holding a read lock, so that fn
+// sees a consistent matrix that no goroutine modifies until fn returns. fn must
+// not modify the matrix, retain it after returning, or call the methods of s.
+func (s *SyncDense) Read(fn func(m *Dense)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.mat)
+}
+
+// Write calls fn with the underlying matrix while holding the write lock, so
+// that no other goroutine reads or writes the matrix until fn returns. fn may
+// modify the matrix in place or use it as the receiver of a Dense method,
+// including one that reallocates it, but must not retain it after returning or
+// call the methods of s.
+func (s *SyncDense) Write(fn func(m *Dense)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.mat)
+}
+
+// Snapshot returns a newly allocated copy of the matrix taken under a read lock.
+func (s *SyncDense) Snapshot() *Dense {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return DenseCopyOf(s.mat)
+}
@@ -0,0 +1,80 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"sync"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSyncDense(c *check.C) {
+	m := NewSyncDense(NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}))
+	r, cols := m.Dims()
+	c.Check(r == 2 && cols == 3, check.Equals, true)
+	c.Check(m.At(1, 2), check.Equals, 6.0)
+	m.Set(0, 0, 7)
+	c.Check(m.Row(nil, 0), check.DeepEquals, []float64{7, 2, 3})
+	c.Check(m.SetRow(1, []float64{8, 9, 10}), check.Equals, 3)
+	c.Check(m.Col(nil, 2), check.DeepEquals, []float64{3, 10})
+	c.Check(m.SetCol(1, []float64{-1, -2}), check.Equals, 2)
+	snap := m.Snapshot()
+	c.Check(snap.Equals(NewDense(2, 3, []float64{7, -1, 3, 8, -2, 10})), check.Equals, true)
+	m.Set(0, 0, 0)
+	c.Check(snap.At(0, 0), check.Equals, 7.0)
+
+	// Write may reshape the matrix.
+	m.Write(func(d *Dense) { d.Mul(d, NewDense(3, 1, []float64{1, 1, 1})) })
+	r, cols = m.Dims()
+	c.Check(r == 2 && cols == 1, check.Equals, true)
+	m.Read(func(d *Dense) {
+		c.Check(d.At(0, 0), check.Equals, 2.0)
+		c.Check(d.At(1, 0), check.Equals, 16.0)
+	})
+	c.Check(func() { m.At(2, 0) }, check.PanicMatches, string(ErrRowAccess))
+
+	// Readers never observe a partially completed write, in which the
+	// elements would differ.
+	m = NewSyncDense(NewDense(20, 20, nil))
+	var wg sync.WaitGroup
+	torn := make(chan bool, 1)
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(v float64) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Write(func(d *Dense) {
+					for r := 0; r < 20; r++ {
+						row := d.RowView(r)
+						for j := range row {
+							row[j] = v
+						}
+					}
+				})
+			}
+		}(float64(w))
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Read(func(d *Dense) {
+					v := d.At(0, 0)
+					for r := 0; r < 20; r++ {
+						for _, e := range d.RowView(r) {
+							if e != v {
+								select {
+								case torn <- true:
+								default:
+								}
+								return
+							}
+						}
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	c.Check(len(torn), check.Equals, 0)
+}
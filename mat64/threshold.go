@@ -0,0 +1,69 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+const (
+	ErrThreshold     = Error("mat64: negative threshold")
+	ErrThresholdMode = Error("mat64: invalid threshold mode")
+)
+
+// ThresholdMode specifies how SVThreshold treats the singular values.
+type ThresholdMode int
+
+const (
+	// SoftThreshold shrinks each singular value toward zero by tau,
+	// setting those not greater than tau to zero. This is the proximal
+	// operator of tau times the nuclear norm.
+	SoftThreshold ThresholdMode = iota
+
+	// HardThreshold sets the singular values not greater than tau to zero
+	// and leaves the others unchanged. This is the projection onto the
+	// matrices of the resulting rank nearest in Frobenius norm.
+	HardThreshold
+)
+
+// SVThreshold returns U.diag(f(sigma)).V' where a = U.diag(sigma).V' is the
+// singular value decomposition of a and f applies the thresholding mode with
+// threshold tau to each singular value. It also returns the number of singular
+// values that remain non-zero. SVThreshold will panic with ErrThreshold if tau
+// is negative and with ErrThresholdMode if mode is not a valid ThresholdMode. The matrix a is not modified.
+func SVThreshold(a *Dense, tau float64, mode ThresholdMode) (*Dense, int) {
+	if tau < 0 {
+		panic(ErrThreshold)
+	}
+	if mode != SoftThreshold && mode != HardThreshold {
+		panic(ErrThresholdMode)
+	}
+
+	m, n := a.Dims()
+	svd := SVD(DenseCopyOf(a), epsilon, small, true, true)
+
+	// Form U.diag(f(sigma)) over the retained singular values only.
+	var r int
+	for _, s := range svd.Sigma {
+		if s > tau {
+			r++
+		}
+	}
+	if r == 0 {
+		return NewDense(m, n, nil), 0
+	}
+	us := NewDense(m, r, nil)
+	for i := 0; i < m; i++ {
+		row := us.rowView(i)
+		for j := range row {
+			s := svd.Sigma[j]
+			if mode == SoftThreshold {
+				s -= tau
+			}
+			row[j] = svd.U.At(i, j) * s
+		}
+	}
+	var v, vt, t Dense
+	v.View(svd.V, 0, 0, n, r)
+	vt.TCopy(&v)
+	t.Mul(us, &vt)
+	return &t, r
+}
@@ -0,0 +1,72 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestSVThreshold(c *check.C) {
+	// Build a 4x3 matrix with known singular values 5, 2 and 0.5.
+	u := householderQ(NewDense(4, 3, []float64{
+		1, 2, 0,
+		-1, 1, 3,
+		2, 0, 1,
+		0, 1, -1,
+	}))
+	var u3 Dense
+	u3.Submatrix(u, 0, 0, 4, 3)
+	v := householderQ(NewDense(3, 3, []float64{
+		2, 1, 0,
+		1, -1, 1,
+		0, 1, 3,
+	}))
+	build := func(sigma []float64) *Dense {
+		us := DenseCopyOf(&u3)
+		for i := 0; i < 4; i++ {
+			for j := range sigma {
+				us.Set(i, j, us.At(i, j)*sigma[j])
+			}
+		}
+		var vt, a Dense
+		vt.TCopy(v)
+		a.Mul(us, &vt)
+		return &a
+	}
+	a := build([]float64{5, 2, 0.5})
+	orig := DenseCopyOf(a)
+
+	for _, test := range []struct {
+		tau   float64
+		mode  ThresholdMode
+		sigma []float64
+		rank  int
+	}{
+		{0, SoftThreshold, []float64{5, 2, 0.5}, 3},
+		{1, SoftThreshold, []float64{4, 1, 0}, 2},
+		{1, HardThreshold, []float64{5, 2, 0}, 2},
+		{2.5, HardThreshold, []float64{5, 0, 0}, 1},
+		{3, SoftThreshold, []float64{2, 0, 0}, 1},
+		{6, SoftThreshold, []float64{0, 0, 0}, 0},
+	} {
+		got, rank := SVThreshold(a, test.tau, test.mode)
+		c.Check(a.Equals(orig), check.Equals, true)
+		c.Check(rank, check.Equals, test.rank, check.Commentf("tau=%v mode=%v", test.tau, test.mode))
+		c.Check(got.EqualsApprox(build(test.sigma), 1e-12), check.Equals, true, check.Commentf("tau=%v mode=%v", test.tau, test.mode))
+	}
+
+	// Soft thresholding is the proximal operator of the nuclear norm, so
+	// the residual has spectral norm at most tau.
+	got, _ := SVThreshold(a, 1, SoftThreshold)
+	var res Dense
+	res.Sub(a, got)
+	c.Check(res.Norm(2) <= 1+1e-12, check.Equals, true)
+	c.Check(math.Abs(res.Norm(2)-1) < 1e-12, check.Equals, true)
+
+	c.Check(func() { SVThreshold(a, -1, SoftThreshold) }, check.PanicMatches, string(ErrThreshold))
+	c.Check(func() { SVThreshold(a, 1, ThresholdMode(5)) }, check.PanicMatches, string(ErrThresholdMode))
+}
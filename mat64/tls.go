@@ -0,0 +1,62 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// TotalLeastSquares returns the total least squares solution x of a.x ≈ b, for
+// errors-in-variables regression where both a and b are subject to error, and the
+// correction [da db] of smallest Frobenius norm such that (a+da).x = b+db
+// exactly. The m-by-n matrix a and m-by-k matrix b are not modified, and each
+// column of b is a right hand side.
+//
+// The solution is found from the singular value decomposition of [a b] as
+// described in Golub and Van Loan, "Matrix Computations", section 12.3. If
+// [a b] = U.S.V' and V2 holds the last k columns of V, partitioned as
+// [V12; V22], then x = -V12.V22^-1 and the correction is -[a b].V2.V2'.
+//
+// TotalLeastSquares will panic with ErrShape if a and b do not have the same
+// number of rows or m < n+k, and with ErrSingular if V22 is singular, in which
+// case the problem has no total least squares solution.
+func TotalLeastSquares(a, b *Dense) (x, correction *Dense) {
+	m, n := a.Dims()
+	bm, k := b.Dims()
+	if bm != m || m < n+k {
+		panic(ErrShape)
+	}
+
+	ab := NewDense(m, n+k, nil)
+	ab.Copy(a)
+	var bv Dense
+	bv.View(ab, 0, n, m, k)
+	bv.Copy(b)
+	orig := DenseCopyOf(ab)
+
+	f := SVD(ab, epsilon, small, false, true)
+	var v2, v12, v22 Dense
+	v2.View(f.V, 0, n, n+k, k)
+	v12.View(f.V, 0, n, n, k)
+	v22.View(f.V, n, n, k, k)
+
+	// x = -V12.V22^-1, found as the solution of V22'.x' = -V12'.
+	var v22t, v12t Dense
+	v22t.TCopy(&v22)
+	v12t.TCopy(&v12)
+	v12t.Scale(-1, &v12t)
+	lu := LU(&v22t)
+	if lu.IsSingular() {
+		panic(ErrSingular)
+	}
+	xt := lu.Solve(&v12t)
+	x = NewDense(n, k, nil)
+	x.TCopy(xt)
+
+	// correction = -[a b].V2.V2'
+	var abv2, v2t Dense
+	abv2.Mul(orig, &v2)
+	v2t.TCopy(&v2)
+	correction = NewDense(m, n+k, nil)
+	correction.Mul(&abv2, &v2t)
+	correction.Scale(-1, correction)
+	return x, correction
+}
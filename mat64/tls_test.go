@@ -0,0 +1,60 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+	"math"
+	"math/rand"
+)
+
+func (s *S) TestTotalLeastSquares(c *check.C) {
+	// Points on the line y = 2x are fitted exactly.
+	a := NewDense(4, 1, []float64{1, 2, 3, 4})
+	b := NewDense(4, 1, []float64{2, 4, 6, 8})
+	x, corr := TotalLeastSquares(a, b)
+	c.Check(math.Abs(x.At(0, 0)-2) < 1e-12, check.Equals, true)
+	c.Check(corr.EqualsApprox(NewDense(4, 2, nil), 1e-12), check.Equals, true)
+
+	// For a single regressor through the origin TLS is orthogonal
+	// regression: the slope is that of the principal axis of the points.
+	a = NewDense(3, 1, []float64{1, 2, 3})
+	b = NewDense(3, 1, []float64{1, 3, 2})
+	x, corr = TotalLeastSquares(a, b)
+	sxx, syy, sxy := 14.0, 14.0, 13.0
+	want := (syy - sxx + math.Sqrt((syy-sxx)*(syy-sxx)+4*sxy*sxy)) / (2 * sxy)
+	c.Check(math.Abs(x.At(0, 0)-want) < 1e-12, check.Equals, true, check.Commentf("got %v want %v", x.At(0, 0), want))
+
+	// The corrected data satisfy the system exactly for random problems
+	// with several right hand sides.
+	rnd := rand.New(rand.NewSource(1))
+	a = NewDense(10, 3, nil)
+	b = NewDense(10, 2, nil)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 3; j++ {
+			a.Set(i, j, rnd.NormFloat64())
+		}
+		for j := 0; j < 2; j++ {
+			b.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	origA, origB := DenseCopyOf(a), DenseCopyOf(b)
+	x, corr = TotalLeastSquares(a, b)
+	c.Check(a.Equals(origA) && b.Equals(origB), check.Equals, true)
+	var da, db, ax Dense
+	da.View(corr, 0, 0, 10, 3)
+	db.View(corr, 0, 3, 10, 2)
+	da.Add(&da, a)
+	db.Add(&db, b)
+	ax.Mul(&da, x)
+	c.Check(ax.EqualsApprox(&db, 1e-10), check.Equals, true)
+
+	c.Check(func() { TotalLeastSquares(NewDense(2, 2, nil), NewDense(2, 1, nil)) }, check.PanicMatches, string(ErrShape))
+
+	// With a zero regressor no correction of a can explain b.
+	c.Check(func() {
+		TotalLeastSquares(NewDense(3, 1, nil), NewDense(3, 1, []float64{1, 2, 3}))
+	}, check.PanicMatches, string(ErrSingular))
+}
@@ -0,0 +1,393 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+var (
+	_ Matrix = (*Toeplitz)(nil)
+	_ Matrix = (*Circulant)(nil)
+)
+
+// Toeplitz is a matrix that is constant along each diagonal, held as its first
+// column and first row. Products with vectors are computed in O(n log n) time
+// by embedding the matrix in a circulant matrix and using the fast Fourier
+// transform.
+type Toeplitz struct {
+	c, r []float64
+
+	// spec is the discrete Fourier transform of the first column of
+	// the embedding circulant matrix.
+	spec []complex128
+}
+
+// NewToeplitz returns the len(c)-by-len(r) Toeplitz matrix with first column c
+// and first row r. The diagonal is taken from c[0] and r[0] is not used. The
+// slices are copied.
+func NewToeplitz(c, r []float64) *Toeplitz {
+	t := &Toeplitz{
+		c: append([]float64(nil), c...),
+		r: append([]float64(nil), r...),
+	}
+	m, n := len(c), len(r)
+	if m == 0 || n == 0 {
+		return t
+	}
+	size := 1
+	for size < m+n-1 {
+		size <<= 1
+	}
+	t.spec = make([]complex128, size)
+	for i, v := range c {
+		t.spec[i] = complex(v, 0)
+	}
+	for j := 1; j < n; j++ {
+		t.spec[size-j] = complex(r[j], 0)
+	}
+	fft(t.spec, false)
+	return t
+}
+
+func (t *Toeplitz) Dims() (r, c int) { return len(t.c), len(t.r) }
+
+// At returns the element at row r and column c.
+func (t *Toeplitz) At(r, c int) float64 {
+	if r >= len(t.c) || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= len(t.r) || c < 0 {
+		panic(ErrColAccess)
+	}
+	if r >= c {
+		return t.c[r-c]
+	}
+	return t.r[c-r]
+}
+
+// MulVec sets dst to the product of the matrix with x and returns dst. If dst
+// is nil a new slice is allocated. MulVec will panic with ErrShape if x does not
+// have length equal to the number of columns, or a non-nil dst does not have
+// length equal to the number of rows.
+func (t *Toeplitz) MulVec(dst, x []float64) []float64 {
+	m, n := t.Dims()
+	if len(x) != n {
+		panic(ErrShape)
+	}
+	if dst == nil {
+		dst = make([]float64, m)
+	} else if len(dst) != m {
+		panic(ErrShape)
+	}
+	if m == 0 || n == 0 {
+		for i := range dst {
+			dst[i] = 0
+		}
+		return dst
+	}
+	work := make([]complex128, len(t.spec))
+	for j, v := range x {
+		work[j] = complex(v, 0)
+	}
+	fft(work, false)
+	for k, s := range t.spec {
+		work[k] *= s
+	}
+	fft(work, true)
+	for i := range dst {
+		dst[i] = real(work[i])
+	}
+	return dst
+}
+
+// Apply sets dst to the product of the matrix with x, as required by the
+// LinearOperator interface.
+func (t *Toeplitz) Apply(dst, x []float64) {
+	checkApply(t, dst, x)
+	t.MulVec(dst, x)
+}
+
+// Dense returns a newly allocated dense copy of the matrix.
+func (t *Toeplitz) Dense() *Dense {
+	return ToeplitzDense(t.c, t.r)
+}
+
+// Solve returns the solution x of t.x = b for a square t by the Levinson
+// recursion in O(n²) time, which requires each leading principal submatrix of t
+// to be nonsingular, as it is when t is symmetric positive definite. Solve will
+// panic with ErrSquare if t is not square, with ErrShape if b does not have the
+// order of t, and with ErrSingular if a leading principal submatrix is singular.
+func (t *Toeplitz) Solve(b []float64) []float64 {
+	m, n := t.Dims()
+	if m != n {
+		panic(ErrSquare)
+	}
+	if len(b) != n {
+		panic(ErrShape)
+	}
+	x := make([]float64, n)
+	if n == 0 {
+		return x
+	}
+	if t.c[0] == 0 {
+		panic(ErrSingular)
+	}
+
+	// The forward and backward vectors f and b of order k satisfy
+	// T_k.f = e_1 and T_k.b = e_k for the leading k-by-k submatrix T_k,
+	// and are extended by one element at each step along with x.
+	fwd := make([]float64, n)
+	bwd := make([]float64, n)
+	fwd[0] = 1 / t.c[0]
+	bwd[0] = fwd[0]
+	x[0] = b[0] / t.c[0]
+	nf := make([]float64, n)
+	nb := make([]float64, n)
+	for k := 1; k < n; k++ {
+		var ef, eb, ex float64
+		for j := 0; j < k; j++ {
+			ef += t.c[k-j] * fwd[j]
+			eb += t.r[j+1] * bwd[j]
+			ex += t.c[k-j] * x[j]
+		}
+		d := 1 - ef*eb
+		if d == 0 {
+			panic(ErrSingular)
+		}
+
+		// f = ([f; 0] - ef.[0; b]) / d and b = ([0; b] - eb.[f; 0]) / d.
+		for j := 0; j <= k; j++ {
+			var fj, bj float64
+			if j < k {
+				fj = fwd[j]
+			}
+			if j > 0 {
+				bj = bwd[j-1]
+			}
+			nf[j] = (fj - ef*bj) / d
+			nb[j] = (bj - eb*fj) / d
+		}
+		fwd, nf = nf, fwd
+		bwd, nb = nb, bwd
+
+		// x = [x; 0] + (b_k - ex).b
+		for j := 0; j <= k; j++ {
+			x[j] += (b[k] - ex) * bwd[j]
+		}
+	}
+	return x
+}
+
+// Circulant is a square matrix in which each column is the previous one rotated
+// down by one element, held as its first column. Circulant matrices are
+// diagonalized by the discrete Fourier transform, so products with vectors and
+// solutions of linear systems are computed in O(n log n) time.
+type Circulant struct {
+	c []float64
+
+	// eig is the discrete Fourier transform of c, the eigenvalues.
+	eig []complex128
+}
+
+// NewCirculant returns the len(c)-by-len(c) circulant matrix with first column c.
+// The slice is copied.
+func NewCirculant(c []float64) *Circulant {
+	m := &Circulant{
+		c:   append([]float64(nil), c...),
+		eig: make([]complex128, len(c)),
+	}
+	for i, v := range c {
+		m.eig[i] = complex(v, 0)
+	}
+	dft(m.eig, false)
+	return m
+}
+
+func (m *Circulant) Dims() (r, c int) { return len(m.c), len(m.c) }
+
+// At returns the element at row r and column c.
+func (m *Circulant) At(r, c int) float64 {
+	n := len(m.c)
+	if r >= n || r < 0 {
+		panic(ErrRowAccess)
+	}
+	if c >= n || c < 0 {
+		panic(ErrColAccess)
+	}
+	return m.c[(r-c+n)%n]
+}
+
+// Eigenvalues returns the eigenvalues of the matrix, the discrete Fourier
+// transform of its first column. Eigenvalue k corresponds to the eigenvector
+// with elements exp(2πijk/n).
+func (m *Circulant) Eigenvalues() []complex128 {
+	return append([]complex128(nil), m.eig...)
+}
+
+// MulVec sets dst to the product of the matrix with x and returns dst. If dst
+// is nil a new slice is allocated. MulVec will panic with ErrShape if x or a
+// non-nil dst does not have length equal to the order of the matrix.
+func (m *Circulant) MulVec(dst, x []float64) []float64 {
+	n := len(m.c)
+	if len(x) != n {
+		panic(ErrShape)
+	}
+	if dst == nil {
+		dst = make([]float64, n)
+	} else if len(dst) != n {
+		panic(ErrShape)
+	}
+	work := make([]complex128, n)
+	for i, v := range x {
+		work[i] = complex(v, 0)
+	}
+	dft(work, false)
+	for k, e := range m.eig {
+		work[k] *= e
+	}
+	dft(work, true)
+	for i := range dst {
+		dst[i] = real(work[i])
+	}
+	return dst
+}
+
+// Apply sets dst to the product of the matrix with x, as required by the
+// LinearOperator interface.
+func (m *Circulant) Apply(dst, x []float64) {
+	checkApply(m, dst, x)
+	m.MulVec(dst, x)
+}
+
+// Dense returns a newly allocated dense copy of the matrix.
+func (m *Circulant) Dense() *Dense { return CirculantDense(m.c) }
+
+// Solve returns the solution x of m.x = b, dividing the transform of b by the
+// eigenvalues. Solve will panic with ErrShape if b does not have the order of m,
+// and with ErrSingular if m is numerically singular.
+func (m *Circulant) Solve(b []float64) []float64 {
+	n := len(m.c)
+	if len(b) != n {
+		panic(ErrShape)
+	}
+	var norm float64
+	for _, e := range m.eig {
+		norm = math.Max(norm, cmplx.Abs(e))
+	}
+	for _, e := range m.eig {
+		if cmplx.Abs(e) <= float64(n)*epsilon*norm {
+			panic(ErrSingular)
+		}
+	}
+	work := make([]complex128, n)
+	for i, v := range b {
+		work[i] = complex(v, 0)
+	}
+	dft(work, false)
+	for k, e := range m.eig {
+		work[k] /= e
+	}
+	dft(work, true)
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = real(work[i])
+	}
+	return x
+}
+
+// dft computes in place the discrete Fourier transform of x, or the inverse
+// transform including the 1/n scaling if inverse is true, for any length of x.
+// Lengths that are not a power of two use the chirp z-transform of Bluestein,
+// which expresses the transform as a convolution of power of two length.
+func dft(x []complex128, inverse bool) {
+	n := len(x)
+	if n&(n-1) == 0 {
+		fft(x, inverse)
+		return
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	// chirp[j] = exp(sign.πi.j²/n), with j² reduced modulo 2n to
+	// keep the angle accurate.
+	chirp := make([]complex128, n)
+	for j := range chirp {
+		jj := (j * j) % (2 * n)
+		chirp[j] = cmplx.Rect(1, sign*math.Pi*float64(jj)/float64(n))
+	}
+	size := 1
+	for size < 2*n-1 {
+		size <<= 1
+	}
+	a := make([]complex128, size)
+	b := make([]complex128, size)
+	for j, v := range x {
+		a[j] = v * chirp[j]
+	}
+	b[0] = cmplx.Conj(chirp[0])
+	for j := 1; j < n; j++ {
+		b[j] = cmplx.Conj(chirp[j])
+		b[size-j] = b[j]
+	}
+	fft(a, false)
+	fft(b, false)
+	for k := range a {
+		a[k] *= b[k]
+	}
+	fft(a, true)
+	for k := range x {
+		x[k] = a[k] * chirp[k]
+	}
+	if inverse {
+		s := complex(1/float64(n), 0)
+		for i := range x {
+			x[i] *= s
+		}
+	}
+}
+
+// fft computes in place the discrete Fourier transform of x, or the inverse
+// transform including the 1/n scaling if inverse is true, by the iterative
+// radix-2 Cooley-Tukey algorithm. The length of x must be a power of two.
+func fft(x []complex128, inverse bool) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	for size := 2; size <= n; size <<= 1 {
+		w := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			wk := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				u := x[start+k]
+				v := x[start+k+size/2] * wk
+				x[start+k] = u + v
+				x[start+k+size/2] = u - v
+				wk *= w
+			}
+		}
+	}
+	if inverse {
+		s := complex(1/float64(n), 0)
+		for i := range x {
+			x[i] *= s
+		}
+	}
+}
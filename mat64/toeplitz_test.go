@@ -0,0 +1,150 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestDFT(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 5, 8, 12, 17, 64} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+		}
+		got := append([]complex128(nil), x...)
+		dft(got, false)
+		for k := range x {
+			var want complex128
+			for j, v := range x {
+				want += v * cmplx.Rect(1, -2*math.Pi*float64(j*k)/float64(n))
+			}
+			c.Check(cmplx.Abs(got[k]-want) < 1e-10, check.Equals, true, check.Commentf("n=%d k=%d", n, k))
+		}
+		dft(got, true)
+		for i := range x {
+			c.Check(cmplx.Abs(got[i]-x[i]) < 1e-12, check.Equals, true)
+		}
+	}
+}
+
+func (s *S) TestToeplitz(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ m, n int }{
+		{1, 1}, {3, 3}, {5, 2}, {2, 7}, {16, 16}, {31, 9},
+	} {
+		col := make([]float64, test.m)
+		row := make([]float64, test.n)
+		for i := range col {
+			col[i] = rnd.NormFloat64()
+		}
+		for i := range row {
+			row[i] = rnd.NormFloat64()
+		}
+		t := NewToeplitz(col, row)
+		d := t.Dense()
+		c.Check(d.Equals(ToeplitzDense(col, row)), check.Equals, true)
+		for i := 0; i < test.m; i++ {
+			for j := 0; j < test.n; j++ {
+				c.Check(t.At(i, j), check.Equals, d.At(i, j))
+			}
+		}
+
+		x := make([]float64, test.n)
+		for i := range x {
+			x[i] = rnd.NormFloat64()
+		}
+		got := t.MulVec(nil, x)
+		for i := range got {
+			want := dotUnitary(d.rowView(i), x)
+			c.Check(math.Abs(got[i]-want) < 1e-12, check.Equals, true)
+		}
+	}
+	c.Check(NewToeplitz(nil, []float64{1}).MulVec(nil, []float64{2}), check.HasLen, 0)
+
+	// Symmetric positive definite and non-symmetric systems.
+	for _, n := range []int{1, 2, 6, 20} {
+		col := make([]float64, n)
+		row := make([]float64, n)
+		for i := range col {
+			col[i] = math.Pow(0.5, float64(i))
+			row[i] = col[i]
+		}
+		col[0] = 2
+		for _, sym := range []bool{true, false} {
+			if !sym {
+				for i := 1; i < n; i++ {
+					row[i] = 0.3 * rnd.NormFloat64()
+				}
+			}
+			t := NewToeplitz(col, row)
+			b := make([]float64, n)
+			for i := range b {
+				b[i] = rnd.NormFloat64()
+			}
+			x := t.Solve(b)
+			got := t.MulVec(nil, x)
+			for i := range b {
+				c.Check(math.Abs(got[i]-b[i]) < 1e-10, check.Equals, true,
+					check.Commentf("n=%d sym=%t", n, sym))
+			}
+		}
+	}
+	c.Check(func() { NewToeplitz([]float64{1, 2}, []float64{1}).Solve([]float64{1, 2}) }, check.PanicMatches, string(ErrSquare))
+	c.Check(func() { NewToeplitz([]float64{0, 1}, []float64{0, 1}).Solve([]float64{1, 2}) }, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { NewToeplitz([]float64{1, 1}, []float64{1, 1}).Solve([]float64{1, 2}) }, check.PanicMatches, string(ErrSingular))
+}
+
+func (s *S) TestCirculant(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 8, 13} {
+		col := make([]float64, n)
+		for i := range col {
+			col[i] = rnd.NormFloat64()
+		}
+		col[0] += float64(n)
+		m := NewCirculant(col)
+		d := m.Dense()
+		c.Check(d.Equals(CirculantDense(col)), check.Equals, true)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				c.Check(m.At(i, j), check.Equals, d.At(i, j))
+			}
+		}
+
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = rnd.NormFloat64()
+		}
+		got := m.MulVec(nil, x)
+		for i := range got {
+			c.Check(math.Abs(got[i]-dotUnitary(d.rowView(i), x)) < 1e-12, check.Equals, true)
+		}
+
+		sol := m.Solve(x)
+		back := m.MulVec(nil, sol)
+		for i := range back {
+			c.Check(math.Abs(back[i]-x[i]) < 1e-12, check.Equals, true)
+		}
+
+		// Each eigenvalue has the Fourier mode as eigenvector.
+		for k, e := range m.Eigenvalues() {
+			for i := 0; i < n; i++ {
+				var cv complex128
+				for j := 0; j < n; j++ {
+					cv += complex(d.At(i, j), 0) * cmplx.Rect(1, 2*math.Pi*float64(j*k)/float64(n))
+				}
+				want := e * cmplx.Rect(1, 2*math.Pi*float64(i*k)/float64(n))
+				c.Check(cmplx.Abs(cv-want) < 1e-10, check.Equals, true)
+			}
+		}
+	}
+	c.Check(func() { NewCirculant([]float64{1, 1}).Solve([]float64{1, 2}) }, check.PanicMatches, string(ErrSingular))
+}
@@ -0,0 +1,58 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// The functions and methods of the package report misuse, such as mismatched
+// dimensions or a non-square argument to a square operation, and numerical
+// failure, such as a singular system, by panicking with a value of type Error.
+// Any such call may be made error returning by wrapping it with Maybe,
+// MaybeFloat or MaybeDense. The Try functions below are error returning forms
+// of the most common operations for callers, such as servers, that should not
+// recover around each call. Panics that are not of type Error indicate a bug
+// and are not recovered.
+
+// TrySolve returns a matrix x that satisfies a.x = b as for Solve. The returned
+// error is ErrShape if a and b have different numbers of rows, ErrSingular if a
+// is square and singular, and ErrRankDeficient if a is rectangular and rank
+// deficient.
+func TrySolve(a, b Matrix) (*Dense, error) {
+	return MaybeDense(func() *Dense { return Solve(a, b) })
+}
+
+// TryInverse returns the inverse or pseudoinverse of the matrix a as for Inverse.
+// The returned error is as described for TrySolve.
+func TryInverse(a Matrix) (*Dense, error) {
+	return MaybeDense(func() *Dense { return Inverse(a) })
+}
+
+// TryDet returns the determinant of the matrix a. The returned error is
+// ErrSquare if a is not square.
+func TryDet(a Matrix) (float64, error) {
+	if r, c := a.Dims(); r != c {
+		return 0, ErrSquare
+	}
+	return MaybeFloat(func() float64 { return Det(a) })
+}
+
+// TryMul sets the receiver to the matrix product of a and b as for Mul. The
+// returned error is ErrShape if the dimensions of a, b and the receiver are
+// mismatched.
+func (m *Dense) TryMul(a, b Matrix) error {
+	return Maybe(func() { m.Mul(a, b) })
+}
+
+// TryAdd sets the receiver to the sum of a and b as for Add. The returned error
+// is ErrShape if the dimensions of a, b and the receiver are mismatched, and
+// ErrOverlap if the receiver partially overlaps a or b. The receiver is not
+// modified when an error is returned.
+func (m *Dense) TryAdd(a, b Matrix) error {
+	return Maybe(func() { m.Add(a, b) })
+}
+
+// TrySub sets the receiver to the difference of a and b as for Sub. The returned
+// error is as described for TryAdd.
+func (m *Dense) TrySub(a, b Matrix) error {
+	return Maybe(func() { m.Sub(a, b) })
+}
@@ -0,0 +1,55 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestTry(c *check.C) {
+	a := NewDense(2, 2, []float64{2, 1, 1, 3})
+	b := NewDense(2, 1, []float64{3, 4})
+
+	x, err := TrySolve(a, b)
+	c.Check(err, check.IsNil)
+	c.Check(x.EqualsApprox(NewDense(2, 1, []float64{1, 1}), 1e-14), check.Equals, true)
+	_, err = TrySolve(a, NewDense(3, 1, nil))
+	c.Check(err, check.Equals, ErrShape)
+	_, err = TrySolve(NewDense(2, 2, []float64{1, 2, 2, 4}), b)
+	c.Check(err, check.Equals, ErrSingular)
+	_, err = TrySolve(NewDense(3, 2, []float64{1, 0, 2, 0, 3, 0}), NewDense(3, 1, nil))
+	c.Check(err, check.Equals, ErrRankDeficient)
+
+	inv, err := TryInverse(a)
+	c.Check(err, check.IsNil)
+	var id Dense
+	id.Mul(a, inv)
+	c.Check(id.EqualsApprox(identityDense(2), 1e-14), check.Equals, true)
+	_, err = TryInverse(NewDense(2, 2, nil))
+	c.Check(err, check.Equals, ErrSingular)
+
+	det, err := TryDet(a)
+	c.Check(err, check.IsNil)
+	c.Check(det, check.Equals, 5.)
+	_, err = TryDet(NewDense(2, 3, nil))
+	c.Check(err, check.Equals, ErrSquare)
+
+	var m Dense
+	c.Check(m.TryMul(a, b), check.IsNil)
+	c.Check(m.Equals(NewDense(2, 1, []float64{10, 15})), check.Equals, true)
+	c.Check(m.TryMul(b, a), check.Equals, ErrShape)
+
+	var sum Dense
+	c.Check(sum.TryAdd(a, a), check.IsNil)
+	c.Check(sum.TrySub(&sum, a), check.IsNil)
+	c.Check(sum.Equals(a), check.Equals, true)
+	c.Check(sum.TryAdd(a, b), check.Equals, ErrShape)
+	c.Check(sum.Equals(a), check.Equals, true)
+
+	// Index and factorization failures are recoverable errors.
+	c.Check(Maybe(func() { a.At(2, 0) }), check.Equals, ErrRowAccess)
+	c.Check(Maybe(func() { a.Set(0, -1, 0) }), check.Equals, ErrColAccess)
+	c.Check(Maybe(func() { Cholesky(NewDense(2, 2, []float64{1, 2, 2, 1})).Solve(b) }), check.Equals, ErrNotPositiveDefinite)
+}
@@ -27,7 +27,7 @@ var (
 	// _ Scaler  = vector
 	// _ Applyer = vector
 
-	// _ Normer = vector
+	_ Normer = vector
 	// _ Sumer  = vector
 
 	// _ Stacker   = vector
@@ -0,0 +1,167 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+)
+
+// WhiteningMethod specifies the whitening transform computed by NewWhitening.
+type WhiteningMethod int
+
+const (
+	// PCAWhitening rotates the data onto the principal directions of the
+	// covariance and scales them to unit variance, W = Λ^-1/2.V'.
+	PCAWhitening WhiteningMethod = iota
+
+	// ZCAWhitening additionally rotates back to the original coordinates,
+	// W = V.Λ^-1/2.V', giving the whitened data closest to the original
+	// in the least squares sense.
+	ZCAWhitening
+)
+
+// Whitening is a linear transform that maps data to zero mean and identity
+// covariance, by
+//
+//  whitened = (x - Mean).W'
+type Whitening struct {
+	// Mean holds the column means of the data.
+	Mean []float64
+
+	// W is the p-by-p whitening matrix.
+	W *Dense
+}
+
+// NewWhitening returns the whitening transform of the n-by-p data with
+// observations in its rows, computed from the symmetric eigen decomposition
+// V.Λ.V' of the sample covariance matrix. NewWhitening will panic with ErrShape
+// if n is less than two, and with ErrSingular if the covariance matrix is
+// numerically singular. The data are not modified.
+func NewWhitening(data *Dense, method WhiteningMethod) *Whitening {
+	n, p := data.Dims()
+	if n < 2 {
+		panic(ErrShape)
+	}
+	x := DenseCopyOf(data)
+	w := &Whitening{Mean: Center(x, false).Offset}
+
+	var xt, cov Dense
+	xt.TCopy(x)
+	cov.Mul(&xt, x)
+	cov.Scale(1/float64(n-1), &cov)
+	Symmetrize(&cov)
+	eig := Eigen(&cov, epsilon)
+	vals := eig.Values()
+	var maxVal float64
+	for _, v := range vals {
+		maxVal = math.Max(maxVal, real(v))
+	}
+
+	// lw = Λ^-1/2.V'.
+	lw := NewDense(p, p, nil)
+	for i, v := range vals {
+		lambda := real(v)
+		if lambda <= float64(p)*epsilon*maxVal {
+			panic(ErrSingular)
+		}
+		s := 1 / math.Sqrt(lambda)
+		row := lw.rowView(i)
+		for j := range row {
+			row[j] = s * eig.V.At(j, i)
+		}
+	}
+	if method == ZCAWhitening {
+		w.W = NewDense(p, p, nil)
+		w.W.Mul(eig.V, lw)
+		Symmetrize(w.W)
+	} else {
+		w.W = lw
+	}
+	return w
+}
+
+// Transform returns the whitened observations in the rows of x. Transform will
+// panic with ErrShape if x does not have the same number of columns as the data.
+// The matrix x is not modified.
+func (w *Whitening) Transform(x *Dense) *Dense {
+	r, c := x.Dims()
+	if c != len(w.Mean) {
+		panic(ErrShape)
+	}
+	y := DenseCopyOf(x)
+	for i := 0; i < r; i++ {
+		row := y.rowView(i)
+		for j := range row {
+			row[j] -= w.Mean[j]
+		}
+	}
+	var wt Dense
+	wt.TCopy(w.W)
+	z := NewDense(r, c, nil)
+	z.Mul(y, &wt)
+	return z
+}
+
+// Mahalanobis computes Mahalanobis distances from the mean of a distribution,
+// holding the Cholesky factorization of its covariance so that each distance
+// costs a single triangular solve.
+type Mahalanobis struct {
+	mean []float64
+	l    *Dense
+}
+
+// NewMahalanobis returns a Mahalanobis distance for the distribution with the
+// given mean and covariance. The covariance is factorized once on construction
+// and is not modified. NewMahalanobis will panic with ErrShape if cov is not
+// len(mean)-by-len(mean), and with ErrNotPositiveDefinite if cov is not
+// symmetric positive definite.
+func NewMahalanobis(mean []float64, cov *Dense) *Mahalanobis {
+	r, c := cov.Dims()
+	if r != len(mean) || c != len(mean) {
+		panic(ErrShape)
+	}
+	chol := Cholesky(DenseCopyOf(cov))
+	if !chol.SPD {
+		panic(ErrNotPositiveDefinite)
+	}
+	return &Mahalanobis{
+		mean: append([]float64(nil), mean...),
+		l:    chol.L,
+	}
+}
+
+// Distance returns the Mahalanobis distance sqrt((x-μ)'.Σ^-1.(x-μ)) of x from
+// the mean μ. Distance will panic with ErrShape if x does not have the length of
+// the mean.
+func (m *Mahalanobis) Distance(x []float64) float64 {
+	if len(x) != len(m.mean) {
+		panic(ErrShape)
+	}
+	// Solve L.z = x - μ, so that the distance is the norm of z.
+	z := make([]float64, len(x))
+	var d float64
+	for i := range z {
+		row := m.l.rowView(i)
+		z[i] = (x[i] - m.mean[i] - dotUnitary(row[:i], z[:i])) / row[i]
+		d = math.Hypot(d, z[i])
+	}
+	return d
+}
+
+// Distances returns the Mahalanobis distances of the observations in the rows
+// of x from the mean, computed in parallel over the rows.
+func (m *Mahalanobis) Distances(x *Dense) []float64 {
+	r, c := x.Dims()
+	if c != len(m.mean) {
+		panic(ErrShape)
+	}
+	d := make([]float64, r)
+	parallelRows(r, c*c, func(i0, i1 int) {
+		for i := i0; i < i1; i++ {
+			d[i] = m.Distance(x.rowView(i))
+		}
+	})
+	return d
+}
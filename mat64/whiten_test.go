@@ -0,0 +1,88 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	"math"
+	"math/rand"
+
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestWhitening(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	const n, p = 200, 4
+	data := NewNormal(n, p, 0, 1, rnd)
+	data.Mul(data, NewNormal(p, p, 0, 1, rnd))
+	for i := 0; i < n; i++ {
+		data.rowView(i)[2] += 7
+	}
+	orig := DenseCopyOf(data)
+
+	for _, method := range []WhiteningMethod{PCAWhitening, ZCAWhitening} {
+		w := NewWhitening(data, method)
+		c.Check(data.Equals(orig), check.Equals, true)
+		z := w.Transform(data)
+
+		// The whitened data have zero mean and identity covariance.
+		mean := Center(DenseCopyOf(z), false).Offset
+		for _, v := range mean {
+			c.Check(math.Abs(v) < 1e-12, check.Equals, true)
+		}
+		var zt, cov Dense
+		zt.TCopy(z)
+		cov.Mul(&zt, z)
+		cov.Scale(1/float64(n-1), &cov)
+		c.Check(cov.EqualsApprox(identityDense(p), 1e-10), check.Equals, true)
+
+		if method == ZCAWhitening {
+			c.Check(symmetric(w.W), check.Equals, true)
+		}
+	}
+
+	// Collinear columns give a singular covariance.
+	sing := NewDense(3, 2, []float64{1, 2, 2, 4, 3, 6})
+	c.Check(func() { NewWhitening(sing, PCAWhitening) }, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { NewWhitening(NewDense(1, 2, nil), PCAWhitening) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestMahalanobis(c *check.C) {
+	cov := NewDense(2, 2, []float64{
+		4, 0,
+		0, 1,
+	})
+	m := NewMahalanobis([]float64{1, -1}, cov)
+	c.Check(m.Distance([]float64{1, -1}), check.Equals, 0.0)
+	c.Check(math.Abs(m.Distance([]float64{3, -1})-1) < 1e-15, check.Equals, true)
+	c.Check(math.Abs(m.Distance([]float64{1, 1})-2) < 1e-15, check.Equals, true)
+
+	// Compare with the explicit inverse for a general covariance.
+	rnd := rand.New(rand.NewSource(1))
+	const p = 5
+	cov = NewSPDCond(p, 100, rnd)
+	mean := []float64{1, 2, 3, 4, 5}
+	m = NewMahalanobis(mean, cov)
+	inv := Inverse(cov)
+	x := NewNormal(20, p, 0, 3, rnd)
+	dists := m.Distances(x)
+	for i := 0; i < 20; i++ {
+		d := make([]float64, p)
+		for j := range d {
+			d[j] = x.At(i, j) - mean[j]
+		}
+		want := make([]float64, p)
+		for j := range want {
+			want[j] = dotUnitary(inv.rowView(j), d)
+		}
+		w := math.Sqrt(dotUnitary(d, want))
+		c.Check(math.Abs(dists[i]-w) < 1e-10*w, check.Equals, true)
+		c.Check(dists[i], check.Equals, m.Distance(x.rowView(i)))
+	}
+
+	c.Check(func() { NewMahalanobis([]float64{0, 0}, NewDense(2, 2, []float64{1, 2, 2, 1})) },
+		check.PanicMatches, string(ErrNotPositiveDefinite))
+	c.Check(func() { NewMahalanobis([]float64{0}, cov) }, check.PanicMatches, string(ErrShape))
+	c.Check(func() { m.Distance([]float64{0}) }, check.PanicMatches, string(ErrShape))
+}
@@ -0,0 +1,121 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+// ShermanMorrison updates ainv, the inverse of a square matrix a, in place to
+// the inverse of the rank one update a + u.v', using the Sherman-Morrison
+// formula
+//
+//  (a + u.v')^-1 = a^-1 - a^-1.u.v'.a^-1 / (1 + v'.a^-1.u)
+//
+// in O(n²) operations. Repeated updates accumulate rounding error, so long
+// running quasi-Newton and online learning loops should occasionally recompute
+// the inverse. ShermanMorrison will panic with ErrSquare if ainv is not square,
+// with ErrShape if u or v do not have length n, and with ErrSingular if the
+// updated matrix is singular.
+func ShermanMorrison(ainv *Dense, u, v []float64) {
+	n, c := ainv.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	if len(u) != n || len(v) != n {
+		panic(ErrShape)
+	}
+
+	// au = a^-1.u and va = v'.a^-1.
+	au := make([]float64, n)
+	for i := range au {
+		au[i] = dotUnitary(ainv.rowView(i), u)
+	}
+	va := make([]float64, n)
+	mulTransVec(va, ainv, v)
+
+	den := 1 + dotUnitary(v, au)
+	if den == 0 {
+		panic(ErrSingular)
+	}
+	for i, x := range au {
+		if x != 0 {
+			axpyUnitary(-x/den, va, ainv.rowView(i))
+		}
+	}
+}
+
+// Woodbury solves systems with the low rank update a + u.c.v' of a matrix a
+// for which a factorization is available, by the Sherman-Morrison-Woodbury
+// identity, without forming or factorizing the updated matrix. For an n-by-n
+// matrix a and rank k update, each solve costs two solves with the
+// factorization of a and O(n.k) further operations once the update has been
+// prepared.
+type Woodbury struct {
+	a Solver
+
+	// ainvU is a^-1.u and w is c.v', so that the updated matrix
+	// is a + u.w.
+	ainvU, w *Dense
+
+	// capacitance is the LU factorization of I + w.a^-1.u.
+	capacitance LUFactors
+}
+
+// NewWoodbury returns a Woodbury for solving with a + u.c.v', where a is the
+// Solver for the n-by-n matrix a, u and v are n-by-k, and c is k-by-k. If c is
+// nil it is taken to be the identity. The matrices u, c and v are not retained.
+//
+// NewWoodbury will panic with ErrShape if the dimensions of u, c and v do not
+// agree, and with ErrSingular if the updated matrix is singular.
+func NewWoodbury(a Solver, u, c, v Matrix) *Woodbury {
+	n, k := u.Dims()
+	if vn, vk := v.Dims(); vn != n || vk != k {
+		panic(ErrShape)
+	}
+
+	w := NewDense(k, n, nil)
+	w.TCopy(v)
+	if c != nil {
+		if cr, cc := c.Dims(); cr != k || cc != k {
+			panic(ErrShape)
+		}
+		var cw Dense
+		cw.Mul(c, w)
+		w = &cw
+	}
+
+	ainvU := a.Solve(DenseCopyOf(u))
+
+	capacitance := NewDense(k, k, nil)
+	capacitance.Mul(w, ainvU)
+	for i := 0; i < k; i++ {
+		capacitance.Set(i, i, capacitance.At(i, i)+1)
+	}
+	lu := LU(capacitance)
+	if lu.IsSingular() {
+		panic(ErrSingular)
+	}
+	return &Woodbury{a: a, ainvU: ainvU, w: w, capacitance: lu}
+}
+
+// Solve returns the solution x of (a + u.c.v').x = b, computed as
+//
+//  x = y - a^-1.u.(I + c.v'.a^-1.u)^-1.c.v'.y
+//
+// where y = a^-1.b. The matrix b is not modified. Solve will panic with
+// ErrShape if b does not have n rows.
+func (f *Woodbury) Solve(b *Dense) *Dense {
+	n, _ := f.ainvU.Dims()
+	if bm, _ := b.Dims(); bm != n {
+		panic(ErrShape)
+	}
+	y := f.a.Solve(DenseCopyOf(b))
+
+	var wy Dense
+	wy.Mul(f.w, y)
+	z := f.capacitance.Solve(&wy)
+
+	var correction Dense
+	correction.Mul(f.ainvU, z)
+	y.Sub(y, &correction)
+	return y
+}
@@ -0,0 +1,102 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat64
+
+import (
+	check "launchpad.net/gocheck"
+)
+
+func (s *S) TestShermanMorrison(c *check.C) {
+	a := NewDense(3, 3, []float64{
+		4, 1, 0,
+		1, 3, 1,
+		0, 1, 2,
+	})
+	u := []float64{1, 2, -1}
+	v := []float64{0.5, -1, 2}
+
+	ainv := Inverse(DenseCopyOf(a))
+	ShermanMorrison(ainv, u, v)
+
+	upd := DenseCopyOf(a)
+	for i := range u {
+		for j := range v {
+			upd.Set(i, j, upd.At(i, j)+u[i]*v[j])
+		}
+	}
+	c.Check(ainv.EqualsApprox(Inverse(upd), 1e-12), check.Equals, true)
+
+	// Removing a rank one term that makes the matrix singular.
+	ainv = Inverse(NewDense(2, 2, []float64{2, 0, 0, 1}))
+	c.Check(func() { ShermanMorrison(ainv, []float64{-2, 0}, []float64{1, 0}) }, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { ShermanMorrison(ainv, []float64{1}, []float64{1, 0}) }, check.PanicMatches, string(ErrShape))
+}
+
+func (s *S) TestWoodbury(c *check.C) {
+	a := NewDense(4, 4, []float64{
+		5, 1, 0, 1,
+		1, 4, 1, 0,
+		0, 1, 3, 1,
+		1, 0, 1, 6,
+	})
+	u := NewDense(4, 2, []float64{
+		1, 0,
+		2, 1,
+		0, 1,
+		-1, 3,
+	})
+	v := NewDense(4, 2, []float64{
+		0.5, 1,
+		0, -1,
+		1, 0,
+		2, 1,
+	})
+	cm := NewDense(2, 2, []float64{
+		2, 1,
+		0, 1,
+	})
+	b := NewDense(4, 2, []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+		7, 8,
+	})
+
+	for _, t := range []struct {
+		name string
+		a    Solver
+		c    Matrix
+	}{
+		{"LU", LU(DenseCopyOf(a)), cm},
+		{"LU identity", LU(DenseCopyOf(a)), nil},
+		{"Cholesky", Cholesky(a), cm},
+		{"LDL", LDL(DenseCopyOf(a)), cm},
+	} {
+		// Form and solve the updated matrix directly.
+		var vt, ucvt Dense
+		vt.TCopy(v)
+		if t.c != nil {
+			var uc Dense
+			uc.Mul(u, t.c)
+			ucvt.Mul(&uc, &vt)
+		} else {
+			ucvt.Mul(u, &vt)
+		}
+		var upd Dense
+		upd.Add(a, &ucvt)
+		want := LU(&upd).Solve(DenseCopyOf(b))
+
+		orig := DenseCopyOf(b)
+		got := NewWoodbury(t.a, u, t.c, v).Solve(b)
+		c.Check(got.EqualsApprox(want, 1e-12), check.Equals, true, check.Commentf("%s", t.name))
+		c.Check(b.Equals(orig), check.Equals, true, check.Commentf("%s", t.name))
+	}
+
+	// a - a = 0 is singular.
+	id := identityDense(2)
+	neg := NewDense(2, 2, []float64{-1, 0, 0, -1})
+	c.Check(func() { NewWoodbury(LU(identityDense(2)), id, nil, neg) }, check.PanicMatches, string(ErrSingular))
+	c.Check(func() { NewWoodbury(LU(identityDense(2)), id, nil, NewDense(2, 1, nil)) }, check.PanicMatches, string(ErrShape))
+}